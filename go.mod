@@ -5,6 +5,9 @@ go 1.15
 replace k8s.io/client-go => k8s.io/client-go v0.20.0
 
 require (
+	filippo.io/age v1.0.0
+	github.com/modern-go/reflect2 v1.0.2 // indirect
+	github.com/prometheus/client_golang v1.7.1
 	github.com/rancher/eks-operator v1.0.6-rc1
 	github.com/rancher/fleet/pkg/apis v0.0.0-20210203165831-44af1553b47e
 	github.com/rancher/lasso v0.0.0-20200905045615-7fcb07d6a20b
@@ -15,7 +18,13 @@ require (
 	github.com/rancher/wrangler v0.7.3-0.20201028210318-d73835950c29
 	github.com/sirupsen/logrus v1.6.0
 	github.com/urfave/cli v1.22.2
+	go.opentelemetry.io/otel v1.0.0
+	go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc v1.0.0
+	go.opentelemetry.io/otel/sdk v1.0.0
+	go.opentelemetry.io/otel/trace v1.0.0
+	golang.org/x/time v0.0.0-20200630173020-3af7569d3a1e
 	k8s.io/api v0.20.0
+	k8s.io/apiextensions-apiserver v0.18.0
 	k8s.io/apimachinery v0.20.0
 	k8s.io/client-go v12.0.0+incompatible
 )
@@ -1,6 +1,7 @@
 //go:generate go run pkg/codegen/cleanup/main.go
 //go:generate go run pkg/codegen/main.go
 //go:generate go run main.go --write-crds ./charts/rancher-operator-crd/templates/crds.yaml
+//go:generate go run main.go --webhook-address :9443 --write-rbac ./charts/rancher-operator/templates/clusterrole.yaml
 
 package main
 
@@ -8,15 +9,27 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/rancher/rancher-operator/pkg/clients"
 	"github.com/rancher/rancher-operator/pkg/controllers"
 	"github.com/rancher/rancher-operator/pkg/crd"
+	"github.com/rancher/rancher-operator/pkg/downstream"
+	"github.com/rancher/rancher-operator/pkg/features"
+	"github.com/rancher/rancher-operator/pkg/leader"
+	"github.com/rancher/rancher-operator/pkg/logging"
+	"github.com/rancher/rancher-operator/pkg/options"
+	"github.com/rancher/rancher-operator/pkg/rbac"
 	"github.com/rancher/wrangler/pkg/kubeconfig"
 	"github.com/rancher/wrangler/pkg/signals"
+	"github.com/rancher/wrangler/pkg/slice"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli"
 
 	_ "github.com/rancher/wrangler/pkg/generated/controllers/apiextensions.k8s.io/v1beta1"
+	// the image has no zoneinfo, maintenance windows can be in any time zone
+	_ "time/tzdata"
 )
 
 var (
@@ -25,6 +38,16 @@ var (
 	KubeConfig string
 	Context    string
 	WriteCRDs  string
+	WriteRBAC  string
+	LogLevel   string
+	LogFormat  string
+	Options    options.Options
+
+	FleetWorkspaceMapping  string
+	AllowedFleetWorkspaces string
+	Controllers            string
+	WatchNamespaces        string
+	FeatureGates           string
 )
 
 func main() {
@@ -42,10 +65,369 @@ func main() {
 			EnvVar:      "CONTEXT",
 			Destination: &Context,
 		},
+		cli.StringFlag{
+			Name:        "management-kubeconfig",
+			EnvVar:      "MANAGEMENT_KUBECONFIG",
+			Usage:       "Kubeconfig of the cluster Rancher runs in, when it isn't the cluster of the rancher.cattle.io objects",
+			Destination: &Options.ManagementKubeConfig,
+		},
+		cli.StringFlag{
+			Name:        "management-url",
+			EnvVar:      "MANAGEMENT_URL",
+			Usage:       "Kube API of the cluster Rancher runs in, like https://rancher.example.com/k8s/clusters/local, used with --management-token instead of --management-kubeconfig",
+			Destination: &Options.ManagementURL,
+		},
+		cli.StringFlag{
+			Name:        "management-token",
+			EnvVar:      "MANAGEMENT_TOKEN",
+			Usage:       "Bearer token for --management-url",
+			Destination: &Options.ManagementToken,
+		},
+		cli.StringFlag{
+			Name:        "management-ca-file",
+			EnvVar:      "MANAGEMENT_CA_FILE",
+			Usage:       "CA certificate of --management-url, the system roots are used when unset",
+			Destination: &Options.ManagementCAFile,
+		},
+		cli.StringFlag{
+			Name:        "log-level",
+			EnvVar:      "LOG_LEVEL",
+			Value:       "info",
+			Usage:       "Log level, one of trace, debug, info, warn or error",
+			Destination: &LogLevel,
+		},
+		cli.StringFlag{
+			Name:        "log-format",
+			EnvVar:      "LOG_FORMAT",
+			Value:       logging.FormatText,
+			Usage:       "Log format, text or json",
+			Destination: &LogFormat,
+		},
 		cli.StringFlag{
 			Name:        "write-crds",
 			Destination: &WriteCRDs,
 		},
+		cli.StringFlag{
+			Name:        "write-rbac",
+			Destination: &WriteRBAC,
+		},
+		cli.DurationFlag{
+			Name:        "deletion-grace-period",
+			EnvVar:      "DELETION_GRACE_PERIOD",
+			Usage:       "Time to wait after a cluster is deleted before its generated objects are removed",
+			Destination: &Options.DeletionGracePeriod,
+		},
+		cli.DurationFlag{
+			Name:        "cache-sync-timeout",
+			EnvVar:      "CACHE_SYNC_TIMEOUT",
+			Usage:       "Time the leader may take to sync its caches before it exits naming the kinds still syncing, 0 to wait forever",
+			Value:       5 * time.Minute,
+			Destination: &Options.CacheSyncTimeout,
+		},
+		cli.StringFlag{
+			Name:        "instance-name",
+			EnvVar:      "INSTANCE_NAME",
+			Usage:       "Name of this operator instance, the management clusters and secrets applied by an instance with another name are never updated or pruned",
+			Value:       "rancher-operator",
+			Destination: &Options.InstanceName,
+		},
+		cli.DurationFlag{
+			Name:        "resync-period",
+			EnvVar:      "RESYNC_PERIOD",
+			Usage:       "Time between requeues of every cached object, 0 to disable",
+			Value:       10 * time.Hour,
+			Destination: &Options.ResyncPeriod,
+		},
+		cli.Float64Flag{
+			Name:        "resync-jitter",
+			EnvVar:      "RESYNC_JITTER",
+			Usage:       "Fraction of --resync-period added at random to the period of each kind, so kinds aren't resynced at the same time",
+			Value:       0.2,
+			Destination: &Options.ResyncJitter,
+		},
+		cli.DurationFlag{
+			Name:        "shutdown-timeout",
+			EnvVar:      "SHUTDOWN_TIMEOUT",
+			Usage:       "Time to wait for running reconciles on shutdown before the leader lock is released",
+			Value:       20 * time.Second,
+			Destination: &Options.ShutdownTimeout,
+		},
+		cli.DurationFlag{
+			Name:        "provisioning-timeout",
+			EnvVar:      "PROVISIONING_TIMEOUT",
+			Usage:       "Time a cluster may stay not ready before it is marked Stalled, 0 to disable, overridden by spec.provisioningTimeoutSeconds",
+			Destination: &Options.ProvisioningTimeout,
+		},
+		cli.DurationFlag{
+			Name:        "registration-token-ttl",
+			EnvVar:      "REGISTRATION_TOKEN_TTL",
+			Usage:       "Time a registration token of an imported cluster is used before it is rotated and the older ones are deleted, 0 to never rotate",
+			Value:       24 * time.Hour,
+			Destination: &Options.RegistrationTokenTTL,
+		},
+		cli.StringFlag{
+			Name:        "argocd-namespace",
+			EnvVar:      "ARGOCD_NAMESPACE",
+			Usage:       "Namespace to write ArgoCD cluster secrets to for every ready cluster",
+			Destination: &Options.ArgoCDNamespace,
+		},
+		cli.StringFlag{
+			Name:        "encryption-key-file",
+			EnvVar:      "ENCRYPTION_KEY_FILE",
+			Usage:       "File containing the base64 encoded AES key used to decrypt ENC[aesgcm,...] values in cluster specs",
+			Destination: &Options.EncryptionKeyFile,
+		},
+		cli.StringFlag{
+			Name:        "age-identity-file",
+			EnvVar:      "AGE_IDENTITY_FILE",
+			Usage:       "File containing the age identities used to decrypt ENC[age,...] values in cluster specs",
+			Destination: &Options.AgeIdentityFile,
+		},
+		cli.StringFlag{
+			Name:        "version-skew-policy",
+			EnvVar:      "VERSION_SKEW_POLICY",
+			Usage:       "Check requested kubernetes versions against the management cluster version, either warn or enforce",
+			Destination: &Options.VersionSkewPolicy,
+		},
+		cli.StringFlag{
+			Name:        "orphan-cluster-policy",
+			EnvVar:      "ORPHAN_CLUSTER_POLICY",
+			Usage:       "Handle management clusters whose cluster no longer exists, either flag to annotate them with rancher.cattle.io/orphaned or delete, disabled if empty",
+			Destination: &Options.OrphanClusterPolicy,
+		},
+		cli.StringFlag{
+			Name:        "kubeconfig-ca-bundle-file",
+			EnvVar:      "KUBECONFIG_CA_BUNDLE_FILE",
+			Usage:       "PEM CA bundle embedded in generated kubeconfigs instead of the CA served by Rancher",
+			Destination: &Options.KubeConfigCABundleFile,
+		},
+		cli.StringFlag{
+			Name:        "metrics-address",
+			EnvVar:      "METRICS_ADDRESS",
+			Usage:       "Address to serve prometheus metrics on, for example :8080",
+			Destination: &Options.MetricsAddress,
+		},
+		cli.StringFlag{
+			Name:        "health-address",
+			EnvVar:      "HEALTH_ADDRESS",
+			Value:       ":8081",
+			Usage:       "Address to serve the /healthz and /readyz probes on, empty to disable",
+			Destination: &Options.HealthAddress,
+		},
+		cli.StringFlag{
+			Name:        "debug-address",
+			EnvVar:      "DEBUG_ADDRESS",
+			Usage:       "Address to serve pprof profiles on /debug/pprof/ and the state of the controllers on /debug/controllers, empty to disable",
+			Destination: &Options.DebugAddress,
+		},
+		cli.StringFlag{
+			Name:        "webhook-address",
+			EnvVar:      "WEBHOOK_ADDRESS",
+			Usage:       "Address to serve the admission webhooks on, for example :9443",
+			Destination: &Options.WebhookAddress,
+		},
+		cli.StringFlag{
+			Name:        "webhook-cert-dir",
+			EnvVar:      "WEBHOOK_CERT_DIR",
+			Value:       "/etc/rancher-operator/webhook",
+			Usage:       "Directory with the tls.crt and tls.key the admission webhooks are served with",
+			Destination: &Options.WebhookCertDir,
+		},
+		cli.StringFlag{
+			Name:        "webhook-service",
+			EnvVar:      "WEBHOOK_SERVICE",
+			Value:       "rancher-operator-webhook",
+			Usage:       "Service of the admission webhooks, CRDs are converted through it when the cert dir has a ca.crt",
+			Destination: &Options.WebhookService,
+		},
+		cli.StringFlag{
+			Name:        "config-namespace",
+			EnvVar:      "NAMESPACE",
+			Usage:       "Namespace of the rancher-operator-config config map, its settings are reloaded when it changes",
+			Destination: &Options.ConfigNamespace,
+		},
+		cli.StringFlag{
+			Name:        "webhook-namespace",
+			EnvVar:      "NAMESPACE",
+			Usage:       "Namespace of the webhook service",
+			Destination: &Options.WebhookNamespace,
+		},
+		cli.IntFlag{
+			Name:        "max-clusters-per-namespace",
+			EnvVar:      "MAX_CLUSTERS_PER_NAMESPACE",
+			Usage:       "Number of clusters the webhook allows per namespace, 0 for unlimited, overridden by the rancher.cattle.io/cluster-quota namespace annotation",
+			Destination: &Options.MaxClustersPerNamespace,
+		},
+		cli.StringFlag{
+			Name:        "fleet-workspace-mapping",
+			EnvVar:      "FLEET_WORKSPACE_MAPPING",
+			Usage:       "Comma separated namespace=workspace pairs of the fleet workspace of clusters without spec.fleetWorkspaceName, * matches any other namespace, by default clusters use the workspace of their namespace name",
+			Destination: &FleetWorkspaceMapping,
+		},
+		cli.StringFlag{
+			Name:        "allowed-fleet-workspaces",
+			EnvVar:      "ALLOWED_FLEET_WORKSPACES",
+			Usage:       "Comma separated fleet workspaces any cluster may set in spec.fleetWorkspaceName, besides the workspace of its namespace name and the one fleet-workspace-mapping maps it to",
+			Destination: &AllowedFleetWorkspaces,
+		},
+		cli.Float64Flag{
+			Name:        "kube-api-qps",
+			EnvVar:      "KUBE_API_QPS",
+			Usage:       "Requests per second sent to the Kubernetes API, 0 for the client-go default",
+			Destination: &Options.KubeAPIQPS,
+		},
+		cli.IntFlag{
+			Name:        "kube-api-burst",
+			EnvVar:      "KUBE_API_BURST",
+			Usage:       "Requests sent to the Kubernetes API in a burst above --kube-api-qps, 0 for the client-go default",
+			Destination: &Options.KubeAPIBurst,
+		},
+		cli.Float64Flag{
+			Name:        "management-api-qps",
+			EnvVar:      "MANAGEMENT_API_QPS",
+			Usage:       "Requests per second sent to the management.cattle.io and Rancher APIs, 0 to only apply --kube-api-qps",
+			Destination: &Options.ManagementAPIQPS,
+		},
+		cli.IntFlag{
+			Name:        "management-api-burst",
+			EnvVar:      "MANAGEMENT_API_BURST",
+			Usage:       "Requests sent to the management.cattle.io and Rancher APIs in a burst above --management-api-qps",
+			Destination: &Options.ManagementAPIBurst,
+		},
+		cli.Float64Flag{
+			Name:        "cluster-reconcile-qps",
+			EnvVar:      "CLUSTER_RECONCILE_QPS",
+			Usage:       "Requeued clusters reconciled per second, 0 for the default of the controllers",
+			Destination: &Options.ClusterReconcileQPS,
+		},
+		cli.IntFlag{
+			Name:        "cluster-reconcile-burst",
+			EnvVar:      "CLUSTER_RECONCILE_BURST",
+			Usage:       "Requeued clusters reconciled in a burst above --cluster-reconcile-qps",
+			Destination: &Options.ClusterReconcileBurst,
+		},
+		cli.StringFlag{
+			Name:        "shard-label",
+			EnvVar:      "SHARD_LABEL",
+			Usage:       "Label of clusters selecting the operator deployment that reconciles them",
+			Destination: &Options.ShardLabel,
+		},
+		cli.StringFlag{
+			Name:        "shard-value",
+			EnvVar:      "SHARD_VALUE",
+			Usage:       "Value of --shard-label of the clusters reconciled by this deployment, empty for the clusters without the label",
+			Destination: &Options.ShardValue,
+		},
+		cli.StringFlag{
+			Name:        "watch-namespaces",
+			EnvVar:      "WATCH_NAMESPACES",
+			Usage:       "Comma separated namespaces to reconcile rancher.cattle.io objects in, by default all namespaces are watched",
+			Destination: &WatchNamespaces,
+		},
+		cli.StringFlag{
+			Name:        "feature-gates",
+			EnvVar:      "FEATURE_GATES",
+			Usage:       "Comma separated <feature>=<true|false> pairs turning experimental features on or off, known features are " + strings.Join(features.Names(), ", "),
+			Destination: &FeatureGates,
+		},
+		cli.StringFlag{
+			Name:        "tracing-endpoint",
+			EnvVar:      "OTEL_EXPORTER_OTLP_ENDPOINT",
+			Usage:       "OTLP gRPC collector address to export traces to, for example otel-collector:4317",
+			Destination: &Options.TracingEndpoint,
+		},
+		cli.BoolFlag{
+			Name:        "tracing-insecure",
+			EnvVar:      "OTEL_EXPORTER_OTLP_INSECURE",
+			Usage:       "Export traces without TLS",
+			Destination: &Options.TracingInsecure,
+		},
+		cli.IntFlag{
+			Name:        "downstream-concurrency",
+			EnvVar:      "DOWNSTREAM_CONCURRENCY",
+			Usage:       "Maximum number of concurrent connections to downstream clusters",
+			Value:       downstream.DefaultConcurrency,
+			Destination: &Options.DownstreamConcurrency,
+		},
+		cli.IntFlag{
+			Name:        "downstream-cluster-concurrency",
+			EnvVar:      "DOWNSTREAM_CLUSTER_CONCURRENCY",
+			Usage:       "Maximum number of concurrent connections to a single downstream cluster",
+			Value:       downstream.DefaultClusterConcurrency,
+			Destination: &Options.DownstreamClusterConcurrency,
+		},
+		cli.IntFlag{
+			Name:        "workers",
+			EnvVar:      "WORKERS",
+			Usage:       "Number of objects of a kind reconciled concurrently",
+			Value:       clients.DefaultWorkers,
+			Destination: &Options.Workers,
+		},
+		cli.IntFlag{
+			Name:        "cluster-workers",
+			EnvVar:      "CLUSTER_WORKERS",
+			Usage:       "Number of clusters reconciled concurrently, 0 to use --workers",
+			Destination: &Options.ClusterWorkers,
+		},
+		cli.IntFlag{
+			Name:        "kubeconfig-workers",
+			EnvVar:      "KUBECONFIG_WORKERS",
+			Usage:       "Number of rancher tokens issuing kubeconfigs reconciled concurrently, 0 to use --workers",
+			Destination: &Options.KubeconfigWorkers,
+		},
+		cli.IntFlag{
+			Name:        "project-workers",
+			EnvVar:      "PROJECT_WORKERS",
+			Usage:       "Number of projects reconciled concurrently, 0 to use --workers",
+			Destination: &Options.ProjectWorkers,
+		},
+		cli.IntFlag{
+			Name:        "fleet-bundle-workers",
+			EnvVar:      "FLEET_BUNDLE_WORKERS",
+			Usage:       "Number of fleet bundles reconciled concurrently, 0 to use --workers",
+			Destination: &Options.FleetBundleWorkers,
+		},
+		cli.StringFlag{
+			Name:        "controllers",
+			EnvVar:      "CONTROLLERS",
+			Usage:       "Comma separated controllers to run, by default all of them run. Known controllers are " + strings.Join(rbac.Controllers(), ", "),
+			Destination: &Controllers,
+		},
+		cli.StringFlag{
+			Name:        "leader-election-namespace",
+			EnvVar:      "LEADER_ELECTION_NAMESPACE",
+			Usage:       "Namespace of the lock the replicas elect the leader running the controllers with",
+			Value:       leader.DefaultNamespace,
+			Destination: &Options.LeaderElectionNamespace,
+		},
+		cli.StringFlag{
+			Name:        "leader-election-name",
+			EnvVar:      "LEADER_ELECTION_NAME",
+			Usage:       "Name of the leader election lock",
+			Value:       leader.DefaultName,
+			Destination: &Options.LeaderElectionName,
+		},
+		cli.DurationFlag{
+			Name:        "leader-election-lease-duration",
+			EnvVar:      "LEADER_ELECTION_LEASE_DURATION",
+			Usage:       "Time a replica waits before taking over the lock of a leader that stopped renewing it",
+			Value:       leader.DefaultLeaseDuration,
+			Destination: &Options.LeaderElectionLeaseDuration,
+		},
+		cli.DurationFlag{
+			Name:        "leader-election-renew-deadline",
+			EnvVar:      "LEADER_ELECTION_RENEW_DEADLINE",
+			Usage:       "Time the leader keeps retrying to renew the lock before it exits",
+			Value:       leader.DefaultRenewDeadline,
+			Destination: &Options.LeaderElectionRenewDeadline,
+		},
+		cli.DurationFlag{
+			Name:        "leader-election-retry-period",
+			EnvVar:      "LEADER_ELECTION_RETRY_PERIOD",
+			Usage:       "Time between attempts to acquire or renew the lock",
+			Value:       leader.DefaultRetryPeriod,
+			Destination: &Options.LeaderElectionRetryPeriod,
+		},
 	}
 	app.Action = run
 
@@ -55,19 +437,60 @@ func main() {
 }
 
 func run(c *cli.Context) error {
+	if err := logging.Setup(LogLevel, LogFormat); err != nil {
+		return err
+	}
+
 	if WriteCRDs != "" {
 		logrus.Info("Writing CRDS to ", WriteCRDs)
 		return crd.WriteFile(WriteCRDs)
 	}
 
+	Options.Controllers = options.ParseList(Controllers)
+	for _, controller := range Options.Controllers {
+		if !slice.ContainsString(rbac.Controllers(), controller) {
+			return fmt.Errorf("unknown controller %q, known controllers are %s", controller, strings.Join(rbac.Controllers(), ", "))
+		}
+	}
+	if err := features.Set(FeatureGates); err != nil {
+		return fmt.Errorf("invalid feature-gates: %w", err)
+	}
+
+	if WriteRBAC != "" {
+		logrus.Info("Writing RBAC to ", WriteRBAC)
+		return rbac.WriteFile(WriteRBAC, rbacControllers(), features.EnabledNames())
+	}
+
+	workspaces, err := options.ParseMapping(FleetWorkspaceMapping)
+	if err != nil {
+		return fmt.Errorf("invalid fleet-workspace-mapping: %w", err)
+	}
+	Options.FleetWorkspaces = workspaces
+	Options.AllowedFleetWorkspaces = options.ParseList(AllowedFleetWorkspaces)
+	Options.WatchNamespaces = options.ParseList(WatchNamespaces)
+
 	logrus.Info("Starting controller")
 	ctx := signals.SetupSignalHandler(context.Background())
 	clientConfig := kubeconfig.GetNonInteractiveClientConfigWithContext(KubeConfig, Context)
 
-	if err := controllers.Register(ctx, "", clientConfig); err != nil {
+	if err := controllers.Register(ctx, "", clientConfig, Options); err != nil {
 		return err
 	}
 
 	<-ctx.Done()
 	return nil
 }
+
+// rbacControllers are the controllers and webhooks run with the options, their rules are written by --write-rbac
+func rbacControllers() []string {
+	var controllers []string
+	for _, controller := range rbac.Controllers() {
+		if Options.Enabled(controller) {
+			controllers = append(controllers, controller)
+		}
+	}
+	if Options.WebhookAddress != "" {
+		controllers = append(controllers, rbac.Webhook)
+	}
+	return controllers
+}
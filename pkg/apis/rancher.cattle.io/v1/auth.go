@@ -1,10 +1,18 @@
 package v1
 
 import (
+	"github.com/rancher/wrangler/pkg/condition"
+	"github.com/rancher/wrangler/pkg/genericcondition"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// RoleTemplateBindingConditionSubjectsResolved is set on role template bindings, global role template
+	// bindings and global role bindings, false lists the subjects that have no principal
+	RoleTemplateBindingConditionSubjectsResolved condition.Cond = "SubjectsResolved"
+)
+
 // +genclient
 // +genclient:nonNamespaced
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -13,11 +21,20 @@ type RoleTemplate struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Rules  []rbacv1.PolicyRule `json:"rules,omitempty"`
-	Status RoleTemplateStatus  `json:"status,omitempty"`
+	DisplayName string              `json:"displayName,omitempty"`
+	Rules       []rbacv1.PolicyRule `json:"rules,omitempty"`
+	// Context is either cluster or project, the scope the role template can be bound in
+	Context string `json:"context,omitempty"`
+	// RoleTemplateNames are role templates whose rules are inherited, managed here or built in to Rancher
+	RoleTemplateNames []string `json:"roleTemplateNames,omitempty"`
+	// Locked role templates can not be used in new bindings
+	Locked bool `json:"locked,omitempty"`
+
+	Status RoleTemplateStatus `json:"status,omitempty"`
 }
 
 type RoleTemplateStatus struct {
+	ObservedGeneration int64 `json:"observedGeneration"`
 }
 
 // +genclient
@@ -29,19 +46,90 @@ type RoleTemplateBinding struct {
 
 	RoleTemplateName string                   `json:"roleTemplateName,omitempty"`
 	BindingScope     RoleTemplateBindingScope `json:"bindingScope,omitempty"`
-	// Subjects of only kind User/Group and apiGroup rancher.cattle.io are supported
+	// Subjects of only kind User/Group and apiGroup rancher.cattle.io are supported, the name is either looked up,
+	// as <provider>:<name> to pick one auth provider, or used as the principal ID when it contains ://
 	Subjects []rbacv1.Subject `json:"subjects,omitempty"`
 
 	Status RoleTemplateBindingStatus `json:"status,omitempty"`
 }
 
 type RoleTemplateBindingStatus struct {
+	ObservedGeneration int64                               `json:"observedGeneration"`
+	Conditions         []genericcondition.GenericCondition `json:"conditions,omitempty"`
 }
 
 type RoleTemplateBindingScope struct {
 	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// Name selects a single cluster or project, in addition to the ones matched by Selector
+	Name string `json:"name,omitempty"`
 	// Kind only Cluster and Project are supported
 	Kind string `json:"kind,omitempty"`
 	// APIGroup only rancher.cattle.io is supported
 	APIGroup string `json:"apiGroup,omitempty"`
 }
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// GlobalRoleTemplateBinding binds a role template to subjects in every cluster matched by ClusterSelector, in all
+// namespaces, including clusters created or labeled later
+type GlobalRoleTemplateBinding struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	RoleTemplateName string                `json:"roleTemplateName,omitempty"`
+	ClusterSelector  *metav1.LabelSelector `json:"clusterSelector,omitempty"`
+	// Subjects are supported as in RoleTemplateBinding
+	Subjects []rbacv1.Subject `json:"subjects,omitempty"`
+
+	Status GlobalRoleTemplateBindingStatus `json:"status,omitempty"`
+}
+
+type GlobalRoleTemplateBindingStatus struct {
+	ObservedGeneration int64                               `json:"observedGeneration"`
+	Conditions         []genericcondition.GenericCondition `json:"conditions,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// GlobalRole is a Rancher wide role, changes made to the management global role are reverted
+type GlobalRole struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	DisplayName string              `json:"displayName,omitempty"`
+	Rules       []rbacv1.PolicyRule `json:"rules,omitempty"`
+	// NewUserDefault binds the role to every user when they are created
+	NewUserDefault bool `json:"newUserDefault,omitempty"`
+
+	Status GlobalRoleStatus `json:"status,omitempty"`
+}
+
+type GlobalRoleStatus struct {
+	ObservedGeneration int64 `json:"observedGeneration"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// GlobalRoleBinding binds a global role, managed here or built in to Rancher such as admin or user-base, to
+// subjects
+type GlobalRoleBinding struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	GlobalRoleName string `json:"globalRoleName,omitempty"`
+	// Subjects are looked up as in RoleTemplateBinding, users must have logged in to Rancher once
+	Subjects []rbacv1.Subject `json:"subjects,omitempty"`
+
+	Status GlobalRoleBindingStatus `json:"status,omitempty"`
+}
+
+type GlobalRoleBindingStatus struct {
+	ObservedGeneration int64                               `json:"observedGeneration"`
+	Conditions         []genericcondition.GenericCondition `json:"conditions,omitempty"`
+}
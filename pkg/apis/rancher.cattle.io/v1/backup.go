@@ -0,0 +1,55 @@
+package v1
+
+import (
+	"github.com/rancher/wrangler/pkg/condition"
+	"github.com/rancher/wrangler/pkg/genericcondition"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ClusterBackupKey is the key of the tarball in the secret of a backup
+	ClusterBackupKey = "backup.tar.gz"
+	// ClusterBackupAnnotation is set to the UID of the backup on the objects it restored
+	ClusterBackupAnnotation = "rancher.cattle.io/cluster-backup"
+
+	ClusterBackupConditionCompleted condition.Cond = "Completed"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterBackup captures the cluster ClusterName, in the same namespace, with its projects, role template bindings,
+// node pools, node templates and the secrets and config maps they reference into a tarball, or restores the objects
+// of a tarball into its namespace when Restore is set. The backup runs once, create a new object to run it again.
+type ClusterBackup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterBackupSpec   `json:"spec"`
+	Status ClusterBackupStatus `json:"status,omitempty"`
+}
+
+type ClusterBackupSpec struct {
+	ClusterName string `json:"clusterName,omitempty"`
+	// UploadURLSecretName is a secret whose url key is an HTTP URL, such as a presigned object store URL, the tarball
+	// is put to. Without it the tarball is written to a secret named after the backup and owned by it.
+	UploadURLSecretName string                `json:"uploadURLSecretName,omitempty"`
+	Restore             *ClusterBackupRestore `json:"restore,omitempty"`
+}
+
+// ClusterBackupRestore reads the tarball from the secret SecretName or from the url key of the secret
+// DownloadURLSecretName, both in the namespace of the backup
+type ClusterBackupRestore struct {
+	SecretName            string `json:"secretName,omitempty"`
+	DownloadURLSecretName string `json:"downloadURLSecretName,omitempty"`
+	// Overwrite updates objects that already exist, otherwise the restore fails on them
+	Overwrite bool `json:"overwrite,omitempty"`
+}
+
+type ClusterBackupStatus struct {
+	ObservedGeneration int64  `json:"observedGeneration"`
+	CompletedAt        string `json:"completedAt,omitempty"`
+	// Objects are the backed up or restored objects as <kind>/<name>
+	Objects    []string                            `json:"objects,omitempty"`
+	Conditions []genericcondition.GenericCondition `json:"conditions,omitempty"`
+}
@@ -1,13 +1,66 @@
 package v1
 
 import (
+	"time"
+
 	eksv1 "github.com/rancher/eks-operator/pkg/apis/eks.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/condition"
 	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
 	rketypes "github.com/rancher/rke/types"
-	"github.com/rancher/wrangler/pkg/genericcondition"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	KubeConfigFormatToken          = "token"
+	KubeConfigFormatExec           = "exec"
+	KubeConfigFormatServiceAccount = "serviceAccount"
+
+	// FleetLabelsAnnotation on the management cluster holds spec.fleetLabels as JSON for the fleet cluster
+	FleetLabelsAnnotation = "rancher.cattle.io/fleet-labels"
+	// FleetAgentAnnotation set to false on the management cluster stops registering it with fleet, so no fleet
+	// agent is installed
+	FleetAgentAnnotation = "rancher.cattle.io/fleet-agent"
+	// HibernateQuantityAnnotation on a management node pool holds the quantity it is restored to when the cluster
+	// wakes up
+	HibernateQuantityAnnotation = "rancher.cattle.io/hibernate-quantity"
+	// DrainManagedAnnotation marks the management node pools whose drainBeforeDelete is set by the operator from
+	// spec.drainBeforeDelete, only those are reset when it is removed
+	DrainManagedAnnotation = "rancher.cattle.io/drain-before-delete"
+
+	ClusterPhasePending      = "Pending"
+	ClusterPhaseProvisioning = "Provisioning"
+	ClusterPhaseReady        = "Ready"
+	ClusterPhaseUpdating     = "Updating"
+	ClusterPhaseDeleting     = "Deleting"
+	ClusterPhaseFailed       = "Failed"
+	ClusterPhaseHibernated   = "Hibernated"
+
+	ClusterConditionAgentConnected          condition.Cond = "AgentConnected"
+	ClusterConditionBootstrapped            condition.Cond = "Bootstrapped"
+	ClusterConditionCertificatesRotated     condition.Cond = "CertificatesRotated"
+	ClusterConditionClientSecretValid       condition.Cond = "ClientSecretValid"
+	ClusterConditionConflictingOwner        condition.Cond = "ConflictingOwner"
+	ClusterConditionDecommissioned          condition.Cond = "Decommissioned"
+	ClusterConditionHibernated              condition.Cond = "Hibernated"
+	ClusterConditionKubeConfigReady         condition.Cond = "KubeConfigReady"
+	ClusterConditionKubeConfigVerified      condition.Cond = "KubeConfigVerified"
+	ClusterConditionPendingChanges          condition.Cond = "PendingChanges"
+	ClusterConditionPreDeleteHooksCompleted condition.Cond = "PreDeleteHooksCompleted"
+	ClusterConditionProvisioned             condition.Cond = "Provisioned"
+	ClusterConditionReconciled              condition.Cond = "Reconciled"
+	ClusterConditionReady                   condition.Cond = "Ready"
+	ClusterConditionReferenceBound          condition.Cond = "ReferenceBound"
+	ClusterConditionRemoving                condition.Cond = "Removing"
+	ClusterConditionReprovisioned           condition.Cond = "Reprovisioned"
+	ClusterConditionSpecInvalid             condition.Cond = "SpecInvalid"
+	ClusterConditionSSHKeyAuthorized        condition.Cond = "SSHKeyAuthorized"
+	ClusterConditionUpgraded                condition.Cond = "Upgraded"
+	ClusterConditionUpgrading               condition.Cond = "Upgrading"
+	ClusterConditionVerified                condition.Cond = "Verified"
+	ClusterConditionVersionSupported        condition.Cond = "VersionSupported"
+)
+
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -19,30 +72,319 @@ type Cluster struct {
 }
 
 type ClusterSpec struct {
-	ControlPlaneEndpoint          *Endpoint                               `json:"controlPlaneEndpoint,omitempty"`
-	EKSConfig                     *eksv1.EKSClusterConfigSpec             `json:"eksConfig,omitempty"`
-	ImportedConfig                *ImportedConfig                         `json:"importedConfig,omitempty"`
-	ReferencedConfig              *ReferencedConfig                       `json:"referencedConfig,omitempty"`
-	K3SConfig                     *v3.K3sConfig                           `json:"k3sConfig,omitempty"`
-	LocalClusterAuthEndpoint      v3.LocalClusterAuthEndpoint             `json:"localClusterAuthEndpoint,omitempty"`
-	RancherKubernetesEngineConfig *rketypes.RancherKubernetesEngineConfig `json:"rancherKubernetesEngineConfig,omitempty"`
-	RKE2Config                    *v3.Rke2Config                          `json:"rke2Config,omitempty"`
+	AdditionalKubeConfigs                []AdditionalKubeConfig                  `json:"additionalKubeConfigs,omitempty"`
+	AgentEnvVars                         []corev1.EnvVar                         `json:"agentEnvVars,omitempty"`
+	AgentImageOverride                   string                                  `json:"agentImageOverride,omitempty"`
+	APIServerFiles                       *APIServerFiles                         `json:"apiServerFiles,omitempty"`
+	Autoscaling                          []NodeGroupAutoscaling                  `json:"autoscaling,omitempty"`
+	BootstrapCharts                      []BootstrapChart                        `json:"bootstrapCharts,omitempty"`
+	BootstrapManifests                   []BootstrapManifest                     `json:"bootstrapManifests,omitempty"`
+	ClientSecretName                     string                                  `json:"clientSecretName,omitempty"`
+	ClientSecretLabels                   map[string]string                       `json:"clientSecretLabels,omitempty"`
+	ClientSecretAnnotations              map[string]string                       `json:"clientSecretAnnotations,omitempty"`
+	ControlPlaneEndpoint                 *Endpoint                               `json:"controlPlaneEndpoint,omitempty"`
+	Decommission                         *DecommissionPolicy                     `json:"decommission,omitempty"`
+	DefaultPodSecurityPolicyTemplateName string                                  `json:"defaultPodSecurityPolicyTemplateName,omitempty"`
+	DrainBeforeDelete                    *DrainOptions                           `json:"drainBeforeDelete,omitempty"`
+	EKSConfig                            *eksv1.EKSClusterConfigSpec             `json:"eksConfig,omitempty"`
+	EnableFleetAgent                     *bool                                   `json:"enableFleetAgent,omitempty"`
+	EtcdSnapshotSchedule                 *EtcdSnapshotSchedule                   `json:"etcdSnapshotSchedule,omitempty"`
+	FleetLabels                          map[string]string                       `json:"fleetLabels,omitempty"`
+	FleetWorkspaceName                   string                                  `json:"fleetWorkspaceName,omitempty"`
+	Hibernate                            bool                                    `json:"hibernate,omitempty"`
+	ImportedConfig                       *ImportedConfig                         `json:"importedConfig,omitempty"`
+	ReferencedConfig                     *ReferencedConfig                       `json:"referencedConfig,omitempty"`
+	RevokeKubeConfigOnNotReady           bool                                    `json:"revokeKubeConfigOnNotReady,omitempty"`
+	K3SConfig                            *v3.K3sConfig                           `json:"k3sConfig,omitempty"`
+	KubeConfigCABundle                   *CABundleSource                         `json:"kubeConfigCABundle,omitempty"`
+	KubeConfigClusterRole                string                                  `json:"kubeConfigClusterRole,omitempty"`
+	KubeConfigFormat                     string                                  `json:"kubeConfigFormat,omitempty"`
+	KubeConfigSecretNamespaces           []string                                `json:"kubeConfigSecretNamespaces,omitempty"`
+	LocalClusterAuthEndpoint             v3.LocalClusterAuthEndpoint             `json:"localClusterAuthEndpoint,omitempty"`
+	MaintenanceWindow                    *MaintenanceSchedule                    `json:"maintenanceWindow,omitempty"`
+	PreDeleteHooks                       []PreDeleteHook                         `json:"preDeleteHooks,omitempty"`
+	ProvisioningTimeoutSeconds           int                                     `json:"provisioningTimeoutSeconds,omitempty"`
+	Proxy                                *ProxyConfig                            `json:"proxy,omitempty"`
+	RancherKubernetesEngineConfig        *rketypes.RancherKubernetesEngineConfig `json:"rancherKubernetesEngineConfig,omitempty"`
+	RKE2Config                           *v3.Rke2Config                          `json:"rke2Config,omitempty"`
+	SmokeTest                            *SmokeTest                              `json:"smokeTest,omitempty"`
+	SSHKey                               *SSHKeyConfig                           `json:"sshKey,omitempty"`
+	UpgradeStrategy                      *UpgradeStrategy                        `json:"upgradeStrategy,omitempty"`
 }
 
 type ClusterStatus struct {
-	ClusterName        string                              `json:"clusterName,omitempty"`
-	ClientSecretName   string                              `json:"clientSecretName,omitempty"`
-	AgentDeployed      bool                                `json:"agentDeployed,omitempty"`
-	ObservedGeneration int64                               `json:"observedGeneration"`
-	Conditions         []genericcondition.GenericCondition `json:"conditions,omitempty"`
-	Ready              bool                                `json:"ready,omitempty"`
+	// ClusterName is the management cluster of this cluster, chosen once and kept when names collide
+	ClusterName        string             `json:"clusterName,omitempty"`
+	Provider           string             `json:"provider,omitempty"`
+	KubernetesVersion  string             `json:"kubernetesVersion,omitempty"`
+	ClientSecretName   string             `json:"clientSecretName,omitempty"`
+	AgentDeployed      bool               `json:"agentDeployed,omitempty"`
+	AgentConnected     bool               `json:"agentConnected,omitempty"`
+	AgentLastHeartbeat string             `json:"agentLastHeartbeat,omitempty"`
+	ObservedGeneration int64              `json:"observedGeneration"`
+	Conditions         []metav1.Condition `json:"conditions,omitempty"`
+	Ready              bool               `json:"ready,omitempty"`
+	Phase              string             `json:"phase,omitempty"`
+
+	// ConditionHistory holds the most recent status changes of the conditions, oldest first
+	ConditionHistory []ConditionTransition `json:"conditionHistory,omitempty"`
+
+	// CompletedPreDeleteHooks are the names of the pre-delete hooks that succeeded, they are not run again
+	CompletedPreDeleteHooks []string `json:"completedPreDeleteHooks,omitempty"`
+	// BootstrapManifests is the apply status of each of spec.bootstrapManifests
+	BootstrapManifests []BootstrapManifestStatus `json:"bootstrapManifests,omitempty"`
+	// BootstrapCharts is the install status of each of spec.bootstrapCharts
+	BootstrapCharts []BootstrapChartStatus `json:"bootstrapCharts,omitempty"`
+
+	// CertificateRotation is the last rancher.cattle.io/rotate-certificates value that was requested
+	CertificateRotation string `json:"certificateRotation,omitempty"`
+	// Reprovision is the last rancher.cattle.io/reprovision value that was handled
+	Reprovision string `json:"reprovision,omitempty"`
+	// RegistrationSecretName is the secret holding the current registration token and manifest of an imported cluster
+	RegistrationSecretName string `json:"registrationSecretName,omitempty"`
+	// SSHKey is the state of the key generated for spec.sshKey
+	SSHKey *SSHKeyStatus `json:"sshKey,omitempty"`
+
+	// FailureReason and FailureMessage describe the last failed reconcile, empty once it succeeds
+	FailureReason  string `json:"failureReason,omitempty"`
+	FailureMessage string `json:"failureMessage,omitempty"`
+	// RetryCount is the number of failed reconciles of the current generation
+	RetryCount int `json:"retryCount,omitempty"`
+}
+
+// ConditionTransition is a change of the status of a condition
+type ConditionTransition struct {
+	Type               string                 `json:"type"`
+	Status             metav1.ConditionStatus `json:"status"`
+	Reason             string                 `json:"reason,omitempty"`
+	Message            string                 `json:"message,omitempty"`
+	ObservedGeneration int64                  `json:"observedGeneration,omitempty"`
+	Time               metav1.Time            `json:"time"`
+}
+
+// GetConditions implements condition.Object
+func (in *Cluster) GetConditions() *[]metav1.Condition {
+	return &in.Status.Conditions
+}
+
+// GetObservedGeneration implements condition.Object, handlers setting conditions on the cluster observe its current
+// generation
+func (in *Cluster) GetObservedGeneration() int64 {
+	return in.Generation
+}
+
+// GetConditions implements condition.Object
+func (in *ClusterStatus) GetConditions() *[]metav1.Condition {
+	return &in.Conditions
+}
+
+// GetObservedGeneration implements condition.Object
+func (in *ClusterStatus) GetObservedGeneration() int64 {
+	return in.ObservedGeneration
+}
+
+// AdditionalKubeConfig is a kubeconfig that impersonates User and Groups, written to the <clientSecretName>-<name> secret
+type AdditionalKubeConfig struct {
+	Name   string   `json:"name,omitempty"`
+	User   string   `json:"user,omitempty"`
+	Groups []string `json:"groups,omitempty"`
+}
+
+// DecommissionPolicy selects downstream resources deleted before the cluster is removed, so that cloud resources
+// created by workloads are not leaked
+type DecommissionPolicy struct {
+	NamespaceSelector            *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	DeleteLoadBalancers          bool                  `json:"deleteLoadBalancers,omitempty"`
+	DeletePersistentVolumeClaims bool                  `json:"deletePersistentVolumeClaims,omitempty"`
+	TimeoutSeconds               int                   `json:"timeoutSeconds,omitempty"`
+}
+
+// PreDeleteHook runs before the cluster is removed, either as a job in the downstream cluster or by posting the
+// cluster to URL. Exactly one of Job and URL is set, the hook succeeds once the job completes or URL returns 2xx.
+type PreDeleteHook struct {
+	Name           string        `json:"name"`
+	Job            *PreDeleteJob `json:"job,omitempty"`
+	URL            string        `json:"url,omitempty"`
+	TimeoutSeconds int           `json:"timeoutSeconds,omitempty"`
+}
+
+// PreDeleteJob is the template of the job run by a pre-delete hook, Namespace defaults to cattle-pre-delete-hooks
+// and is created if missing
+type PreDeleteJob struct {
+	Namespace          string          `json:"namespace,omitempty"`
+	Image              string          `json:"image"`
+	Command            []string        `json:"command,omitempty"`
+	Args               []string        `json:"args,omitempty"`
+	Env                []corev1.EnvVar `json:"env,omitempty"`
+	ServiceAccountName string          `json:"serviceAccountName,omitempty"`
+	BackoffLimit       *int32          `json:"backoffLimit,omitempty"`
+}
+
+// CABundleSource references a PEM CA bundle, in the namespace of the cluster, to embed in generated kubeconfigs
+type CABundleSource struct {
+	SecretKeyRef    *corev1.SecretKeySelector    `json:"secretKeyRef,omitempty"`
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+}
+
+// UpgradeStrategy controls how a new kubernetes version is rolled out to the nodes of the cluster
+type UpgradeStrategy struct {
+	// MaxUnavailableControlPlane and MaxUnavailableWorker are the number of nodes upgraded at once, RKE also
+	// accepts a percentage
+	MaxUnavailableControlPlane string `json:"maxUnavailableControlPlane,omitempty"`
+	MaxUnavailableWorker       string `json:"maxUnavailableWorker,omitempty"`
+	// Drain drains nodes before they are upgraded, the drain setting of the provider config is kept when unset
+	Drain *DrainOptions `json:"drain,omitempty"`
+}
+
+// DrainOptions are passed to the drain of nodes before they are upgraded or deleted
+type DrainOptions struct {
+	Force              bool  `json:"force,omitempty"`
+	IgnoreDaemonSets   *bool `json:"ignoreDaemonSets,omitempty"`
+	DeleteLocalData    bool  `json:"deleteLocalData,omitempty"`
+	GracePeriodSeconds int   `json:"gracePeriodSeconds,omitempty"`
+	TimeoutSeconds     int   `json:"timeoutSeconds,omitempty"`
+}
+
+// MaintenanceSchedule restricts when spec changes, including new kubernetes versions, are applied to the management
+// cluster. Changes made outside of the windows are held back until the next one opens.
+type MaintenanceSchedule struct {
+	// TimeZone of the windows such as Europe/Berlin, UTC when empty
+	TimeZone string              `json:"timeZone,omitempty"`
+	Windows  []MaintenanceWindow `json:"windows,omitempty"`
+}
+
+// MaintenanceWindow starts at Start, a time such as 22:00 in the time zone of the schedule, on Days and
+// lasts DurationMinutes. Days are Mon through Sun, every day when empty.
+type MaintenanceWindow struct {
+	Days            []string `json:"days,omitempty"`
+	Start           string   `json:"start,omitempty"`
+	DurationMinutes int      `json:"durationMinutes,omitempty"`
+}
+
+// Weekday parses the day of a maintenance window
+func Weekday(day string) (time.Weekday, bool) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if d.String()[:3] == day {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+type SmokeTest struct {
+	Image string `json:"image,omitempty"`
+}
+
+// BootstrapManifest is a config map or secret in the namespace of the cluster, each of its keys holds YAML that is
+// applied to the downstream cluster once it is ready. Objects removed from the manifest are deleted downstream.
+type BootstrapManifest struct {
+	// Kind is ConfigMap or Secret, defaults to ConfigMap
+	Kind string `json:"kind,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+type BootstrapManifestStatus struct {
+	Kind string `json:"kind,omitempty"`
+	Name string `json:"name,omitempty"`
+	// Hash is the sha256 of the applied content, it is applied again once the content changes
+	Hash      string `json:"hash,omitempty"`
+	AppliedAt string `json:"appliedAt,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// SSHKeyConfig has the operator generate the SSH key that RKE uses for the nodes of its config without a key of
+// their own. A daemon set in the downstream cluster writes the public key to the nodes, a rotated key is only used
+// by RKE once every node authorized it, so rotating never reprovisions a node.
+type SSHKeyConfig struct {
+	// SecretName is the kubernetes.io/ssh-auth secret in the namespace of the cluster holding the key, defaults
+	// to <cluster>-ssh-key. The key of an existing secret is used as is.
+	SecretName string `json:"secretName,omitempty"`
+	// AuthorizedKeysPath is the authorized keys file of the SSH user on the nodes, defaults to /root/.ssh/authorized_keys
+	AuthorizedKeysPath string `json:"authorizedKeysPath,omitempty"`
+	// RotationIntervalHours rotates the key periodically, without it the key is only rotated on request
+	RotationIntervalHours int `json:"rotationIntervalHours,omitempty"`
+}
+
+type SSHKeyStatus struct {
+	SecretName string `json:"secretName,omitempty"`
+	// Fingerprint is the SHA256 fingerprint of the key RKE uses
+	Fingerprint string `json:"fingerprint,omitempty"`
+	// PendingFingerprint is the rotated key while it is authorized on the nodes
+	PendingFingerprint string `json:"pendingFingerprint,omitempty"`
+	// AuthorizedFingerprints are the keys written to every node
+	AuthorizedFingerprints []string `json:"authorizedFingerprints,omitempty"`
+	RotatedAt              string   `json:"rotatedAt,omitempty"`
+	// Rotation is the last rancher.cattle.io/rotate-ssh-key value that was handled
+	Rotation string `json:"rotation,omitempty"`
+}
+
+// APIServerFiles are kube-apiserver config files read from secrets in the namespace of the cluster. They are passed
+// to RKE, which writes them to the control plane nodes, and changes to the secrets are rolled out to the nodes.
+type APIServerFiles struct {
+	// AuditPolicy is an audit.k8s.io Policy, audit logging is enabled with it
+	AuditPolicy *corev1.SecretKeySelector `json:"auditPolicy,omitempty"`
+	// AdmissionConfiguration is an apiserver.config.k8s.io AdmissionConfiguration, like a pod security admission
+	// configuration
+	AdmissionConfiguration *corev1.SecretKeySelector `json:"admissionConfiguration,omitempty"`
+}
+
+// NodeGroupAutoscaling hands the size of a node group of spec.eksConfig to the cluster autoscaler running in the
+// cluster. Its bounds replace the sizes of the node group and the desired size set by the autoscaler is kept.
+type NodeGroupAutoscaling struct {
+	// NodeGroupName is the nodegroupName of the node group
+	NodeGroupName string `json:"nodeGroupName,omitempty"`
+	Enabled       bool   `json:"enabled,omitempty"`
+	MinSize       int64  `json:"minSize,omitempty"`
+	MaxSize       int64  `json:"maxSize,omitempty"`
+	// ScaleDownUnneededTime is how long a node is unneeded before it is removed, such as 10m
+	ScaleDownUnneededTime string `json:"scaleDownUnneededTime,omitempty"`
+	// ScaleDownUnreadyTime is how long a node is unready before it is removed, such as 20m
+	ScaleDownUnreadyTime string `json:"scaleDownUnreadyTime,omitempty"`
+	// ScaleDownUtilizationThreshold is the share of requested resources below which a node is removed, such as 0.5
+	ScaleDownUtilizationThreshold string `json:"scaleDownUtilizationThreshold,omitempty"`
+}
+
+// BootstrapChart is a helm chart installed into the downstream cluster once it is ready. It is applied as a
+// helm.cattle.io/v1 HelmChart, so the downstream cluster needs the helm controller that k3s and rke2 run, and
+// upgraded when the chart, version or values change. Charts removed from the spec are uninstalled.
+type BootstrapChart struct {
+	// Name is the release name, defaults to the chart
+	Name    string `json:"name,omitempty"`
+	Repo    string `json:"repo,omitempty"`
+	Chart   string `json:"chart,omitempty"`
+	Version string `json:"version,omitempty"`
+	// TargetNamespace is the namespace of the release, defaults to default
+	TargetNamespace string `json:"targetNamespace,omitempty"`
+	// ValuesSecretName is a secret in the namespace of the cluster, its values.yaml key holds the chart values
+	ValuesSecretName string `json:"valuesSecretName,omitempty"`
+}
+
+type BootstrapChartStatus struct {
+	Name    string `json:"name,omitempty"`
+	Version string `json:"version,omitempty"`
+	// Hash is the sha256 of the applied chart and values, it is upgraded once they change
+	Hash      string `json:"hash,omitempty"`
+	AppliedAt string `json:"appliedAt,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// ProxyConfig is passed to the agents as HTTP_PROXY, HTTPS_PROXY and NO_PROXY, and to the kubernetes components
+// of RKE clusters. Variables set in agentEnvVars are kept.
+type ProxyConfig struct {
+	HTTPProxy  string `json:"httpProxy,omitempty"`
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+	NoProxy    string `json:"noProxy,omitempty"`
 }
 
 type ImportedConfig struct {
 	KubeConfigSecret string `json:"kubeConfigSecret,omitempty"`
 }
 
+// ReferencedConfig binds the cluster to an existing management cluster, the one named Name or the single one
+// matching Selector. With both the named cluster must match the selector. Once the bound management cluster is
+// deleted the cluster is bound to the one matching then.
 type ReferencedConfig struct {
+	Name     string                `json:"name,omitempty"`
 	Selector *metav1.LabelSelector `json:"selector,omitempty"`
 }
 
@@ -0,0 +1,49 @@
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterGroup holds settings merged into every cluster in its namespace matched by Selector, settings of the
+// cluster itself take precedence and groups are merged in name order
+type ClusterGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterGroupSpec   `json:"spec"`
+	Status ClusterGroupStatus `json:"status,omitempty"`
+}
+
+type ClusterGroupSpec struct {
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// KubernetesVersions are used by members that do not set a version, so a group is upgraded with one edit
+	KubernetesVersions ClusterGroupKubernetesVersions `json:"kubernetesVersions,omitempty"`
+	// AgentEnvVars are added to those of the members that do not set the same variable
+	AgentEnvVars []corev1.EnvVar `json:"agentEnvVars,omitempty"`
+	// RoleTemplateBindings bind role templates to subjects in every member
+	RoleTemplateBindings []ClusterGroupRoleTemplateBinding `json:"roleTemplateBindings,omitempty"`
+}
+
+// ClusterGroupKubernetesVersions holds a version per provider, their version formats differ
+type ClusterGroupKubernetesVersions struct {
+	RKE  string `json:"rke,omitempty"`
+	K3s  string `json:"k3s,omitempty"`
+	RKE2 string `json:"rke2,omitempty"`
+}
+
+type ClusterGroupRoleTemplateBinding struct {
+	RoleTemplateName string `json:"roleTemplateName,omitempty"`
+	// Subjects are supported as in RoleTemplateBinding
+	Subjects []rbacv1.Subject `json:"subjects,omitempty"`
+}
+
+type ClusterGroupStatus struct {
+	ObservedGeneration int64 `json:"observedGeneration"`
+	// Clusters is the number of members
+	Clusters int `json:"clusters"`
+}
@@ -0,0 +1,41 @@
+package v1
+
+import (
+	"github.com/rancher/wrangler/pkg/condition"
+	"github.com/rancher/wrangler/pkg/genericcondition"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ClusterDiscoveryConditionDiscovered condition.Cond = "Discovered"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterDiscovery imports a cluster for every kubeconfig secret it finds in its namespace, those named in
+// SecretNames and those matching Selector. The clusters are owned by the discovery, a cluster is deleted once its
+// secret no longer matches.
+type ClusterDiscovery struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterDiscoverySpec   `json:"spec"`
+	Status ClusterDiscoveryStatus `json:"status,omitempty"`
+}
+
+type ClusterDiscoverySpec struct {
+	SecretNames []string              `json:"secretNames,omitempty"`
+	Selector    *metav1.LabelSelector `json:"selector,omitempty"`
+	// ClusterLabels are set on every imported cluster, so cluster groups can select them
+	ClusterLabels map[string]string `json:"clusterLabels,omitempty"`
+}
+
+type ClusterDiscoveryStatus struct {
+	ObservedGeneration int64 `json:"observedGeneration"`
+	// Clusters are the names of the imported clusters
+	Clusters []string `json:"clusters,omitempty"`
+	// Skipped are the secrets that were not imported and why, such as a second secret for the same API server
+	Skipped    []string                            `json:"skipped,omitempty"`
+	Conditions []genericcondition.GenericCondition `json:"conditions,omitempty"`
+}
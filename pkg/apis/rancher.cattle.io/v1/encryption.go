@@ -0,0 +1,42 @@
+package v1
+
+import (
+	"github.com/rancher/wrangler/pkg/condition"
+	"github.com/rancher/wrangler/pkg/genericcondition"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	EncryptionKeyRotationPhasePending   = "Pending"
+	EncryptionKeyRotationPhaseRotating  = "Rotating"
+	EncryptionKeyRotationPhaseCompleted = "Completed"
+	EncryptionKeyRotationPhaseFailed    = "Failed"
+
+	EncryptionKeyRotationConditionRotated condition.Cond = "Rotated"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// EncryptionKeyRotation rotates the secrets encryption key of the cluster ClusterName, in the same namespace. The
+// rotation runs once, create a new object to rotate again.
+type EncryptionKeyRotation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EncryptionKeyRotationSpec   `json:"spec"`
+	Status EncryptionKeyRotationStatus `json:"status,omitempty"`
+}
+
+type EncryptionKeyRotationSpec struct {
+	ClusterName string `json:"clusterName,omitempty"`
+}
+
+type EncryptionKeyRotationStatus struct {
+	ObservedGeneration int64  `json:"observedGeneration"`
+	Phase              string `json:"phase,omitempty"`
+	StartedAt          string `json:"startedAt,omitempty"`
+	// LastRotationTime is when the new key was in use
+	LastRotationTime string                              `json:"lastRotationTime,omitempty"`
+	Conditions       []genericcondition.GenericCondition `json:"conditions,omitempty"`
+}
@@ -0,0 +1,62 @@
+package v1
+
+import (
+	"github.com/rancher/wrangler/pkg/condition"
+	"github.com/rancher/wrangler/pkg/genericcondition"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	EtcdSnapshotRestorePhasePending   = "Pending"
+	EtcdSnapshotRestorePhaseRestoring = "Restoring"
+	EtcdSnapshotRestorePhaseCompleted = "Completed"
+	EtcdSnapshotRestorePhaseFailed    = "Failed"
+
+	EtcdSnapshotRestoreConditionRestored condition.Cond = "Restored"
+)
+
+// EtcdSnapshotSchedule configures recurring etcd snapshots of the cluster. Rancher only manages the snapshots of
+// RKE clusters, k3s and rke2 clusters take their own.
+type EtcdSnapshotSchedule struct {
+	IntervalHours int `json:"intervalHours,omitempty"`
+	// Retention is the number of snapshots kept
+	Retention int             `json:"retention,omitempty"`
+	S3        *EtcdSnapshotS3 `json:"s3,omitempty"`
+}
+
+// EtcdSnapshotS3 uploads snapshots to a bucket, the accessKey and secretKey are read from the CredentialsSecretName
+// secret in the namespace of the cluster
+type EtcdSnapshotS3 struct {
+	BucketName            string `json:"bucketName,omitempty"`
+	Region                string `json:"region,omitempty"`
+	Endpoint              string `json:"endpoint,omitempty"`
+	Folder                string `json:"folder,omitempty"`
+	CredentialsSecretName string `json:"credentialsSecretName,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// EtcdSnapshotRestore restores the cluster ClusterName, in the same namespace, from one of its etcd snapshots. The
+// restore runs once, create a new object to restore again.
+type EtcdSnapshotRestore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EtcdSnapshotRestoreSpec   `json:"spec"`
+	Status EtcdSnapshotRestoreStatus `json:"status,omitempty"`
+}
+
+type EtcdSnapshotRestoreSpec struct {
+	ClusterName string `json:"clusterName,omitempty"`
+	// SnapshotName is the name of the Rancher etcd backup, in the namespace of the management cluster
+	SnapshotName string `json:"snapshotName,omitempty"`
+}
+
+type EtcdSnapshotRestoreStatus struct {
+	ObservedGeneration int64                               `json:"observedGeneration"`
+	Phase              string                              `json:"phase,omitempty"`
+	StartedAt          string                              `json:"startedAt,omitempty"`
+	CompletedAt        string                              `json:"completedAt,omitempty"`
+	Conditions         []genericcondition.GenericCondition `json:"conditions,omitempty"`
+}
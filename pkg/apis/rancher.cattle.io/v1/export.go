@@ -0,0 +1,43 @@
+package v1
+
+import (
+	"github.com/rancher/wrangler/pkg/condition"
+	"github.com/rancher/wrangler/pkg/genericcondition"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ClusterExportTargetConfigMap = "ConfigMap"
+	ClusterExportTargetSecret    = "Secret"
+
+	ClusterExportConditionExported condition.Cond = "Exported"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterExport writes the management cluster rendered for the cluster ClusterName, in the same namespace, to a
+// config map or secret named after the export. Cluster groups are merged and encrypted values are kept encrypted,
+// the export runs once, create a new object to export again.
+type ClusterExport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterExportSpec   `json:"spec"`
+	Status ClusterExportStatus `json:"status,omitempty"`
+}
+
+type ClusterExportSpec struct {
+	ClusterName string `json:"clusterName,omitempty"`
+	// Target is ConfigMap or Secret, defaults to ConfigMap
+	Target string `json:"target,omitempty"`
+}
+
+type ClusterExportStatus struct {
+	ObservedGeneration int64 `json:"observedGeneration"`
+	// ClusterGeneration is the generation of the cluster that was exported
+	ClusterGeneration int64                               `json:"clusterGeneration,omitempty"`
+	SpecHash          string                              `json:"specHash,omitempty"`
+	ExportedAt        string                              `json:"exportedAt,omitempty"`
+	Conditions        []genericcondition.GenericCondition `json:"conditions,omitempty"`
+}
@@ -0,0 +1,32 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterReferenceGrant allows referenced clusters in other namespaces to claim the management clusters owned by
+// the namespace of the grant, such as those of a shared infrastructure namespace, and optionally clusters in other
+// namespaces to replicate their kubeconfig secrets into it
+type ClusterReferenceGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClusterReferenceGrantSpec `json:"spec"`
+}
+
+type ClusterReferenceGrantSpec struct {
+	// From are the namespaces granted access
+	From []ClusterReferenceGrantFrom `json:"from,omitempty"`
+	// Selector limits the grant to the management clusters it matches, all clusters of the namespace when empty
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// KubeConfigSecrets allows the clusters of the From namespaces to list the namespace of the grant in
+	// kubeConfigSecretNamespaces, the selector does not apply
+	KubeConfigSecrets bool `json:"kubeConfigSecrets,omitempty"`
+}
+
+type ClusterReferenceGrantFrom struct {
+	Namespace string `json:"namespace,omitempty"`
+}
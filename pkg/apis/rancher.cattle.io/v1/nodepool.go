@@ -0,0 +1,57 @@
+package v1
+
+import (
+	"github.com/rancher/wrangler/pkg/condition"
+	"github.com/rancher/wrangler/pkg/genericcondition"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	NodePoolConditionReconciled condition.Cond = "Reconciled"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NodePool is a pool of nodes that Rancher provisions from a node template for an RKE cluster in the same
+// namespace. It is reconciled into a management.cattle.io/v3 NodePool of the management cluster, so scaling the
+// pool doesn't change the cluster.
+type NodePool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NodePoolSpec   `json:"spec"`
+	Status NodePoolStatus `json:"status,omitempty"`
+}
+
+type NodePoolSpec struct {
+	ClusterName string `json:"clusterName,omitempty"`
+	// NodeTemplateName is a NodeTemplate in the namespace of the pool, or a management.cattle.io/v3 NodeTemplate of
+	// Rancher as <namespace>:<name>
+	NodeTemplateName string `json:"nodeTemplateName,omitempty"`
+	// HostnamePrefix defaults to the name of the pool followed by a dash
+	HostnamePrefix string `json:"hostnamePrefix,omitempty"`
+	Etcd           bool   `json:"etcd,omitempty"`
+	ControlPlane   bool   `json:"controlPlane,omitempty"`
+	Worker         bool   `json:"worker,omitempty"`
+	// Desired is the node count, kept within Min and Max so an autoscaler only has to set it
+	Desired int `json:"desired,omitempty"`
+	Min     int `json:"min,omitempty"`
+	// Max of zero doesn't bound Desired
+	Max               int               `json:"max,omitempty"`
+	Labels            map[string]string `json:"labels,omitempty"`
+	Taints            []corev1.Taint    `json:"taints,omitempty"`
+	DrainBeforeDelete bool              `json:"drainBeforeDelete,omitempty"`
+}
+
+type NodePoolStatus struct {
+	ObservedGeneration int64 `json:"observedGeneration"`
+	// PoolName is the management.cattle.io/v3 NodePool as <management cluster>:<name>, the nodePoolName of its nodes
+	PoolName string `json:"poolName,omitempty"`
+	// Quantity is the node count requested from Rancher, zero while the cluster hibernates
+	Quantity   int                                 `json:"quantity"`
+	Nodes      int                                 `json:"nodes"`
+	ReadyNodes int                                 `json:"readyNodes"`
+	Conditions []genericcondition.GenericCondition `json:"conditions,omitempty"`
+}
@@ -0,0 +1,123 @@
+package v1
+
+import (
+	"github.com/rancher/wrangler/pkg/condition"
+	"github.com/rancher/wrangler/pkg/genericcondition"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	NodeTemplateConditionReconciled condition.Cond = "Reconciled"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NodeTemplate is the machine config of a node driver, NodePools in the same namespace reference it by name. It is
+// reconciled into a management.cattle.io/v3 NodeTemplate of Rancher, exactly one of the driver configs is set.
+type NodeTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NodeTemplateSpec   `json:"spec"`
+	Status NodeTemplateStatus `json:"status,omitempty"`
+}
+
+type NodeTemplateSpec struct {
+	// CloudCredentialName is the Rancher cloud credential of the driver as <namespace>:<name>, such as
+	// cattle-global-data:cc-abcde
+	CloudCredentialName  string `json:"cloudCredentialName,omitempty"`
+	EngineInstallURL     string `json:"engineInstallURL,omitempty"`
+	UseInternalIPAddress bool   `json:"useInternalIpAddress,omitempty"`
+
+	AmazonEC2Config *AmazonEC2Config `json:"amazonec2Config,omitempty"`
+	AzureConfig     *AzureConfig     `json:"azureConfig,omitempty"`
+	HarvesterConfig *HarvesterConfig `json:"harvesterConfig,omitempty"`
+	VSphereConfig   *VSphereConfig   `json:"vmwarevsphereConfig,omitempty"`
+}
+
+type NodeTemplateStatus struct {
+	ObservedGeneration int64 `json:"observedGeneration"`
+	// TemplateName is the management.cattle.io/v3 NodeTemplate as <namespace>:<name>
+	TemplateName string                              `json:"templateName,omitempty"`
+	Conditions   []genericcondition.GenericCondition `json:"conditions,omitempty"`
+}
+
+// The driver configs use the fields of the Rancher node templates, which pass them to the docker machine driver as
+// flags. Numbers are strings like in Rancher.
+
+type AmazonEC2Config struct {
+	Region              string   `json:"region,omitempty"`
+	Zone                string   `json:"zone,omitempty"`
+	AMI                 string   `json:"ami,omitempty"`
+	InstanceType        string   `json:"instanceType,omitempty"`
+	VPCID               string   `json:"vpcId,omitempty"`
+	SubnetID            string   `json:"subnetId,omitempty"`
+	SecurityGroup       []string `json:"securityGroup,omitempty"`
+	IAMInstanceProfile  string   `json:"iamInstanceProfile,omitempty"`
+	KeypairName         string   `json:"keypairName,omitempty"`
+	RootSize            string   `json:"rootSize,omitempty"`
+	VolumeType          string   `json:"volumeType,omitempty"`
+	PrivateAddressOnly  bool     `json:"privateAddressOnly,omitempty"`
+	RequestSpotInstance bool     `json:"requestSpotInstance,omitempty"`
+	SpotPrice           string   `json:"spotPrice,omitempty"`
+	SSHUser             string   `json:"sshUser,omitempty"`
+	// Tags are the instance tags as key1,value1,key2,value2
+	Tags     string `json:"tags,omitempty"`
+	UserData string `json:"userdata,omitempty"`
+}
+
+type AzureConfig struct {
+	Environment     string   `json:"environment,omitempty"`
+	Location        string   `json:"location,omitempty"`
+	ResourceGroup   string   `json:"resourceGroup,omitempty"`
+	AvailabilitySet string   `json:"availabilitySet,omitempty"`
+	Size            string   `json:"size,omitempty"`
+	Image           string   `json:"image,omitempty"`
+	Vnet            string   `json:"vnet,omitempty"`
+	Subnet          string   `json:"subnet,omitempty"`
+	SubnetPrefix    string   `json:"subnetPrefix,omitempty"`
+	StorageType     string   `json:"storageType,omitempty"`
+	DiskSize        string   `json:"diskSize,omitempty"`
+	ManagedDisks    bool     `json:"managedDisks,omitempty"`
+	NoPublicIP      bool     `json:"noPublicIp,omitempty"`
+	StaticPublicIP  bool     `json:"staticPublicIp,omitempty"`
+	OpenPort        []string `json:"openPort,omitempty"`
+	SSHUser         string   `json:"sshUser,omitempty"`
+}
+
+type HarvesterConfig struct {
+	VMNamespace  string `json:"vmNamespace,omitempty"`
+	CPUCount     string `json:"cpuCount,omitempty"`
+	MemorySize   string `json:"memorySize,omitempty"`
+	DiskSize     string `json:"diskSize,omitempty"`
+	DiskBus      string `json:"diskBus,omitempty"`
+	ImageName    string `json:"imageName,omitempty"`
+	NetworkName  string `json:"networkName,omitempty"`
+	NetworkModel string `json:"networkModel,omitempty"`
+	SSHUser      string `json:"sshUser,omitempty"`
+	UserData     string `json:"userData,omitempty"`
+}
+
+type VSphereConfig struct {
+	Vcenter        string   `json:"vcenter,omitempty"`
+	VcenterPort    string   `json:"vcenterPort,omitempty"`
+	Datacenter     string   `json:"datacenter,omitempty"`
+	Datastore      string   `json:"datastore,omitempty"`
+	Folder         string   `json:"folder,omitempty"`
+	Hostsystem     string   `json:"hostsystem,omitempty"`
+	Pool           string   `json:"pool,omitempty"`
+	Network        []string `json:"network,omitempty"`
+	CreationType   string   `json:"creationType,omitempty"`
+	CloneFrom      string   `json:"cloneFrom,omitempty"`
+	ContentLibrary string   `json:"contentLibrary,omitempty"`
+	CPUCount       string   `json:"cpuCount,omitempty"`
+	MemorySize     string   `json:"memorySize,omitempty"`
+	DiskSize       string   `json:"diskSize,omitempty"`
+	CloudConfig    string   `json:"cloudConfig,omitempty"`
+	Cloudinit      string   `json:"cloudinit,omitempty"`
+	Cfgparam       []string `json:"cfgparam,omitempty"`
+	Tags           []string `json:"tags,omitempty"`
+	SSHUser        string   `json:"sshUser,omitempty"`
+	SSHPort        string   `json:"sshPort,omitempty"`
+}
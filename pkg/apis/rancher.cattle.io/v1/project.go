@@ -1,9 +1,16 @@
 package v1
 
 import (
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/wrangler/pkg/condition"
+	"github.com/rancher/wrangler/pkg/genericcondition"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	ProjectConditionNamespacesAssigned condition.Cond = "NamespacesAssigned"
+)
+
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -15,9 +22,22 @@ type Project struct {
 	Status ProjectStatus `json:"status,omitempty"`
 }
 
+// ProjectSpec creates a management project in the clusters matched by ClusterSelector and in the cluster named
+// ClusterName, both are clusters in the namespace of the project
 type ProjectSpec struct {
-	ClusterSelector *metav1.LabelSelector `json:"clusterSelector,omitempty"`
+	ClusterSelector               *metav1.LabelSelector      `json:"clusterSelector,omitempty"`
+	ClusterName                   string                     `json:"clusterName,omitempty"`
+	DisplayName                   string                     `json:"displayName,omitempty"`
+	ResourceQuota                 *v3.ProjectResourceQuota   `json:"resourceQuota,omitempty"`
+	NamespaceDefaultResourceQuota *v3.NamespaceResourceQuota `json:"namespaceDefaultResourceQuota,omitempty"`
+	ContainerDefaultResourceLimit *v3.ContainerResourceLimit `json:"containerDefaultResourceLimit,omitempty"`
+	// PodSecurityPolicyTemplateName overrides the pod security policy template of the cluster for the project
+	PodSecurityPolicyTemplateName string `json:"podSecurityPolicyTemplateName,omitempty"`
+	// Namespaces are created in the downstream clusters of the project and assigned to it
+	Namespaces []string `json:"namespaces,omitempty"`
 }
 
 type ProjectStatus struct {
+	ObservedGeneration int64                               `json:"observedGeneration"`
+	Conditions         []genericcondition.GenericCondition `json:"conditions,omitempty"`
 }
@@ -0,0 +1,69 @@
+package v1
+
+import (
+	"github.com/rancher/wrangler/pkg/condition"
+	"github.com/rancher/wrangler/pkg/genericcondition"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	RancherTokenConditionIssued condition.Cond = "Issued"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RancherUser is a Rancher user without a password, such as a CI service user, that only authenticates with the
+// tokens of its RancherTokens
+type RancherUser struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RancherUserSpec   `json:"spec"`
+	Status RancherUserStatus `json:"status,omitempty"`
+}
+
+type RancherUserSpec struct {
+	DisplayName string `json:"displayName,omitempty"`
+	// GlobalRoles are bound to the user, user-base is enough for tokens that are only used with cluster and
+	// project role template bindings
+	GlobalRoles []string `json:"globalRoles,omitempty"`
+	Disabled    bool     `json:"disabled,omitempty"`
+}
+
+type RancherUserStatus struct {
+	ObservedGeneration int64 `json:"observedGeneration"`
+	// UserName is the management user and PrincipalID the subject name to use in role template bindings
+	UserName    string `json:"userName,omitempty"`
+	PrincipalID string `json:"principalID,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RancherToken is an API token of a RancherUser in the same namespace, written to a secret and replaced when it
+// expires, is revoked, or is older than RotateAfterSeconds
+type RancherToken struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RancherTokenSpec   `json:"spec"`
+	Status RancherTokenStatus `json:"status,omitempty"`
+}
+
+type RancherTokenSpec struct {
+	UserName string `json:"userName,omitempty"`
+	// SecretName defaults to <name>-token, the secret holds the bearer token under token and the Rancher URL
+	// under url
+	SecretName         string `json:"secretName,omitempty"`
+	TTLSeconds         int    `json:"ttlSeconds,omitempty"`
+	RotateAfterSeconds int    `json:"rotateAfterSeconds,omitempty"`
+}
+
+type RancherTokenStatus struct {
+	ObservedGeneration int64                               `json:"observedGeneration"`
+	TokenName          string                              `json:"tokenName,omitempty"`
+	SecretName         string                              `json:"secretName,omitempty"`
+	IssuedAt           string                              `json:"issuedAt,omitempty"`
+	Conditions         []genericcondition.GenericCondition `json:"conditions,omitempty"`
+}
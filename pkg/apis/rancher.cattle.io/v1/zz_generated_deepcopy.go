@@ -1,3 +1,4 @@
+//go:build !ignore_autogenerated
 // +build !ignore_autogenerated
 
 /*
@@ -25,11 +26,191 @@ import (
 	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
 	types "github.com/rancher/rke/types"
 	genericcondition "github.com/rancher/wrangler/pkg/genericcondition"
+	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIServerFiles) DeepCopyInto(out *APIServerFiles) {
+	*out = *in
+	if in.AuditPolicy != nil {
+		in, out := &in.AuditPolicy, &out.AuditPolicy
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdmissionConfiguration != nil {
+		in, out := &in.AdmissionConfiguration, &out.AdmissionConfiguration
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerFiles.
+func (in *APIServerFiles) DeepCopy() *APIServerFiles {
+	if in == nil {
+		return nil
+	}
+	out := new(APIServerFiles)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdditionalKubeConfig) DeepCopyInto(out *AdditionalKubeConfig) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdditionalKubeConfig.
+func (in *AdditionalKubeConfig) DeepCopy() *AdditionalKubeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AdditionalKubeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AmazonEC2Config) DeepCopyInto(out *AmazonEC2Config) {
+	*out = *in
+	if in.SecurityGroup != nil {
+		in, out := &in.SecurityGroup, &out.SecurityGroup
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AmazonEC2Config.
+func (in *AmazonEC2Config) DeepCopy() *AmazonEC2Config {
+	if in == nil {
+		return nil
+	}
+	out := new(AmazonEC2Config)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureConfig) DeepCopyInto(out *AzureConfig) {
+	*out = *in
+	if in.OpenPort != nil {
+		in, out := &in.OpenPort, &out.OpenPort
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureConfig.
+func (in *AzureConfig) DeepCopy() *AzureConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootstrapChart) DeepCopyInto(out *BootstrapChart) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapChart.
+func (in *BootstrapChart) DeepCopy() *BootstrapChart {
+	if in == nil {
+		return nil
+	}
+	out := new(BootstrapChart)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootstrapChartStatus) DeepCopyInto(out *BootstrapChartStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapChartStatus.
+func (in *BootstrapChartStatus) DeepCopy() *BootstrapChartStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BootstrapChartStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootstrapManifest) DeepCopyInto(out *BootstrapManifest) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapManifest.
+func (in *BootstrapManifest) DeepCopy() *BootstrapManifest {
+	if in == nil {
+		return nil
+	}
+	out := new(BootstrapManifest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootstrapManifestStatus) DeepCopyInto(out *BootstrapManifestStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapManifestStatus.
+func (in *BootstrapManifestStatus) DeepCopy() *BootstrapManifestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BootstrapManifestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CABundleSource) DeepCopyInto(out *CABundleSource) {
+	*out = *in
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CABundleSource.
+func (in *CABundleSource) DeepCopy() *CABundleSource {
+	if in == nil {
+		return nil
+	}
+	out := new(CABundleSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Cluster) DeepCopyInto(out *Cluster) {
 	*out = *in
@@ -40,18 +221,1846 @@ func (in *Cluster) DeepCopyInto(out *Cluster) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Cluster.
-func (in *Cluster) DeepCopy() *Cluster {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Cluster.
+func (in *Cluster) DeepCopy() *Cluster {
+	if in == nil {
+		return nil
+	}
+	out := new(Cluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Cluster) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterBackup) DeepCopyInto(out *ClusterBackup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBackup.
+func (in *ClusterBackup) DeepCopy() *ClusterBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterBackup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterBackupList) DeepCopyInto(out *ClusterBackupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterBackup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBackupList.
+func (in *ClusterBackupList) DeepCopy() *ClusterBackupList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterBackupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterBackupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterBackupRestore) DeepCopyInto(out *ClusterBackupRestore) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBackupRestore.
+func (in *ClusterBackupRestore) DeepCopy() *ClusterBackupRestore {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterBackupRestore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterBackupSpec) DeepCopyInto(out *ClusterBackupSpec) {
+	*out = *in
+	if in.Restore != nil {
+		in, out := &in.Restore, &out.Restore
+		*out = new(ClusterBackupRestore)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBackupSpec.
+func (in *ClusterBackupSpec) DeepCopy() *ClusterBackupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterBackupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterBackupStatus) DeepCopyInto(out *ClusterBackupStatus) {
+	*out = *in
+	if in.Objects != nil {
+		in, out := &in.Objects, &out.Objects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]genericcondition.GenericCondition, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBackupStatus.
+func (in *ClusterBackupStatus) DeepCopy() *ClusterBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDiscovery) DeepCopyInto(out *ClusterDiscovery) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDiscovery.
+func (in *ClusterDiscovery) DeepCopy() *ClusterDiscovery {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDiscovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterDiscovery) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDiscoveryList) DeepCopyInto(out *ClusterDiscoveryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterDiscovery, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDiscoveryList.
+func (in *ClusterDiscoveryList) DeepCopy() *ClusterDiscoveryList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDiscoveryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterDiscoveryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDiscoverySpec) DeepCopyInto(out *ClusterDiscoverySpec) {
+	*out = *in
+	if in.SecretNames != nil {
+		in, out := &in.SecretNames, &out.SecretNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterLabels != nil {
+		in, out := &in.ClusterLabels, &out.ClusterLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDiscoverySpec.
+func (in *ClusterDiscoverySpec) DeepCopy() *ClusterDiscoverySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDiscoverySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDiscoveryStatus) DeepCopyInto(out *ClusterDiscoveryStatus) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Skipped != nil {
+		in, out := &in.Skipped, &out.Skipped
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]genericcondition.GenericCondition, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDiscoveryStatus.
+func (in *ClusterDiscoveryStatus) DeepCopy() *ClusterDiscoveryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDiscoveryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterExport) DeepCopyInto(out *ClusterExport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterExport.
+func (in *ClusterExport) DeepCopy() *ClusterExport {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterExport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterExport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterExportList) DeepCopyInto(out *ClusterExportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterExport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterExportList.
+func (in *ClusterExportList) DeepCopy() *ClusterExportList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterExportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterExportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterExportSpec) DeepCopyInto(out *ClusterExportSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterExportSpec.
+func (in *ClusterExportSpec) DeepCopy() *ClusterExportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterExportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterExportStatus) DeepCopyInto(out *ClusterExportStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]genericcondition.GenericCondition, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterExportStatus.
+func (in *ClusterExportStatus) DeepCopy() *ClusterExportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterExportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterGroup) DeepCopyInto(out *ClusterGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterGroup.
+func (in *ClusterGroup) DeepCopy() *ClusterGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterGroupKubernetesVersions) DeepCopyInto(out *ClusterGroupKubernetesVersions) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterGroupKubernetesVersions.
+func (in *ClusterGroupKubernetesVersions) DeepCopy() *ClusterGroupKubernetesVersions {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterGroupKubernetesVersions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterGroupList) DeepCopyInto(out *ClusterGroupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterGroupList.
+func (in *ClusterGroupList) DeepCopy() *ClusterGroupList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterGroupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterGroupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterGroupRoleTemplateBinding) DeepCopyInto(out *ClusterGroupRoleTemplateBinding) {
+	*out = *in
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = make([]rbacv1.Subject, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterGroupRoleTemplateBinding.
+func (in *ClusterGroupRoleTemplateBinding) DeepCopy() *ClusterGroupRoleTemplateBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterGroupRoleTemplateBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterGroupSpec) DeepCopyInto(out *ClusterGroupSpec) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	out.KubernetesVersions = in.KubernetesVersions
+	if in.AgentEnvVars != nil {
+		in, out := &in.AgentEnvVars, &out.AgentEnvVars
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RoleTemplateBindings != nil {
+		in, out := &in.RoleTemplateBindings, &out.RoleTemplateBindings
+		*out = make([]ClusterGroupRoleTemplateBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterGroupSpec.
+func (in *ClusterGroupSpec) DeepCopy() *ClusterGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterGroupStatus) DeepCopyInto(out *ClusterGroupStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterGroupStatus.
+func (in *ClusterGroupStatus) DeepCopy() *ClusterGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterList) DeepCopyInto(out *ClusterList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Cluster, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterList.
+func (in *ClusterList) DeepCopy() *ClusterList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterReferenceGrant) DeepCopyInto(out *ClusterReferenceGrant) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterReferenceGrant.
+func (in *ClusterReferenceGrant) DeepCopy() *ClusterReferenceGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterReferenceGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterReferenceGrant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterReferenceGrantFrom) DeepCopyInto(out *ClusterReferenceGrantFrom) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterReferenceGrantFrom.
+func (in *ClusterReferenceGrantFrom) DeepCopy() *ClusterReferenceGrantFrom {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterReferenceGrantFrom)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterReferenceGrantList) DeepCopyInto(out *ClusterReferenceGrantList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterReferenceGrant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterReferenceGrantList.
+func (in *ClusterReferenceGrantList) DeepCopy() *ClusterReferenceGrantList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterReferenceGrantList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterReferenceGrantList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterReferenceGrantSpec) DeepCopyInto(out *ClusterReferenceGrantSpec) {
+	*out = *in
+	if in.From != nil {
+		in, out := &in.From, &out.From
+		*out = make([]ClusterReferenceGrantFrom, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterReferenceGrantSpec.
+func (in *ClusterReferenceGrantSpec) DeepCopy() *ClusterReferenceGrantSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterReferenceGrantSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
+	*out = *in
+	if in.AdditionalKubeConfigs != nil {
+		in, out := &in.AdditionalKubeConfigs, &out.AdditionalKubeConfigs
+		*out = make([]AdditionalKubeConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AgentEnvVars != nil {
+		in, out := &in.AgentEnvVars, &out.AgentEnvVars
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.APIServerFiles != nil {
+		in, out := &in.APIServerFiles, &out.APIServerFiles
+		*out = new(APIServerFiles)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = make([]NodeGroupAutoscaling, len(*in))
+		copy(*out, *in)
+	}
+	if in.BootstrapCharts != nil {
+		in, out := &in.BootstrapCharts, &out.BootstrapCharts
+		*out = make([]BootstrapChart, len(*in))
+		copy(*out, *in)
+	}
+	if in.BootstrapManifests != nil {
+		in, out := &in.BootstrapManifests, &out.BootstrapManifests
+		*out = make([]BootstrapManifest, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClientSecretLabels != nil {
+		in, out := &in.ClientSecretLabels, &out.ClientSecretLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ClientSecretAnnotations != nil {
+		in, out := &in.ClientSecretAnnotations, &out.ClientSecretAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ControlPlaneEndpoint != nil {
+		in, out := &in.ControlPlaneEndpoint, &out.ControlPlaneEndpoint
+		*out = new(Endpoint)
+		**out = **in
+	}
+	if in.Decommission != nil {
+		in, out := &in.Decommission, &out.Decommission
+		*out = new(DecommissionPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DrainBeforeDelete != nil {
+		in, out := &in.DrainBeforeDelete, &out.DrainBeforeDelete
+		*out = new(DrainOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EKSConfig != nil {
+		in, out := &in.EKSConfig, &out.EKSConfig
+		*out = new(ekscattleiov1.EKSClusterConfigSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EnableFleetAgent != nil {
+		in, out := &in.EnableFleetAgent, &out.EnableFleetAgent
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EtcdSnapshotSchedule != nil {
+		in, out := &in.EtcdSnapshotSchedule, &out.EtcdSnapshotSchedule
+		*out = new(EtcdSnapshotSchedule)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FleetLabels != nil {
+		in, out := &in.FleetLabels, &out.FleetLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ImportedConfig != nil {
+		in, out := &in.ImportedConfig, &out.ImportedConfig
+		*out = new(ImportedConfig)
+		**out = **in
+	}
+	if in.ReferencedConfig != nil {
+		in, out := &in.ReferencedConfig, &out.ReferencedConfig
+		*out = new(ReferencedConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.K3SConfig != nil {
+		in, out := &in.K3SConfig, &out.K3SConfig
+		*out = new(v3.K3sConfig)
+		**out = **in
+	}
+	if in.KubeConfigCABundle != nil {
+		in, out := &in.KubeConfigCABundle, &out.KubeConfigCABundle
+		*out = new(CABundleSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KubeConfigSecretNamespaces != nil {
+		in, out := &in.KubeConfigSecretNamespaces, &out.KubeConfigSecretNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.LocalClusterAuthEndpoint = in.LocalClusterAuthEndpoint
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceSchedule)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PreDeleteHooks != nil {
+		in, out := &in.PreDeleteHooks, &out.PreDeleteHooks
+		*out = make([]PreDeleteHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(ProxyConfig)
+		**out = **in
+	}
+	if in.RancherKubernetesEngineConfig != nil {
+		in, out := &in.RancherKubernetesEngineConfig, &out.RancherKubernetesEngineConfig
+		*out = new(types.RancherKubernetesEngineConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RKE2Config != nil {
+		in, out := &in.RKE2Config, &out.RKE2Config
+		*out = new(v3.Rke2Config)
+		**out = **in
+	}
+	if in.SmokeTest != nil {
+		in, out := &in.SmokeTest, &out.SmokeTest
+		*out = new(SmokeTest)
+		**out = **in
+	}
+	if in.SSHKey != nil {
+		in, out := &in.SSHKey, &out.SSHKey
+		*out = new(SSHKeyConfig)
+		**out = **in
+	}
+	if in.UpgradeStrategy != nil {
+		in, out := &in.UpgradeStrategy, &out.UpgradeStrategy
+		*out = new(UpgradeStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpec.
+func (in *ClusterSpec) DeepCopy() *ClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ConditionHistory != nil {
+		in, out := &in.ConditionHistory, &out.ConditionHistory
+		*out = make([]ConditionTransition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CompletedPreDeleteHooks != nil {
+		in, out := &in.CompletedPreDeleteHooks, &out.CompletedPreDeleteHooks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BootstrapManifests != nil {
+		in, out := &in.BootstrapManifests, &out.BootstrapManifests
+		*out = make([]BootstrapManifestStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.BootstrapCharts != nil {
+		in, out := &in.BootstrapCharts, &out.BootstrapCharts
+		*out = make([]BootstrapChartStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.SSHKey != nil {
+		in, out := &in.SSHKey, &out.SSHKey
+		*out = new(SSHKeyStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStatus.
+func (in *ClusterStatus) DeepCopy() *ClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConditionTransition) DeepCopyInto(out *ConditionTransition) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConditionTransition.
+func (in *ConditionTransition) DeepCopy() *ConditionTransition {
+	if in == nil {
+		return nil
+	}
+	out := new(ConditionTransition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DecommissionPolicy) DeepCopyInto(out *DecommissionPolicy) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DecommissionPolicy.
+func (in *DecommissionPolicy) DeepCopy() *DecommissionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(DecommissionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrainOptions) DeepCopyInto(out *DrainOptions) {
+	*out = *in
+	if in.IgnoreDaemonSets != nil {
+		in, out := &in.IgnoreDaemonSets, &out.IgnoreDaemonSets
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrainOptions.
+func (in *DrainOptions) DeepCopy() *DrainOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(DrainOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EncryptionKeyRotation) DeepCopyInto(out *EncryptionKeyRotation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EncryptionKeyRotation.
+func (in *EncryptionKeyRotation) DeepCopy() *EncryptionKeyRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(EncryptionKeyRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EncryptionKeyRotation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EncryptionKeyRotationList) DeepCopyInto(out *EncryptionKeyRotationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EncryptionKeyRotation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EncryptionKeyRotationList.
+func (in *EncryptionKeyRotationList) DeepCopy() *EncryptionKeyRotationList {
+	if in == nil {
+		return nil
+	}
+	out := new(EncryptionKeyRotationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EncryptionKeyRotationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EncryptionKeyRotationSpec) DeepCopyInto(out *EncryptionKeyRotationSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EncryptionKeyRotationSpec.
+func (in *EncryptionKeyRotationSpec) DeepCopy() *EncryptionKeyRotationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EncryptionKeyRotationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EncryptionKeyRotationStatus) DeepCopyInto(out *EncryptionKeyRotationStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]genericcondition.GenericCondition, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EncryptionKeyRotationStatus.
+func (in *EncryptionKeyRotationStatus) DeepCopy() *EncryptionKeyRotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EncryptionKeyRotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Endpoint) DeepCopyInto(out *Endpoint) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Endpoint.
+func (in *Endpoint) DeepCopy() *Endpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(Endpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdSnapshotRestore) DeepCopyInto(out *EtcdSnapshotRestore) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdSnapshotRestore.
+func (in *EtcdSnapshotRestore) DeepCopy() *EtcdSnapshotRestore {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdSnapshotRestore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EtcdSnapshotRestore) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdSnapshotRestoreList) DeepCopyInto(out *EtcdSnapshotRestoreList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EtcdSnapshotRestore, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdSnapshotRestoreList.
+func (in *EtcdSnapshotRestoreList) DeepCopy() *EtcdSnapshotRestoreList {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdSnapshotRestoreList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EtcdSnapshotRestoreList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdSnapshotRestoreSpec) DeepCopyInto(out *EtcdSnapshotRestoreSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdSnapshotRestoreSpec.
+func (in *EtcdSnapshotRestoreSpec) DeepCopy() *EtcdSnapshotRestoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdSnapshotRestoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdSnapshotRestoreStatus) DeepCopyInto(out *EtcdSnapshotRestoreStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]genericcondition.GenericCondition, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdSnapshotRestoreStatus.
+func (in *EtcdSnapshotRestoreStatus) DeepCopy() *EtcdSnapshotRestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdSnapshotRestoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdSnapshotS3) DeepCopyInto(out *EtcdSnapshotS3) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdSnapshotS3.
+func (in *EtcdSnapshotS3) DeepCopy() *EtcdSnapshotS3 {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdSnapshotS3)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdSnapshotSchedule) DeepCopyInto(out *EtcdSnapshotSchedule) {
+	*out = *in
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(EtcdSnapshotS3)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdSnapshotSchedule.
+func (in *EtcdSnapshotSchedule) DeepCopy() *EtcdSnapshotSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdSnapshotSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalRole) DeepCopyInto(out *GlobalRole) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]rbacv1.PolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.Status = in.Status
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalRole.
+func (in *GlobalRole) DeepCopy() *GlobalRole {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GlobalRole) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalRoleBinding) DeepCopyInto(out *GlobalRoleBinding) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = make([]rbacv1.Subject, len(*in))
+		copy(*out, *in)
+	}
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalRoleBinding.
+func (in *GlobalRoleBinding) DeepCopy() *GlobalRoleBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalRoleBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GlobalRoleBinding) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalRoleBindingList) DeepCopyInto(out *GlobalRoleBindingList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GlobalRoleBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalRoleBindingList.
+func (in *GlobalRoleBindingList) DeepCopy() *GlobalRoleBindingList {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalRoleBindingList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GlobalRoleBindingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalRoleBindingStatus) DeepCopyInto(out *GlobalRoleBindingStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]genericcondition.GenericCondition, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalRoleBindingStatus.
+func (in *GlobalRoleBindingStatus) DeepCopy() *GlobalRoleBindingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalRoleBindingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalRoleList) DeepCopyInto(out *GlobalRoleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GlobalRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalRoleList.
+func (in *GlobalRoleList) DeepCopy() *GlobalRoleList {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalRoleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GlobalRoleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalRoleStatus) DeepCopyInto(out *GlobalRoleStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalRoleStatus.
+func (in *GlobalRoleStatus) DeepCopy() *GlobalRoleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalRoleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalRoleTemplateBinding) DeepCopyInto(out *GlobalRoleTemplateBinding) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.ClusterSelector != nil {
+		in, out := &in.ClusterSelector, &out.ClusterSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = make([]rbacv1.Subject, len(*in))
+		copy(*out, *in)
+	}
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalRoleTemplateBinding.
+func (in *GlobalRoleTemplateBinding) DeepCopy() *GlobalRoleTemplateBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalRoleTemplateBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GlobalRoleTemplateBinding) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalRoleTemplateBindingList) DeepCopyInto(out *GlobalRoleTemplateBindingList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GlobalRoleTemplateBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalRoleTemplateBindingList.
+func (in *GlobalRoleTemplateBindingList) DeepCopy() *GlobalRoleTemplateBindingList {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalRoleTemplateBindingList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GlobalRoleTemplateBindingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalRoleTemplateBindingStatus) DeepCopyInto(out *GlobalRoleTemplateBindingStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]genericcondition.GenericCondition, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalRoleTemplateBindingStatus.
+func (in *GlobalRoleTemplateBindingStatus) DeepCopy() *GlobalRoleTemplateBindingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalRoleTemplateBindingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HarvesterConfig) DeepCopyInto(out *HarvesterConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HarvesterConfig.
+func (in *HarvesterConfig) DeepCopy() *HarvesterConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HarvesterConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImportedConfig) DeepCopyInto(out *ImportedConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImportedConfig.
+func (in *ImportedConfig) DeepCopy() *ImportedConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ImportedConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceSchedule) DeepCopyInto(out *MaintenanceSchedule) {
+	*out = *in
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]MaintenanceWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceSchedule.
+func (in *MaintenanceSchedule) DeepCopy() *MaintenanceSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeGroupAutoscaling) DeepCopyInto(out *NodeGroupAutoscaling) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeGroupAutoscaling.
+func (in *NodeGroupAutoscaling) DeepCopy() *NodeGroupAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeGroupAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePool) DeepCopyInto(out *NodePool) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePool.
+func (in *NodePool) DeepCopy() *NodePool {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodePool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePoolList) DeepCopyInto(out *NodePoolList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NodePool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolList.
+func (in *NodePoolList) DeepCopy() *NodePoolList {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePoolList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodePoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePoolSpec) DeepCopyInto(out *NodePoolSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Taints != nil {
+		in, out := &in.Taints, &out.Taints
+		*out = make([]corev1.Taint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolSpec.
+func (in *NodePoolSpec) DeepCopy() *NodePoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePoolStatus) DeepCopyInto(out *NodePoolStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]genericcondition.GenericCondition, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolStatus.
+func (in *NodePoolStatus) DeepCopy() *NodePoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeTemplate) DeepCopyInto(out *NodeTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeTemplate.
+func (in *NodeTemplate) DeepCopy() *NodeTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeTemplateList) DeepCopyInto(out *NodeTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NodeTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeTemplateList.
+func (in *NodeTemplateList) DeepCopy() *NodeTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeTemplateSpec) DeepCopyInto(out *NodeTemplateSpec) {
+	*out = *in
+	if in.AmazonEC2Config != nil {
+		in, out := &in.AmazonEC2Config, &out.AmazonEC2Config
+		*out = new(AmazonEC2Config)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AzureConfig != nil {
+		in, out := &in.AzureConfig, &out.AzureConfig
+		*out = new(AzureConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HarvesterConfig != nil {
+		in, out := &in.HarvesterConfig, &out.HarvesterConfig
+		*out = new(HarvesterConfig)
+		**out = **in
+	}
+	if in.VSphereConfig != nil {
+		in, out := &in.VSphereConfig, &out.VSphereConfig
+		*out = new(VSphereConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeTemplateSpec.
+func (in *NodeTemplateSpec) DeepCopy() *NodeTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeTemplateStatus) DeepCopyInto(out *NodeTemplateStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]genericcondition.GenericCondition, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeTemplateStatus.
+func (in *NodeTemplateStatus) DeepCopy() *NodeTemplateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeTemplateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreDeleteHook) DeepCopyInto(out *PreDeleteHook) {
+	*out = *in
+	if in.Job != nil {
+		in, out := &in.Job, &out.Job
+		*out = new(PreDeleteJob)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreDeleteHook.
+func (in *PreDeleteHook) DeepCopy() *PreDeleteHook {
+	if in == nil {
+		return nil
+	}
+	out := new(PreDeleteHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreDeleteJob) DeepCopyInto(out *PreDeleteJob) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BackoffLimit != nil {
+		in, out := &in.BackoffLimit, &out.BackoffLimit
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreDeleteJob.
+func (in *PreDeleteJob) DeepCopy() *PreDeleteJob {
+	if in == nil {
+		return nil
+	}
+	out := new(PreDeleteJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Project) DeepCopyInto(out *Project) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Project.
+func (in *Project) DeepCopy() *Project {
 	if in == nil {
 		return nil
 	}
-	out := new(Cluster)
+	out := new(Project)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Cluster) DeepCopyObject() runtime.Object {
+func (in *Project) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -59,13 +2068,13 @@ func (in *Cluster) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterList) DeepCopyInto(out *ClusterList) {
+func (in *ProjectList) DeepCopyInto(out *ProjectList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Cluster, len(*in))
+		*out = make([]Project, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -73,18 +2082,18 @@ func (in *ClusterList) DeepCopyInto(out *ClusterList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterList.
-func (in *ClusterList) DeepCopy() *ClusterList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectList.
+func (in *ProjectList) DeepCopy() *ProjectList {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterList)
+	out := new(ProjectList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterList) DeepCopyObject() runtime.Object {
+func (in *ProjectList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -92,59 +2101,48 @@ func (in *ClusterList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
+func (in *ProjectSpec) DeepCopyInto(out *ProjectSpec) {
 	*out = *in
-	if in.ControlPlaneEndpoint != nil {
-		in, out := &in.ControlPlaneEndpoint, &out.ControlPlaneEndpoint
-		*out = new(Endpoint)
-		**out = **in
-	}
-	if in.EKSConfig != nil {
-		in, out := &in.EKSConfig, &out.EKSConfig
-		*out = new(ekscattleiov1.EKSClusterConfigSpec)
+	if in.ClusterSelector != nil {
+		in, out := &in.ClusterSelector, &out.ClusterSelector
+		*out = new(metav1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.ImportedConfig != nil {
-		in, out := &in.ImportedConfig, &out.ImportedConfig
-		*out = new(ImportedConfig)
+	if in.ResourceQuota != nil {
+		in, out := &in.ResourceQuota, &out.ResourceQuota
+		*out = new(v3.ProjectResourceQuota)
 		**out = **in
 	}
-	if in.ReferencedConfig != nil {
-		in, out := &in.ReferencedConfig, &out.ReferencedConfig
-		*out = new(ReferencedConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.K3SConfig != nil {
-		in, out := &in.K3SConfig, &out.K3SConfig
-		*out = new(v3.K3sConfig)
+	if in.NamespaceDefaultResourceQuota != nil {
+		in, out := &in.NamespaceDefaultResourceQuota, &out.NamespaceDefaultResourceQuota
+		*out = new(v3.NamespaceResourceQuota)
 		**out = **in
 	}
-	out.LocalClusterAuthEndpoint = in.LocalClusterAuthEndpoint
-	if in.RancherKubernetesEngineConfig != nil {
-		in, out := &in.RancherKubernetesEngineConfig, &out.RancherKubernetesEngineConfig
-		*out = new(types.RancherKubernetesEngineConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.RKE2Config != nil {
-		in, out := &in.RKE2Config, &out.RKE2Config
-		*out = new(v3.Rke2Config)
+	if in.ContainerDefaultResourceLimit != nil {
+		in, out := &in.ContainerDefaultResourceLimit, &out.ContainerDefaultResourceLimit
+		*out = new(v3.ContainerResourceLimit)
 		**out = **in
 	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpec.
-func (in *ClusterSpec) DeepCopy() *ClusterSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectSpec.
+func (in *ProjectSpec) DeepCopy() *ProjectSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterSpec)
+	out := new(ProjectSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
+func (in *ProjectStatus) DeepCopyInto(out *ProjectStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -154,50 +2152,132 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStatus.
-func (in *ClusterStatus) DeepCopy() *ClusterStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectStatus.
+func (in *ProjectStatus) DeepCopy() *ProjectStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterStatus)
+	out := new(ProjectStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Endpoint) DeepCopyInto(out *Endpoint) {
+func (in *ProxyConfig) DeepCopyInto(out *ProxyConfig) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Endpoint.
-func (in *Endpoint) DeepCopy() *Endpoint {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyConfig.
+func (in *ProxyConfig) DeepCopy() *ProxyConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(Endpoint)
+	out := new(ProxyConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImportedConfig) DeepCopyInto(out *ImportedConfig) {
+func (in *RancherToken) DeepCopyInto(out *RancherToken) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImportedConfig.
-func (in *ImportedConfig) DeepCopy() *ImportedConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RancherToken.
+func (in *RancherToken) DeepCopy() *RancherToken {
 	if in == nil {
 		return nil
 	}
-	out := new(ImportedConfig)
+	out := new(RancherToken)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RancherToken) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RancherTokenList) DeepCopyInto(out *RancherTokenList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RancherToken, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RancherTokenList.
+func (in *RancherTokenList) DeepCopy() *RancherTokenList {
+	if in == nil {
+		return nil
+	}
+	out := new(RancherTokenList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RancherTokenList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Project) DeepCopyInto(out *Project) {
+func (in *RancherTokenSpec) DeepCopyInto(out *RancherTokenSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RancherTokenSpec.
+func (in *RancherTokenSpec) DeepCopy() *RancherTokenSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RancherTokenSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RancherTokenStatus) DeepCopyInto(out *RancherTokenStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]genericcondition.GenericCondition, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RancherTokenStatus.
+func (in *RancherTokenStatus) DeepCopy() *RancherTokenStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RancherTokenStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RancherUser) DeepCopyInto(out *RancherUser) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -206,18 +2286,18 @@ func (in *Project) DeepCopyInto(out *Project) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Project.
-func (in *Project) DeepCopy() *Project {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RancherUser.
+func (in *RancherUser) DeepCopy() *RancherUser {
 	if in == nil {
 		return nil
 	}
-	out := new(Project)
+	out := new(RancherUser)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Project) DeepCopyObject() runtime.Object {
+func (in *RancherUser) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -225,13 +2305,13 @@ func (in *Project) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProjectList) DeepCopyInto(out *ProjectList) {
+func (in *RancherUserList) DeepCopyInto(out *RancherUserList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Project, len(*in))
+		*out = make([]RancherUser, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -239,18 +2319,18 @@ func (in *ProjectList) DeepCopyInto(out *ProjectList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectList.
-func (in *ProjectList) DeepCopy() *ProjectList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RancherUserList.
+func (in *RancherUserList) DeepCopy() *RancherUserList {
 	if in == nil {
 		return nil
 	}
-	out := new(ProjectList)
+	out := new(RancherUserList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ProjectList) DeepCopyObject() runtime.Object {
+func (in *RancherUserList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -258,38 +2338,38 @@ func (in *ProjectList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProjectSpec) DeepCopyInto(out *ProjectSpec) {
+func (in *RancherUserSpec) DeepCopyInto(out *RancherUserSpec) {
 	*out = *in
-	if in.ClusterSelector != nil {
-		in, out := &in.ClusterSelector, &out.ClusterSelector
-		*out = new(metav1.LabelSelector)
-		(*in).DeepCopyInto(*out)
+	if in.GlobalRoles != nil {
+		in, out := &in.GlobalRoles, &out.GlobalRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectSpec.
-func (in *ProjectSpec) DeepCopy() *ProjectSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RancherUserSpec.
+func (in *RancherUserSpec) DeepCopy() *RancherUserSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ProjectSpec)
+	out := new(RancherUserSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProjectStatus) DeepCopyInto(out *ProjectStatus) {
+func (in *RancherUserStatus) DeepCopyInto(out *RancherUserStatus) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectStatus.
-func (in *ProjectStatus) DeepCopy() *ProjectStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RancherUserStatus.
+func (in *RancherUserStatus) DeepCopy() *RancherUserStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ProjectStatus)
+	out := new(RancherUserStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -327,6 +2407,11 @@ func (in *RoleTemplate) DeepCopyInto(out *RoleTemplate) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RoleTemplateNames != nil {
+		in, out := &in.RoleTemplateNames, &out.RoleTemplateNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	out.Status = in.Status
 	return
 }
@@ -360,7 +2445,7 @@ func (in *RoleTemplateBinding) DeepCopyInto(out *RoleTemplateBinding) {
 		*out = make([]rbacv1.Subject, len(*in))
 		copy(*out, *in)
 	}
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -439,6 +2524,11 @@ func (in *RoleTemplateBindingScope) DeepCopy() *RoleTemplateBindingScope {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RoleTemplateBindingStatus) DeepCopyInto(out *RoleTemplateBindingStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]genericcondition.GenericCondition, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -500,3 +2590,108 @@ func (in *RoleTemplateStatus) DeepCopy() *RoleTemplateStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSHKeyConfig) DeepCopyInto(out *SSHKeyConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHKeyConfig.
+func (in *SSHKeyConfig) DeepCopy() *SSHKeyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SSHKeyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSHKeyStatus) DeepCopyInto(out *SSHKeyStatus) {
+	*out = *in
+	if in.AuthorizedFingerprints != nil {
+		in, out := &in.AuthorizedFingerprints, &out.AuthorizedFingerprints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHKeyStatus.
+func (in *SSHKeyStatus) DeepCopy() *SSHKeyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SSHKeyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SmokeTest) DeepCopyInto(out *SmokeTest) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SmokeTest.
+func (in *SmokeTest) DeepCopy() *SmokeTest {
+	if in == nil {
+		return nil
+	}
+	out := new(SmokeTest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeStrategy) DeepCopyInto(out *UpgradeStrategy) {
+	*out = *in
+	if in.Drain != nil {
+		in, out := &in.Drain, &out.Drain
+		*out = new(DrainOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeStrategy.
+func (in *UpgradeStrategy) DeepCopy() *UpgradeStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereConfig) DeepCopyInto(out *VSphereConfig) {
+	*out = *in
+	if in.Network != nil {
+		in, out := &in.Network, &out.Network
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Cfgparam != nil {
+		in, out := &in.Cfgparam, &out.Cfgparam
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereConfig.
+func (in *VSphereConfig) DeepCopy() *VSphereConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereConfig)
+	in.DeepCopyInto(out)
+	return out
+}
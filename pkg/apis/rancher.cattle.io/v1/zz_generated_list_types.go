@@ -43,6 +43,210 @@ func NewCluster(namespace, name string, obj Cluster) *Cluster {
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
+// ClusterBackupList is a list of ClusterBackup resources
+type ClusterBackupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ClusterBackup `json:"items"`
+}
+
+func NewClusterBackup(namespace, name string, obj ClusterBackup) *ClusterBackup {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("ClusterBackup").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterDiscoveryList is a list of ClusterDiscovery resources
+type ClusterDiscoveryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ClusterDiscovery `json:"items"`
+}
+
+func NewClusterDiscovery(namespace, name string, obj ClusterDiscovery) *ClusterDiscovery {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("ClusterDiscovery").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterExportList is a list of ClusterExport resources
+type ClusterExportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ClusterExport `json:"items"`
+}
+
+func NewClusterExport(namespace, name string, obj ClusterExport) *ClusterExport {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("ClusterExport").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterGroupList is a list of ClusterGroup resources
+type ClusterGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ClusterGroup `json:"items"`
+}
+
+func NewClusterGroup(namespace, name string, obj ClusterGroup) *ClusterGroup {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("ClusterGroup").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterReferenceGrantList is a list of ClusterReferenceGrant resources
+type ClusterReferenceGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ClusterReferenceGrant `json:"items"`
+}
+
+func NewClusterReferenceGrant(namespace, name string, obj ClusterReferenceGrant) *ClusterReferenceGrant {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("ClusterReferenceGrant").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// EncryptionKeyRotationList is a list of EncryptionKeyRotation resources
+type EncryptionKeyRotationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []EncryptionKeyRotation `json:"items"`
+}
+
+func NewEncryptionKeyRotation(namespace, name string, obj EncryptionKeyRotation) *EncryptionKeyRotation {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("EncryptionKeyRotation").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// EtcdSnapshotRestoreList is a list of EtcdSnapshotRestore resources
+type EtcdSnapshotRestoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []EtcdSnapshotRestore `json:"items"`
+}
+
+func NewEtcdSnapshotRestore(namespace, name string, obj EtcdSnapshotRestore) *EtcdSnapshotRestore {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("EtcdSnapshotRestore").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// GlobalRoleList is a list of GlobalRole resources
+type GlobalRoleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []GlobalRole `json:"items"`
+}
+
+func NewGlobalRole(namespace, name string, obj GlobalRole) *GlobalRole {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("GlobalRole").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// GlobalRoleBindingList is a list of GlobalRoleBinding resources
+type GlobalRoleBindingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []GlobalRoleBinding `json:"items"`
+}
+
+func NewGlobalRoleBinding(namespace, name string, obj GlobalRoleBinding) *GlobalRoleBinding {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("GlobalRoleBinding").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// GlobalRoleTemplateBindingList is a list of GlobalRoleTemplateBinding resources
+type GlobalRoleTemplateBindingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []GlobalRoleTemplateBinding `json:"items"`
+}
+
+func NewGlobalRoleTemplateBinding(namespace, name string, obj GlobalRoleTemplateBinding) *GlobalRoleTemplateBinding {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("GlobalRoleTemplateBinding").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NodePoolList is a list of NodePool resources
+type NodePoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []NodePool `json:"items"`
+}
+
+func NewNodePool(namespace, name string, obj NodePool) *NodePool {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("NodePool").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NodeTemplateList is a list of NodeTemplate resources
+type NodeTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []NodeTemplate `json:"items"`
+}
+
+func NewNodeTemplate(namespace, name string, obj NodeTemplate) *NodeTemplate {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("NodeTemplate").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
 // ProjectList is a list of Project resources
 type ProjectList struct {
 	metav1.TypeMeta `json:",inline"`
@@ -60,6 +264,40 @@ func NewProject(namespace, name string, obj Project) *Project {
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
+// RancherTokenList is a list of RancherToken resources
+type RancherTokenList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []RancherToken `json:"items"`
+}
+
+func NewRancherToken(namespace, name string, obj RancherToken) *RancherToken {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("RancherToken").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RancherUserList is a list of RancherUser resources
+type RancherUserList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []RancherUser `json:"items"`
+}
+
+func NewRancherUser(namespace, name string, obj RancherUser) *RancherUser {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("RancherUser").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
 // RoleTemplateList is a list of RoleTemplate resources
 type RoleTemplateList struct {
 	metav1.TypeMeta `json:",inline"`
@@ -28,10 +28,24 @@ import (
 )
 
 var (
-	ClusterResourceName             = "clusters"
-	ProjectResourceName             = "projects"
-	RoleTemplateResourceName        = "roletemplates"
-	RoleTemplateBindingResourceName = "roletemplatebindings"
+	ClusterResourceName                   = "clusters"
+	ClusterBackupResourceName             = "clusterbackups"
+	ClusterDiscoveryResourceName          = "clusterdiscoveries"
+	ClusterExportResourceName             = "clusterexports"
+	ClusterGroupResourceName              = "clustergroups"
+	ClusterReferenceGrantResourceName     = "clusterreferencegrants"
+	EncryptionKeyRotationResourceName     = "encryptionkeyrotations"
+	EtcdSnapshotRestoreResourceName       = "etcdsnapshotrestores"
+	GlobalRoleResourceName                = "globalroles"
+	GlobalRoleBindingResourceName         = "globalrolebindings"
+	GlobalRoleTemplateBindingResourceName = "globalroletemplatebindings"
+	NodePoolResourceName                  = "nodepools"
+	NodeTemplateResourceName              = "nodetemplates"
+	ProjectResourceName                   = "projects"
+	RancherTokenResourceName              = "ranchertokens"
+	RancherUserResourceName               = "rancherusers"
+	RoleTemplateResourceName              = "roletemplates"
+	RoleTemplateBindingResourceName       = "roletemplatebindings"
 )
 
 // SchemeGroupVersion is group version used to register these objects
@@ -57,8 +71,36 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&Cluster{},
 		&ClusterList{},
+		&ClusterBackup{},
+		&ClusterBackupList{},
+		&ClusterDiscovery{},
+		&ClusterDiscoveryList{},
+		&ClusterExport{},
+		&ClusterExportList{},
+		&ClusterGroup{},
+		&ClusterGroupList{},
+		&ClusterReferenceGrant{},
+		&ClusterReferenceGrantList{},
+		&EncryptionKeyRotation{},
+		&EncryptionKeyRotationList{},
+		&EtcdSnapshotRestore{},
+		&EtcdSnapshotRestoreList{},
+		&GlobalRole{},
+		&GlobalRoleList{},
+		&GlobalRoleBinding{},
+		&GlobalRoleBindingList{},
+		&GlobalRoleTemplateBinding{},
+		&GlobalRoleTemplateBindingList{},
+		&NodePool{},
+		&NodePoolList{},
+		&NodeTemplate{},
+		&NodeTemplateList{},
 		&Project{},
 		&ProjectList{},
+		&RancherToken{},
+		&RancherTokenList{},
+		&RancherUser{},
+		&RancherUserList{},
 		&RoleTemplate{},
 		&RoleTemplateList{},
 		&RoleTemplateBinding{},
@@ -0,0 +1,73 @@
+package v1beta2
+
+import (
+	eksv1 "github.com/rancher/eks-operator/pkg/apis/eks.cattle.io/v1"
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	rketypes "github.com/rancher/rke/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Cluster is served next to v1, which remains the storage version, and is converted by the operator webhook
+type Cluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterSpec      `json:"spec"`
+	Status v1.ClusterStatus `json:"status,omitempty"`
+}
+
+type ClusterSpec struct {
+	Provider                             Provider                    `json:"provider,omitempty"`
+	KubeConfig                           KubeConfig                  `json:"kubeConfig,omitempty"`
+	AgentEnvVars                         []corev1.EnvVar             `json:"agentEnvVars,omitempty"`
+	AgentImageOverride                   string                      `json:"agentImageOverride,omitempty"`
+	APIServerFiles                       *v1.APIServerFiles          `json:"apiServerFiles,omitempty"`
+	Autoscaling                          []v1.NodeGroupAutoscaling   `json:"autoscaling,omitempty"`
+	BootstrapCharts                      []v1.BootstrapChart         `json:"bootstrapCharts,omitempty"`
+	BootstrapManifests                   []v1.BootstrapManifest      `json:"bootstrapManifests,omitempty"`
+	ControlPlaneEndpoint                 *v1.Endpoint                `json:"controlPlaneEndpoint,omitempty"`
+	Decommission                         *v1.DecommissionPolicy      `json:"decommission,omitempty"`
+	DefaultPodSecurityPolicyTemplateName string                      `json:"defaultPodSecurityPolicyTemplateName,omitempty"`
+	DrainBeforeDelete                    *v1.DrainOptions            `json:"drainBeforeDelete,omitempty"`
+	EnableFleetAgent                     *bool                       `json:"enableFleetAgent,omitempty"`
+	EtcdSnapshotSchedule                 *v1.EtcdSnapshotSchedule    `json:"etcdSnapshotSchedule,omitempty"`
+	FleetLabels                          map[string]string           `json:"fleetLabels,omitempty"`
+	FleetWorkspaceName                   string                      `json:"fleetWorkspaceName,omitempty"`
+	Hibernate                            bool                        `json:"hibernate,omitempty"`
+	LocalClusterAuthEndpoint             v3.LocalClusterAuthEndpoint `json:"localClusterAuthEndpoint,omitempty"`
+	MaintenanceWindow                    *v1.MaintenanceSchedule     `json:"maintenanceWindow,omitempty"`
+	PreDeleteHooks                       []v1.PreDeleteHook          `json:"preDeleteHooks,omitempty"`
+	ProvisioningTimeoutSeconds           int                         `json:"provisioningTimeoutSeconds,omitempty"`
+	Proxy                                *v1.ProxyConfig             `json:"proxy,omitempty"`
+	SmokeTest                            *v1.SmokeTest               `json:"smokeTest,omitempty"`
+	SSHKey                               *v1.SSHKeyConfig            `json:"sshKey,omitempty"`
+	UpgradeStrategy                      *v1.UpgradeStrategy         `json:"upgradeStrategy,omitempty"`
+}
+
+// Provider selects how the cluster is provisioned, exactly one field is set
+type Provider struct {
+	Imported   *v1.ImportedConfig                      `json:"imported,omitempty"`
+	Referenced *v1.ReferencedConfig                    `json:"referenced,omitempty"`
+	RKE        *rketypes.RancherKubernetesEngineConfig `json:"rke,omitempty"`
+	EKS        *eksv1.EKSClusterConfigSpec             `json:"eks,omitempty"`
+	K3s        *v3.K3sConfig                           `json:"k3s,omitempty"`
+	RKE2       *v3.Rke2Config                          `json:"rke2,omitempty"`
+}
+
+// KubeConfig configures the kubeconfig secrets generated for the cluster
+type KubeConfig struct {
+	Format            string                    `json:"format,omitempty"`
+	SecretName        string                    `json:"secretName,omitempty"`
+	SecretLabels      map[string]string         `json:"secretLabels,omitempty"`
+	SecretAnnotations map[string]string         `json:"secretAnnotations,omitempty"`
+	SecretNamespaces  []string                  `json:"secretNamespaces,omitempty"`
+	CABundle          *v1.CABundleSource        `json:"caBundle,omitempty"`
+	ClusterRole       string                    `json:"clusterRole,omitempty"`
+	Additional        []v1.AdditionalKubeConfig `json:"additional,omitempty"`
+	RevokeOnNotReady  bool                      `json:"revokeOnNotReady,omitempty"`
+}
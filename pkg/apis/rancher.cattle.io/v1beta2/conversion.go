@@ -0,0 +1,113 @@
+package v1beta2
+
+import (
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+)
+
+// FromV1 converts a stored v1 Cluster to v1beta2
+func FromV1(in *v1.Cluster) *Cluster {
+	in = in.DeepCopy()
+	spec := in.Spec
+	out := &Cluster{
+		ObjectMeta: in.ObjectMeta,
+		Spec: ClusterSpec{
+			Provider: Provider{
+				Imported:   spec.ImportedConfig,
+				Referenced: spec.ReferencedConfig,
+				RKE:        spec.RancherKubernetesEngineConfig,
+				EKS:        spec.EKSConfig,
+				K3s:        spec.K3SConfig,
+				RKE2:       spec.RKE2Config,
+			},
+			KubeConfig: KubeConfig{
+				Format:            spec.KubeConfigFormat,
+				SecretName:        spec.ClientSecretName,
+				SecretLabels:      spec.ClientSecretLabels,
+				SecretAnnotations: spec.ClientSecretAnnotations,
+				SecretNamespaces:  spec.KubeConfigSecretNamespaces,
+				CABundle:          spec.KubeConfigCABundle,
+				ClusterRole:       spec.KubeConfigClusterRole,
+				Additional:        spec.AdditionalKubeConfigs,
+				RevokeOnNotReady:  spec.RevokeKubeConfigOnNotReady,
+			},
+			AgentEnvVars:                         spec.AgentEnvVars,
+			AgentImageOverride:                   spec.AgentImageOverride,
+			APIServerFiles:                       spec.APIServerFiles,
+			Autoscaling:                          spec.Autoscaling,
+			BootstrapCharts:                      spec.BootstrapCharts,
+			BootstrapManifests:                   spec.BootstrapManifests,
+			ControlPlaneEndpoint:                 spec.ControlPlaneEndpoint,
+			Decommission:                         spec.Decommission,
+			DefaultPodSecurityPolicyTemplateName: spec.DefaultPodSecurityPolicyTemplateName,
+			DrainBeforeDelete:                    spec.DrainBeforeDelete,
+			EnableFleetAgent:                     spec.EnableFleetAgent,
+			EtcdSnapshotSchedule:                 spec.EtcdSnapshotSchedule,
+			FleetLabels:                          spec.FleetLabels,
+			FleetWorkspaceName:                   spec.FleetWorkspaceName,
+			Hibernate:                            spec.Hibernate,
+			LocalClusterAuthEndpoint:             spec.LocalClusterAuthEndpoint,
+			MaintenanceWindow:                    spec.MaintenanceWindow,
+			PreDeleteHooks:                       spec.PreDeleteHooks,
+			ProvisioningTimeoutSeconds:           spec.ProvisioningTimeoutSeconds,
+			Proxy:                                spec.Proxy,
+			SmokeTest:                            spec.SmokeTest,
+			SSHKey:                               spec.SSHKey,
+			UpgradeStrategy:                      spec.UpgradeStrategy,
+		},
+		Status: in.Status,
+	}
+	out.APIVersion, out.Kind = SchemeGroupVersion.WithKind("Cluster").ToAPIVersionAndKind()
+	return out
+}
+
+// ToV1 converts cluster to the v1 storage version
+func (in *Cluster) ToV1() *v1.Cluster {
+	in = in.DeepCopy()
+	spec := in.Spec
+	out := &v1.Cluster{
+		ObjectMeta: in.ObjectMeta,
+		Spec: v1.ClusterSpec{
+			AdditionalKubeConfigs:                spec.KubeConfig.Additional,
+			ClientSecretName:                     spec.KubeConfig.SecretName,
+			ClientSecretLabels:                   spec.KubeConfig.SecretLabels,
+			ClientSecretAnnotations:              spec.KubeConfig.SecretAnnotations,
+			AgentEnvVars:                         spec.AgentEnvVars,
+			AgentImageOverride:                   spec.AgentImageOverride,
+			APIServerFiles:                       spec.APIServerFiles,
+			Autoscaling:                          spec.Autoscaling,
+			BootstrapCharts:                      spec.BootstrapCharts,
+			BootstrapManifests:                   spec.BootstrapManifests,
+			ControlPlaneEndpoint:                 spec.ControlPlaneEndpoint,
+			Decommission:                         spec.Decommission,
+			DefaultPodSecurityPolicyTemplateName: spec.DefaultPodSecurityPolicyTemplateName,
+			DrainBeforeDelete:                    spec.DrainBeforeDelete,
+			EnableFleetAgent:                     spec.EnableFleetAgent,
+			EtcdSnapshotSchedule:                 spec.EtcdSnapshotSchedule,
+			FleetLabels:                          spec.FleetLabels,
+			FleetWorkspaceName:                   spec.FleetWorkspaceName,
+			Hibernate:                            spec.Hibernate,
+			EKSConfig:                            spec.Provider.EKS,
+			ImportedConfig:                       spec.Provider.Imported,
+			ReferencedConfig:                     spec.Provider.Referenced,
+			RevokeKubeConfigOnNotReady:           spec.KubeConfig.RevokeOnNotReady,
+			K3SConfig:                            spec.Provider.K3s,
+			KubeConfigCABundle:                   spec.KubeConfig.CABundle,
+			KubeConfigClusterRole:                spec.KubeConfig.ClusterRole,
+			KubeConfigFormat:                     spec.KubeConfig.Format,
+			KubeConfigSecretNamespaces:           spec.KubeConfig.SecretNamespaces,
+			LocalClusterAuthEndpoint:             spec.LocalClusterAuthEndpoint,
+			MaintenanceWindow:                    spec.MaintenanceWindow,
+			PreDeleteHooks:                       spec.PreDeleteHooks,
+			ProvisioningTimeoutSeconds:           spec.ProvisioningTimeoutSeconds,
+			Proxy:                                spec.Proxy,
+			RancherKubernetesEngineConfig:        spec.Provider.RKE,
+			RKE2Config:                           spec.Provider.RKE2,
+			SmokeTest:                            spec.SmokeTest,
+			SSHKey:                               spec.SSHKey,
+			UpgradeStrategy:                      spec.UpgradeStrategy,
+		},
+		Status: in.Status,
+	}
+	out.APIVersion, out.Kind = v1.SchemeGroupVersion.WithKind("Cluster").ToAPIVersionAndKind()
+	return out
+}
@@ -0,0 +1,178 @@
+package clients
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rancher/lasso/pkg/cache"
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/crd"
+	"github.com/rancher/wrangler/pkg/apply"
+	"github.com/rancher/wrangler/pkg/schemes"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	secretGVK  = corev1.SchemeGroupVersion.WithKind("Secret")
+	clusterGVK = v1.SchemeGroupVersion.WithKind("Cluster")
+)
+
+// cacheOptions only caches the secrets created through apply, such as kubeconfigs, the secrets referenced by
+// clusters and other objects are read from the API server. This applies to every reader of the shared secret
+// cache, see Clients. With a single watched namespace the namespaced rancher.cattle.io kinds
+// are only listed in that namespace so the operator can run with a role there. Informers can't list several
+// namespaces, with more than one the kinds are listed in every namespace and filtered by namespaceFilter. Only
+// the clusters matching clusterSelector are cached, the others are reconciled by other shards. Each kind is
+// resynced at its own jittered period.
+func cacheOptions(clientOpts Options) *cache.SharedCacheFactoryOptions {
+	opts := &cache.SharedCacheFactoryOptions{
+		DefaultResync: clientOpts.ResyncPeriod,
+		KindResync:    resyncPeriods(clientOpts.ResyncPeriod, clientOpts.ResyncJitter),
+		KindTweakList: map[schema.GroupVersionKind]cache.TweakListOptionsFunc{
+			secretGVK: func(opts *metav1.ListOptions) {
+				opts.LabelSelector = apply.LabelHash
+			},
+		},
+	}
+	if clientOpts.ClusterSelector != "" {
+		opts.KindTweakList[clusterGVK] = func(opts *metav1.ListOptions) {
+			opts.LabelSelector = clientOpts.ClusterSelector
+		}
+	}
+	if len(clientOpts.Namespaces) != 1 {
+		return opts
+	}
+	opts.KindNamespace = map[schema.GroupVersionKind]string{}
+	for _, c := range crd.List() {
+		if !c.NonNamespace {
+			opts.KindNamespace[c.GVK] = clientOpts.Namespaces[0]
+		}
+	}
+	return opts
+}
+
+// resyncPeriods gives every kind of the scheme its own resync period between period and period*(1+jitter), so
+// the informers of all kinds don't requeue every object at the same time
+func resyncPeriods(period time.Duration, jitter float64) map[schema.GroupVersionKind]time.Duration {
+	if period <= 0 || jitter <= 0 {
+		return nil
+	}
+	random := rand.New(rand.NewSource(time.Now().UnixNano()))
+	periods := map[schema.GroupVersionKind]time.Duration{}
+	for gvk := range schemes.All.AllKnownTypes() {
+		periods[gvk] = period + time.Duration(random.Float64()*jitter*float64(period))
+	}
+	return periods
+}
+
+// stripCachedFields removes the managed fields from the objects listed and watched through rt, and the kubectl
+// last applied configuration from secrets, before they are decoded into the informer caches. The operator reads
+// neither and they are often larger than the objects. Single objects are returned as is. Other kinds keep the
+// last applied configuration since objects from the caches are updated and an update would remove it.
+func stripCachedFields(rt http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		resp, err := rt.RoundTrip(req)
+		if err != nil || req.Method != http.MethodGet || resp.StatusCode != http.StatusOK ||
+			!strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
+			return resp, err
+		}
+
+		secrets := strings.HasSuffix(req.URL.Path, "/secrets")
+		if watch := req.URL.Query().Get("watch"); watch == "true" || watch == "1" {
+			resp.Body = stripEvents(resp.Body, secrets)
+			return resp, nil
+		}
+
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		var list map[string]interface{}
+		dec := json.NewDecoder(bytes.NewReader(body))
+		dec.UseNumber()
+		if err := dec.Decode(&list); err != nil {
+			return resp, nil
+		}
+		items, ok := list["items"].([]interface{})
+		if !ok {
+			return resp, nil
+		}
+		for _, item := range items {
+			stripFields(item, secrets)
+		}
+		if body, err = json.Marshal(list); err != nil {
+			return nil, err
+		}
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+		resp.ContentLength = int64(len(body))
+		resp.Header.Del("Content-Length")
+		return resp, nil
+	})
+}
+
+// stripEvents rewrites a stream of watch events as they are read
+func stripEvents(body io.ReadCloser, secrets bool) io.ReadCloser {
+	r, w := io.Pipe()
+	go func() {
+		defer body.Close()
+		dec := json.NewDecoder(body)
+		dec.UseNumber()
+		enc := json.NewEncoder(w)
+		for {
+			var event map[string]interface{}
+			if err := dec.Decode(&event); err != nil {
+				w.CloseWithError(err)
+				return
+			}
+			stripFields(event["object"], secrets)
+			if err := enc.Encode(event); err != nil {
+				w.CloseWithError(err)
+				return
+			}
+		}
+	}()
+	return &pipeCloser{
+		PipeReader: r,
+		body:       body,
+	}
+}
+
+// pipeCloser closes the response body along with the pipe, which ends the goroutine decoding it
+type pipeCloser struct {
+	*io.PipeReader
+	body io.Closer
+}
+
+func (p *pipeCloser) Close() error {
+	p.PipeReader.Close()
+	return p.body.Close()
+}
+
+func stripFields(obj interface{}, secrets bool) {
+	data, _ := obj.(map[string]interface{})
+	meta, _ := data["metadata"].(map[string]interface{})
+	if meta == nil {
+		return
+	}
+	delete(meta, "managedFields")
+	if annotations, ok := meta["annotations"].(map[string]interface{}); ok && secrets {
+		delete(annotations, corev1.LastAppliedConfigAnnotation)
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -2,7 +2,11 @@ package clients
 
 import (
 	"context"
+	"time"
 
+	"github.com/rancher/lasso/pkg/cache"
+	"github.com/rancher/lasso/pkg/client"
+	"github.com/rancher/lasso/pkg/controller"
 	"github.com/rancher/rancher-operator/pkg/crd"
 	"github.com/rancher/rancher-operator/pkg/generated/controllers/fleet.cattle.io"
 	fleetcontrollers "github.com/rancher/rancher-operator/pkg/generated/controllers/fleet.cattle.io/v1alpha1"
@@ -10,63 +14,271 @@ import (
 	mgmtcontrollers "github.com/rancher/rancher-operator/pkg/generated/controllers/management.cattle.io/v3"
 	"github.com/rancher/rancher-operator/pkg/generated/controllers/rancher.cattle.io"
 	rocontrollers "github.com/rancher/rancher-operator/pkg/generated/controllers/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/metrics"
+	"github.com/rancher/wrangler/pkg/apply"
 	"github.com/rancher/wrangler/pkg/clients"
+	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
+	"github.com/rancher/wrangler/pkg/generic"
+	"github.com/rancher/wrangler/pkg/schemes"
 	"github.com/rancher/wrangler/pkg/start"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/flowcontrol"
 )
 
+// Clients are the clients and caches shared by all controllers. The secret cache and informer of Core only hold
+// the secrets created through apply, see cacheOptions. Any other secret, such as one created by a user or with
+// Create, must be read with Core.Secret().Get, and watches on secrets never see changes to it, it is read again
+// when the object referencing it is resynced.
 type Clients struct {
 	*clients.Clients
 	rocontrollers.Interface
 
 	Management mgmtcontrollers.Interface
 	Fleet      fleetcontrollers.Interface
+	Recorder   record.EventRecorder
+	// Conversion serves clusters as v1beta2 through the webhook when set
+	Conversion *crd.Conversion
+	// ManagementRateLimiter throttles the requests to Rancher, nil if they aren't throttled separately
+	ManagementRateLimiter flowcontrol.RateLimiter
 
-	starters []start.Starter
+	// Standalone is true when Rancher runs in another cluster than the operator. The management clients reach
+	// the cluster of Rancher, otherwise they are the same as the others. Apply routes management.cattle.io and
+	// fleet.cattle.io objects to the cluster of Rancher.
+	Standalone       bool
+	ManagementConfig *rest.Config
+	ManagementK8s    kubernetes.Interface
+	ManagementCore   corecontrollers.Interface
+	ManagementApply  apply.Apply
+
+	management  *clients.Clients
+	starters    []start.Starter
+	workers     int
+	syncTimeout time.Duration
+	factories   *factorySet
+}
+
+// DefaultWorkers is the number of workers of the controllers of kinds without a configured number
+const DefaultWorkers = 5
+
+// Options configure the controllers and caches of the clients
+type Options struct {
+	Workers Workers
+	// Namespaces restricts the rancher.cattle.io objects reconciled to these namespaces, empty for all
+	Namespaces []string
+	// ClusterSelector restricts the clusters that are cached and reconciled, empty for all clusters
+	ClusterSelector string
+	RateLimits      RateLimits
+	// ResyncPeriod is how often the informers requeue every object, each kind gets up to ResyncJitter times
+	// the period added
+	ResyncPeriod time.Duration
+	ResyncJitter float64
+	// CacheSyncTimeout fails Start when the caches take longer to sync, 0 waits forever
+	CacheSyncTimeout time.Duration
+	// Management is the cluster Rancher runs in, nil if it is the cluster of the operator
+	Management clientcmd.ClientConfig
+}
+
+// Workers sets the number of workers reconciling each kind, kinds that aren't listed get Default workers
+type Workers struct {
+	Default int
+	Kinds   map[schema.GroupVersionKind]int
 }
 
 func (a *Clients) Start(ctx context.Context) error {
-	if err := crd.Create(ctx, a.RESTConfig); err != nil {
+	if err := crd.Create(ctx, a.RESTConfig, a.Conversion); err != nil {
 		return err
 	}
 
-	if err := a.Clients.Start(ctx); err != nil {
-		return err
-	}
+	return waitForSync(ctx, a.syncTimeout, a.factories.controllerFactories, func() error {
+		if err := a.Clients.Start(ctx); err != nil {
+			return err
+		}
+		if a.management != nil {
+			if err := a.management.Start(ctx); err != nil {
+				return err
+			}
+		}
+		return start.All(ctx, a.workers, a.starters...)
+	})
+}
 
-	return start.All(ctx, 5, a.starters...)
+// Drain stops the handlers of every controller from being called and waits up to timeout for running handlers
+// to return. It returns false if some were still running.
+func (a *Clients) Drain(timeout time.Duration) bool {
+	return a.factories.lifecycle.drain(timeout)
 }
 
-func New(clientConfig clientcmd.ClientConfig) (*Clients, error) {
-	clients, err := clients.New(clientConfig, nil)
+// New creates the clients of the operator. When namespaces are given the rancher.cattle.io objects of other
+// namespaces are ignored, all other kinds are watched in every namespace. The management.cattle.io and
+// fleet.cattle.io kinds are read from opts.Management when it is set.
+func New(clientConfig clientcmd.ClientConfig, opts Options) (*Clients, error) {
+	if opts.Workers.Default <= 0 {
+		opts.Workers.Default = DefaultWorkers
+	}
+	clientConfig = &rateLimitedConfig{
+		delegate: clientConfig,
+		qps:      opts.RateLimits.QPS,
+		burst:    opts.RateLimits.Burst,
+	}
+	shared := Options{
+		Workers:      opts.Workers,
+		ResyncPeriod: opts.ResyncPeriod,
+		ResyncJitter: opts.ResyncJitter,
+	}
+
+	restConfig, err := clientConfig.ClientConfig()
 	if err != nil {
 		return nil, err
 	}
+	factories := &factorySet{
+		lifecycle: &lifecycle{},
+	}
+
+	var mgmtClients *clients.Clients
+	if opts.Management != nil {
+		managementClientConfig := &rateLimitedConfig{
+			delegate: opts.Management,
+			qps:      opts.RateLimits.QPS,
+			burst:    opts.RateLimits.Burst,
+		}
+		managementRESTConfig, err := managementClientConfig.ClientConfig()
+		if err != nil {
+			return nil, err
+		}
+		factoryOpts, err := factories.options(managementRESTConfig, shared)
+		if err != nil {
+			return nil, err
+		}
+		if mgmtClients, err = clients.New(managementClientConfig, factoryOpts); err != nil {
+			return nil, err
+		}
+	}
 
-	rancher, err := rancher.NewFactoryFromConfig(clients.RESTConfig)
+	factoryOpts, err := factories.options(restConfig, shared)
+	if err != nil {
+		return nil, err
+	}
+	clients, err := clients.New(clientConfig, factoryOpts)
 	if err != nil {
 		return nil, err
 	}
 
-	mgmt, err := management.NewFactoryFromConfig(clients.RESTConfig)
+	standalone := mgmtClients != nil
+	if standalone {
+		if clients.Apply, err = routedApply(clients.RESTConfig, mgmtClients.RESTConfig); err != nil {
+			return nil, err
+		}
+	} else {
+		mgmtClients = clients
+	}
+
+	factoryOpts, err = factories.options(clients.RESTConfig, opts)
+	if err != nil {
+		return nil, err
+	}
+	rancher, err := rancher.NewFactoryFromConfigWithOptions(clients.RESTConfig, factoryOpts)
 	if err != nil {
 		return nil, err
 	}
 
-	fleet, err := fleet.NewFactoryFromConfig(clients.RESTConfig)
+	// management.cattle.io is served by Rancher, track its latency
+	mgmtConfig := rest.CopyConfig(mgmtClients.RESTConfig)
+	mgmtConfig.Wrap(metrics.InstrumentRancherTransport)
+	mgmtLimiter := opts.RateLimits.managementRateLimiter()
+	if mgmtLimiter != nil {
+		mgmtConfig.RateLimiter = mgmtLimiter
+	}
+	factoryOpts, err = factories.options(mgmtConfig, shared)
+	if err != nil {
+		return nil, err
+	}
+	mgmt, err := management.NewFactoryFromConfigWithOptions(mgmtConfig, factoryOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	factoryOpts, err = factories.options(mgmtClients.RESTConfig, shared)
+	if err != nil {
+		return nil, err
+	}
+	fleet, err := fleet.NewFactoryFromConfigWithOptions(mgmtClients.RESTConfig, factoryOpts)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Clients{
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{
+		Interface: clients.K8s.CoreV1().Events(""),
+	})
+
+	c := &Clients{
 		Clients:    clients,
 		Interface:  rancher.Rancher().V1(),
 		Management: mgmt.Management().V3(),
 		Fleet:      fleet.Fleet().V1alpha1(),
+		Recorder: broadcaster.NewRecorder(schemes.All, corev1.EventSource{
+			Component: "rancher-operator",
+		}),
 		starters: []start.Starter{
 			rancher,
 			mgmt,
 			fleet,
 		},
+		ManagementRateLimiter: mgmtLimiter,
+		Standalone:            standalone,
+		ManagementConfig:      mgmtConfig,
+		ManagementK8s:         mgmtClients.K8s,
+		ManagementCore:        mgmtClients.Core,
+		ManagementApply:       mgmtClients.Apply,
+		workers:               opts.Workers.Default,
+		syncTimeout:           opts.CacheSyncTimeout,
+		factories:             factories,
+	}
+	// the caches of the management clients are started with the others unless they are the same
+	if standalone {
+		c.management = mgmtClients
+	}
+	return c, nil
+}
+
+// factorySet holds the shared controller factories created for the clients
+type factorySet struct {
+	lifecycle           *lifecycle
+	controllerFactories []controller.SharedControllerFactory
+}
+
+// options gives each factory its own shared controllers with the configured number of workers per kind, the
+// factories only start the controllers of their own kinds. The caches are listed through stripCachedFields.
+func (f *factorySet) options(config *rest.Config, opts Options) (*generic.FactoryOptions, error) {
+	config = rest.CopyConfig(config)
+	config.Wrap(stripCachedFields)
+	clientFactory, err := client.NewSharedClientFactory(config, &client.SharedClientFactoryOptions{
+		Scheme: schemes.All,
+	})
+	if err != nil {
+		return nil, err
+	}
+	controllerFactory := controller.NewSharedControllerFactory(cache.NewSharedCachedFactory(clientFactory, cacheOptions(opts)),
+		&controller.SharedControllerFactoryOptions{
+			DefaultWorkers:  opts.Workers.Default,
+			KindWorkers:     opts.Workers.Kinds,
+			KindRateLimiter: opts.RateLimits.kindRateLimiters(),
+		})
+	f.controllerFactories = append(f.controllerFactories, controllerFactory)
+	if len(opts.Namespaces) > 0 {
+		controllerFactory = newNamespaceFilter(controllerFactory, opts.Namespaces)
+	}
+	controllerFactory = &lifecycleFactory{
+		SharedControllerFactory: controllerFactory,
+		lifecycle:               f.lifecycle,
+	}
+	return &generic.FactoryOptions{
+		SharedControllerFactory: controllerFactory,
 	}, nil
 }
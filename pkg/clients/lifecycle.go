@@ -0,0 +1,115 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rancher/lasso/pkg/controller"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// lifecycle tracks the handlers of every controller. Once draining no handler is started, the items taken from
+// the workqueues are dropped and reconciled by the next leader with its initial sync.
+type lifecycle struct {
+	lock     sync.RWMutex
+	draining bool
+	running  sync.WaitGroup
+}
+
+func (l *lifecycle) enter() bool {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	if l.draining {
+		return false
+	}
+	l.running.Add(1)
+	return true
+}
+
+// drain stops starting handlers and waits up to timeout for the running ones to return
+func (l *lifecycle) drain(timeout time.Duration) bool {
+	l.lock.Lock()
+	l.draining = true
+	l.lock.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		l.running.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+type lifecycleFactory struct {
+	controller.SharedControllerFactory
+	lifecycle *lifecycle
+}
+
+func (f *lifecycleFactory) ForResourceKind(gvr schema.GroupVersionResource, kind string, namespaced bool) controller.SharedController {
+	return &lifecycleController{
+		SharedController: f.SharedControllerFactory.ForResourceKind(gvr, kind, namespaced),
+		lifecycle:        f.lifecycle,
+	}
+}
+
+type lifecycleController struct {
+	controller.SharedController
+	lifecycle *lifecycle
+}
+
+func (c *lifecycleController) RegisterHandler(ctx context.Context, name string, handler controller.SharedControllerHandler) {
+	c.SharedController.RegisterHandler(ctx, name, controller.SharedControllerHandlerFunc(func(key string, obj runtime.Object) (runtime.Object, error) {
+		if !c.lifecycle.enter() {
+			return obj, nil
+		}
+		defer c.lifecycle.running.Done()
+		return handler.OnChange(key, obj)
+	}))
+}
+
+// waitForSync runs start and fails once the caches of the factories took longer than timeout to sync, naming
+// the kinds that are still syncing
+func waitForSync(ctx context.Context, timeout time.Duration, factories []controller.SharedControllerFactory, start func() error) error {
+	if timeout <= 0 {
+		return start()
+	}
+
+	result := make(chan error, 1)
+	go func() {
+		result <- start()
+	}()
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(timeout):
+	}
+
+	// the context is already done so this only reports the state of the caches
+	expired, cancel := context.WithCancel(ctx)
+	cancel()
+	var pending []string
+	for _, factory := range factories {
+		for gvk, synced := range factory.SharedCacheFactory().WaitForCacheSync(expired) {
+			if !synced {
+				pending = append(pending, gvk.String())
+			}
+		}
+	}
+	if len(pending) == 0 {
+		return fmt.Errorf("controllers not started after %s", timeout)
+	}
+	sort.Strings(pending)
+	return fmt.Errorf("caches not synced after %s: %s", timeout, strings.Join(pending, ", "))
+}
@@ -0,0 +1,93 @@
+package clients
+
+import (
+	fleet "github.com/rancher/fleet/pkg/apis/fleet.cattle.io/v1alpha1"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/wrangler/pkg/apply"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// managementGroups are served by the cluster Rancher runs in
+var managementGroups = map[string]bool{
+	v3.SchemeGroupVersion.Group:    true,
+	fleet.SchemeGroupVersion.Group: true,
+}
+
+// ManagementClientConfig returns the client config of the cluster Rancher runs in when the operator runs
+// standalone, from a kubeconfig file or a URL and token, nil when it runs in the same cluster
+func ManagementClientConfig(kubeConfig, url, token, caFile string) clientcmd.ClientConfig {
+	if kubeConfig != "" {
+		return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+			&clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeConfig},
+			&clientcmd.ConfigOverrides{})
+	}
+	if url == "" {
+		return nil
+	}
+	return clientcmd.NewDefaultClientConfig(clientcmdapi.Config{
+		Clusters: map[string]*clientcmdapi.Cluster{
+			"management": {
+				Server:               url,
+				CertificateAuthority: caFile,
+			},
+		},
+		AuthInfos: map[string]*clientcmdapi.AuthInfo{
+			"management": {
+				Token: token,
+			},
+		},
+		Contexts: map[string]*clientcmdapi.Context{
+			"management": {
+				Cluster:  "management",
+				AuthInfo: "management",
+			},
+		},
+		CurrentContext: "management",
+	}, &clientcmd.ConfigOverrides{})
+}
+
+// routedApply applies the management.cattle.io and fleet.cattle.io objects to the cluster of management and
+// all others to the cluster of local, so one apply set can hold both
+func routedApply(local, management *rest.Config) (apply.Apply, error) {
+	localDiscovery, err := discovery.NewDiscoveryClientForConfig(local)
+	if err != nil {
+		return nil, err
+	}
+	managementDiscovery, err := discovery.NewDiscoveryClientForConfig(management)
+	if err != nil {
+		return nil, err
+	}
+	localClients := apply.NewClientFactory(local)
+	managementClients := apply.NewClientFactory(management)
+
+	return apply.New(&routedDiscovery{
+		DiscoveryInterface: localDiscovery,
+		management:         managementDiscovery,
+	}, func(gvr schema.GroupVersionResource) (dynamic.NamespaceableResourceInterface, error) {
+		if managementGroups[gvr.Group] {
+			return managementClients(gvr)
+		}
+		return localClients(gvr)
+	}), nil
+}
+
+// routedDiscovery resolves the resources of the management groups in the cluster of management, apply maps
+// kinds to resources through ServerResourcesForGroupVersion
+type routedDiscovery struct {
+	discovery.DiscoveryInterface
+	management discovery.DiscoveryInterface
+}
+
+func (r *routedDiscovery) ServerResourcesForGroupVersion(groupVersion string) (*metav1.APIResourceList, error) {
+	gv, err := schema.ParseGroupVersion(groupVersion)
+	if err == nil && managementGroups[gv.Group] {
+		return r.management.ServerResourcesForGroupVersion(groupVersion)
+	}
+	return r.DiscoveryInterface.ServerResourcesForGroupVersion(groupVersion)
+}
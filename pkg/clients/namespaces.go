@@ -0,0 +1,52 @@
+package clients
+
+import (
+	"context"
+
+	"github.com/rancher/lasso/pkg/controller"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kcache "k8s.io/client-go/tools/cache"
+)
+
+// namespaceFilter skips namespaced objects outside the watched namespaces before any handler is called
+type namespaceFilter struct {
+	controller.SharedControllerFactory
+	namespaces map[string]bool
+}
+
+func newNamespaceFilter(factory controller.SharedControllerFactory, namespaces []string) controller.SharedControllerFactory {
+	f := &namespaceFilter{
+		SharedControllerFactory: factory,
+		namespaces:              map[string]bool{},
+	}
+	for _, namespace := range namespaces {
+		f.namespaces[namespace] = true
+	}
+	return f
+}
+
+func (f *namespaceFilter) ForResourceKind(gvr schema.GroupVersionResource, kind string, namespaced bool) controller.SharedController {
+	c := f.SharedControllerFactory.ForResourceKind(gvr, kind, namespaced)
+	if !namespaced {
+		return c
+	}
+	return &namespacedController{
+		SharedController: c,
+		namespaces:       f.namespaces,
+	}
+}
+
+type namespacedController struct {
+	controller.SharedController
+	namespaces map[string]bool
+}
+
+func (c *namespacedController) RegisterHandler(ctx context.Context, name string, handler controller.SharedControllerHandler) {
+	c.SharedController.RegisterHandler(ctx, name, controller.SharedControllerHandlerFunc(func(key string, obj runtime.Object) (runtime.Object, error) {
+		if namespace, _, err := kcache.SplitMetaNamespaceKey(key); err == nil && !c.namespaces[namespace] {
+			return obj, nil
+		}
+		return handler.OnChange(key, obj)
+	}))
+}
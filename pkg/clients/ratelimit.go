@@ -0,0 +1,90 @@
+package clients
+
+import (
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// RateLimits throttle the requests of the clients and the reconciles of clusters, zero values keep the
+// client-go and wrangler defaults
+type RateLimits struct {
+	QPS   float32
+	Burst int
+	// ManagementQPS and ManagementBurst are shared by the requests to the management.cattle.io API and the
+	// Rancher API, both served by Rancher
+	ManagementQPS   float32
+	ManagementBurst int
+	// ClusterReconcileQPS and ClusterReconcileBurst limit how fast requeued clusters are reconciled, for
+	// example after every cluster was resynced
+	ClusterReconcileQPS   float32
+	ClusterReconcileBurst int
+}
+
+func (r RateLimits) managementRateLimiter() flowcontrol.RateLimiter {
+	if r.ManagementQPS <= 0 {
+		return nil
+	}
+	burst := r.ManagementBurst
+	if burst <= 0 {
+		burst = int(r.ManagementQPS) + 1
+	}
+	return flowcontrol.NewTokenBucketRateLimiter(r.ManagementQPS, burst)
+}
+
+// kindRateLimiters replaces the default rate limiter of the cluster workqueue, failures still back off per
+// cluster the way the default does
+func (r RateLimits) kindRateLimiters() map[schema.GroupVersionKind]workqueue.RateLimiter {
+	if r.ClusterReconcileQPS <= 0 {
+		return nil
+	}
+	burst := r.ClusterReconcileBurst
+	if burst <= 0 {
+		burst = int(r.ClusterReconcileQPS) + 1
+	}
+	return map[schema.GroupVersionKind]workqueue.RateLimiter{
+		clusterGVK: workqueue.NewMaxOfRateLimiter(
+			workqueue.NewItemExponentialFailureRateLimiter(5*time.Millisecond, 1000*time.Second),
+			&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(r.ClusterReconcileQPS), burst)},
+		),
+	}
+}
+
+// rateLimitedConfig sets the QPS and burst of every rest config created from the client config
+type rateLimitedConfig struct {
+	delegate clientcmd.ClientConfig
+	qps      float32
+	burst    int
+}
+
+func (c *rateLimitedConfig) RawConfig() (clientcmdapi.Config, error) {
+	return c.delegate.RawConfig()
+}
+
+func (c *rateLimitedConfig) ClientConfig() (*rest.Config, error) {
+	config, err := c.delegate.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	if c.qps > 0 {
+		config.QPS = c.qps
+	}
+	if c.burst > 0 {
+		config.Burst = c.burst
+	}
+	return config, nil
+}
+
+func (c *rateLimitedConfig) Namespace() (string, bool, error) {
+	return c.delegate.Namespace()
+}
+
+func (c *rateLimitedConfig) ConfigAccess() clientcmd.ConfigAccess {
+	return c.delegate.ConfigAccess()
+}
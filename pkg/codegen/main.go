@@ -18,11 +18,17 @@ func main() {
 			"rancher.cattle.io": {
 				Types: []interface{}{
 					"./pkg/apis/rancher.cattle.io/v1",
+					"./pkg/apis/rancher.cattle.io/v1beta2",
 				},
 				GenerateTypes: true,
+				// the clientset, listers and informers under pkg/generated are for tools outside the operator
+				GenerateClients:   true,
+				GenerateListers:   true,
+				GenerateInformers: true,
 			},
 			"fleet.cattle.io": {
 				Types: []interface{}{
+					fleet.Bundle{},
 					fleet.GitRepo{},
 					fleet.Cluster{},
 					fleet.ClusterGroup{},
@@ -34,7 +40,12 @@ func main() {
 					v3.Cluster{},
 					v3.ClusterRegistrationToken{},
 					v3.ClusterRoleTemplateBinding{},
+					v3.EtcdBackup{},
 					v3.FleetWorkspace{},
+					v3.GlobalRole{},
+					v3.GlobalRoleBinding{},
+					v3.Node{},
+					v3.NodePool{},
 					v3.Project{},
 					v3.ProjectRoleTemplateBinding{},
 					v3.RoleTemplate{},
@@ -0,0 +1,167 @@
+// Package condition sets metav1.Condition conditions with the same methods as wrangler's condition package, which
+// only handles genericcondition.GenericCondition
+package condition
+
+import (
+	"time"
+
+	"github.com/rancher/wrangler/pkg/generic"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Object holds conditions, the obj passed to the methods of Cond must implement it
+type Object interface {
+	// GetConditions returns the conditions of the object, they are modified in place
+	GetConditions() *[]metav1.Condition
+	// GetObservedGeneration is the generation of the object the conditions are set for
+	GetObservedGeneration() int64
+}
+
+type Cond string
+
+func (c Cond) GetStatus(obj interface{}) string {
+	cond := c.find(obj)
+	if cond == nil {
+		return ""
+	}
+	return string(cond.Status)
+}
+
+func (c Cond) SetError(obj interface{}, reason string, err error) {
+	if err == nil || err == generic.ErrSkip {
+		c.True(obj)
+		c.Message(obj, "")
+		c.Reason(obj, reason)
+		return
+	}
+	if reason == "" {
+		reason = "Error"
+	}
+	c.False(obj)
+	c.Message(obj, err.Error())
+	c.Reason(obj, reason)
+}
+
+func (c Cond) SetStatus(obj interface{}, status string) {
+	cond := c.findOrCreate(obj)
+	if string(cond.Status) != status {
+		cond.Status = metav1.ConditionStatus(status)
+		cond.LastTransitionTime = now()
+	}
+	c.touch(obj, cond)
+}
+
+func (c Cond) SetStatusBool(obj interface{}, val bool) {
+	if val {
+		c.True(obj)
+	} else {
+		c.False(obj)
+	}
+}
+
+func (c Cond) True(obj interface{}) {
+	c.SetStatus(obj, string(metav1.ConditionTrue))
+}
+
+func (c Cond) IsTrue(obj interface{}) bool {
+	return c.GetStatus(obj) == string(metav1.ConditionTrue)
+}
+
+func (c Cond) False(obj interface{}) {
+	c.SetStatus(obj, string(metav1.ConditionFalse))
+}
+
+func (c Cond) IsFalse(obj interface{}) bool {
+	return c.GetStatus(obj) == string(metav1.ConditionFalse)
+}
+
+func (c Cond) Unknown(obj interface{}) {
+	c.SetStatus(obj, string(metav1.ConditionUnknown))
+}
+
+func (c Cond) IsUnknown(obj interface{}) bool {
+	return c.GetStatus(obj) == string(metav1.ConditionUnknown)
+}
+
+func (c Cond) CreateUnknownIfNotExists(obj interface{}) {
+	if c.find(obj) == nil {
+		c.Unknown(obj)
+	}
+}
+
+// Reason sets the reason of the condition, an empty reason is replaced by the status as metav1.Condition requires
+// one and follows it from then on
+func (c Cond) Reason(obj interface{}, reason string) {
+	cond := c.findOrCreate(obj)
+	cond.Reason = reason
+	c.touch(obj, cond)
+}
+
+func (c Cond) GetReason(obj interface{}) string {
+	cond := c.find(obj)
+	if cond == nil {
+		return ""
+	}
+	return cond.Reason
+}
+
+func (c Cond) Message(obj interface{}, message string) {
+	cond := c.findOrCreate(obj)
+	cond.Message = message
+	c.touch(obj, cond)
+}
+
+func (c Cond) GetMessage(obj interface{}) string {
+	cond := c.find(obj)
+	if cond == nil {
+		return ""
+	}
+	return cond.Message
+}
+
+func (c Cond) GetLastTransitionTime(obj interface{}) time.Time {
+	cond := c.find(obj)
+	if cond == nil {
+		return time.Time{}
+	}
+	return cond.LastTransitionTime.Time
+}
+
+// touch records the generation the condition is set for and keeps a defaulted reason in sync with the status
+func (c Cond) touch(obj interface{}, cond *metav1.Condition) {
+	cond.ObservedGeneration = obj.(Object).GetObservedGeneration()
+	switch metav1.ConditionStatus(cond.Reason) {
+	case "", metav1.ConditionTrue, metav1.ConditionFalse, metav1.ConditionUnknown:
+		cond.Reason = string(cond.Status)
+	}
+}
+
+func (c Cond) find(obj interface{}) *metav1.Condition {
+	conds := obj.(Object).GetConditions()
+	for i := range *conds {
+		if (*conds)[i].Type == string(c) {
+			return &(*conds)[i]
+		}
+	}
+	return nil
+}
+
+func (c Cond) findOrCreate(obj interface{}) *metav1.Condition {
+	if cond := c.find(obj); cond != nil {
+		return cond
+	}
+	conds := obj.(Object).GetConditions()
+	*conds = append(*conds, metav1.Condition{
+		Type:               string(c),
+		Status:             metav1.ConditionUnknown,
+		Reason:             string(metav1.ConditionUnknown),
+		LastTransitionTime: now(),
+	})
+	return &(*conds)[len(*conds)-1]
+}
+
+// now is truncated to the precision of the serialized time, so an unchanged condition compares equal after a round
+// trip through the API server
+func now() metav1.Time {
+	return metav1.NewTime(time.Now().UTC().Truncate(time.Second))
+}
@@ -0,0 +1,62 @@
+package condition
+
+import (
+	"errors"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type testStatus struct {
+	generation int64
+	conditions []metav1.Condition
+}
+
+func (s *testStatus) GetConditions() *[]metav1.Condition {
+	return &s.conditions
+}
+
+func (s *testStatus) GetObservedGeneration() int64 {
+	return s.generation
+}
+
+const testCond = Cond("Ready")
+
+func TestSetStatus(t *testing.T) {
+	status := &testStatus{generation: 1}
+	testCond.True(status)
+	if len(status.conditions) != 1 {
+		t.Fatalf("got %d conditions, want 1", len(status.conditions))
+	}
+	cond := status.conditions[0]
+	if cond.Status != metav1.ConditionTrue || cond.ObservedGeneration != 1 || cond.Reason != "True" || cond.LastTransitionTime.IsZero() {
+		t.Errorf("got %+v, want a True condition observed at generation 1", cond)
+	}
+
+	// unchanged status keeps the transition time, the generation follows the object
+	transitioned := metav1.NewTime(cond.LastTransitionTime.Add(-1))
+	status.conditions[0].LastTransitionTime = transitioned
+	status.generation = 2
+	testCond.True(status)
+	if cond := status.conditions[0]; !cond.LastTransitionTime.Equal(&transitioned) || cond.ObservedGeneration != 2 {
+		t.Errorf("got %+v, want the transition time kept and generation 2", cond)
+	}
+
+	testCond.False(status)
+	if cond := status.conditions[0]; cond.LastTransitionTime.Equal(&transitioned) || cond.Reason != "False" {
+		t.Errorf("got %+v, want a new transition time and the reason following the status", cond)
+	}
+}
+
+func TestSetError(t *testing.T) {
+	status := &testStatus{}
+	testCond.SetError(status, "", errors.New("failed"))
+	if !testCond.IsFalse(status) || testCond.GetMessage(status) != "failed" || testCond.GetReason(status) != "Error" {
+		t.Errorf("got %+v, want False with reason Error", status.conditions)
+	}
+
+	testCond.SetError(status, "", nil)
+	if !testCond.IsTrue(status) || testCond.GetMessage(status) != "" || testCond.GetReason(status) != "True" {
+		t.Errorf("got %+v, want True without a message", status.conditions)
+	}
+}
@@ -0,0 +1,48 @@
+// Package kstatus is wrangler's kstatus package for metav1.Condition conditions
+package kstatus
+
+import (
+	"github.com/rancher/rancher-operator/pkg/condition"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Conditions read by the kstatus package
+
+const (
+	Reconciling = condition.Cond("Reconciling")
+	Stalled     = condition.Cond("Stalled")
+)
+
+func SetError(obj interface{}, message string) {
+	Reconciling.False(obj)
+	Reconciling.Message(obj, "")
+	Reconciling.Reason(obj, "")
+	Stalled.True(obj)
+	Stalled.Reason(obj, string(Stalled))
+	Stalled.Message(obj, message)
+}
+
+func SetTransitioning(obj interface{}, message string) {
+	Reconciling.True(obj)
+	Reconciling.Message(obj, message)
+	Reconciling.Reason(obj, string(Reconciling))
+	Stalled.False(obj)
+	Stalled.Reason(obj, "")
+	Stalled.Message(obj, "")
+}
+
+func SetActive(obj interface{}) {
+	Reconciling.False(obj)
+	Reconciling.Message(obj, "")
+	Reconciling.Reason(obj, "")
+	Stalled.False(obj)
+	Stalled.Reason(obj, "")
+	Stalled.Message(obj, "")
+}
+
+// SetObservedGeneration records the generation of obj in the observedGeneration of a status returned by a
+// generating handler. The status is reverted when the handler fails, so the generation is only recorded once it
+// is applied.
+func SetObservedGeneration(observedGeneration *int64, obj metav1.Object) {
+	*observedGeneration = obj.GetGeneration()
+}
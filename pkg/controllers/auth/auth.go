@@ -2,14 +2,19 @@ package auth
 
 import (
 	"context"
+	"strconv"
 
 	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
 	"github.com/rancher/rancher-operator/pkg/clients"
+	"github.com/rancher/rancher-operator/pkg/condition/kstatus"
 	projects2 "github.com/rancher/rancher-operator/pkg/controllers/projects"
+	mgmtcontrollers "github.com/rancher/rancher-operator/pkg/generated/controllers/management.cattle.io/v3"
 	rocontrollers "github.com/rancher/rancher-operator/pkg/generated/controllers/rancher.cattle.io/v1"
 	"github.com/rancher/rancher-operator/pkg/principals"
 	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
 	"github.com/rancher/wrangler/pkg/name"
+	"github.com/rancher/wrangler/pkg/relatedresource"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -19,13 +24,27 @@ type handler struct {
 	lookup   *principals.Lookup
 	clusters rocontrollers.ClusterCache
 	projects rocontrollers.ProjectCache
+	rtbs     rocontrollers.RoleTemplateBindingCache
+	grtbs    rocontrollers.GlobalRoleTemplateBindingCache
+	users    mgmtcontrollers.UserCache
+
+	rtbController  rocontrollers.RoleTemplateBindingController
+	grtbController rocontrollers.GlobalRoleTemplateBindingController
+	grbController  rocontrollers.GlobalRoleBindingController
 }
 
 func Register(ctx context.Context, clients *clients.Clients, lookup *principals.Lookup) {
 	h := handler{
 		clusters: clients.Cluster().Cache(),
 		projects: clients.Project().Cache(),
+		rtbs:     clients.RoleTemplateBinding().Cache(),
+		grtbs:    clients.GlobalRoleTemplateBinding().Cache(),
+		users:    clients.Management.User().Cache(),
 		lookup:   lookup,
+
+		rtbController:  clients.RoleTemplateBinding(),
+		grtbController: clients.GlobalRoleTemplateBinding(),
+		grbController:  clients.GlobalRoleBinding(),
 	}
 
 	rocontrollers.RegisterRoleTemplateBindingGeneratingHandler(ctx,
@@ -37,37 +56,103 @@ func Register(ctx context.Context, clients *clients.Clients, lookup *principals.
 		"role-template-binding",
 		h.onRoleTemplateBinding,
 		nil)
+
+	// bindings are generated once the management cluster of a selected cluster exists, and project bindings
+	// are pruned when the project is deleted
+	relatedresource.Watch(ctx, "role-template-binding-scope", h.scopeBindings,
+		clients.RoleTemplateBinding(), clients.Cluster(), clients.Project())
+
+	registerGlobal(ctx, clients, &h)
+	registerGlobalRoles(ctx, clients, &h)
+}
+
+// scopeBindings returns the bindings in namespace that can select obj. Project bindings depend on clusters too,
+// the clusters of a project decide which management projects exist.
+func (h *handler) scopeBindings(namespace, name string, obj runtime.Object) ([]relatedresource.Key, error) {
+	var kinds []string
+	switch obj.(type) {
+	case nil:
+		// a cluster or project was deleted
+		kinds = []string{"Cluster", "Project"}
+	case *v1.Cluster:
+		kinds = []string{"Cluster", "Project"}
+	case *v1.Project:
+		kinds = []string{"Project"}
+	default:
+		return nil, nil
+	}
+
+	rtbs, err := h.rtbs.List(namespace, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []relatedresource.Key
+	for _, rtb := range rtbs {
+		for _, kind := range kinds {
+			if rtb.BindingScope.Kind == kind {
+				keys = append(keys, relatedresource.Key{
+					Namespace: rtb.Namespace,
+					Name:      rtb.Name,
+				})
+			}
+		}
+	}
+	return keys, nil
 }
 
 func (h *handler) onRoleTemplateBinding(rtb *v1.RoleTemplateBinding, status v1.RoleTemplateBindingStatus) ([]runtime.Object, v1.RoleTemplateBindingStatus, error) {
+	kstatus.SetObservedGeneration(&status.ObservedGeneration, rtb)
+
 	if rtb.BindingScope.APIGroup != "rancher.cattle.io" ||
-		rtb.BindingScope.Selector == nil ||
+		(rtb.BindingScope.Selector == nil && rtb.BindingScope.Name == "") ||
 		rtb.RoleTemplateName == "" {
 		return nil, status, nil
 	}
 
-	sel, err := metav1.LabelSelectorAsSelector(rtb.BindingScope.Selector)
+	sel := labels.Nothing()
+	if rtb.BindingScope.Selector != nil {
+		var err error
+		sel, err = metav1.LabelSelectorAsSelector(rtb.BindingScope.Selector)
+		if err != nil {
+			return nil, status, err
+		}
+	}
+
+	subjects, missing, err := h.resolveSubjects(rtb.Subjects)
 	if err != nil {
 		return nil, status, err
 	}
+	setSubjectsResolved(&status, missing)
+	if len(missing) > 0 {
+		h.rtbController.EnqueueAfter(rtb.Namespace, rtb.Name, principalRetryInterval)
+	}
 
 	switch rtb.BindingScope.Kind {
 	case "Project":
-		obj, err := h.onProjectRTB(rtb, sel)
+		obj, err := h.onProjectRTB(rtb, sel, subjects)
 		return obj, status, err
 	case "Cluster":
-		obj, err := h.onClusterRTB(rtb, sel)
+		obj, err := h.onClusterRTB(rtb, sel, subjects)
 		return obj, status, err
 	}
 
 	return nil, status, nil
 }
 
-func (h *handler) onProjectRTB(rtb *v1.RoleTemplateBinding, sel labels.Selector) ([]runtime.Object, error) {
+func (h *handler) onProjectRTB(rtb *v1.RoleTemplateBinding, sel labels.Selector, subjects []principal) ([]runtime.Object, error) {
 	projects, err := h.projects.List(rtb.Namespace, sel)
 	if err != nil {
 		return nil, err
 	}
+	if rtb.BindingScope.Name != "" && !projectListed(projects, rtb.BindingScope.Name) {
+		project, err := h.projects.Get(rtb.Namespace, rtb.BindingScope.Name)
+		if err == nil {
+			projects = append(projects, project)
+		} else if !apierror.IsNotFound(err) {
+			return nil, err
+		}
+	}
 
 	var result []runtime.Object
 
@@ -77,30 +162,17 @@ func (h *handler) onProjectRTB(rtb *v1.RoleTemplateBinding, sel labels.Selector)
 			return nil, err
 		}
 		for _, project := range projects {
-			for _, subject := range rtb.Subjects {
-				var (
-					err  error
-					crtb = &v3.ProjectRoleTemplateBinding{
-						ObjectMeta: metav1.ObjectMeta{
-							Name:      name.SafeConcatName(rtb.Name, "binding"),
-							Namespace: project.Name,
-						},
-						ProjectName:      project.ClusterName + ":" + project.Name,
-						RoleTemplateName: rtb.RoleTemplateName,
-					}
-				)
-
-				if subject.Kind == "User" {
-					crtb.UserPrincipalName, err = h.lookup.LookupUser(subject.Name)
-				} else if subject.Kind == "Group" {
-					crtb.GroupPrincipalName, err = h.lookup.LookupGroup(subject.Name)
-				}
-
-				if err != nil {
-					return nil, err
-				}
-
-				result = append(result, crtb)
+			for _, subject := range subjects {
+				result = append(result, &v3.ProjectRoleTemplateBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      bindingName(rtb.Name, subject.index),
+						Namespace: project.Name,
+					},
+					ProjectName:        project.Spec.ClusterName + ":" + project.Name,
+					RoleTemplateName:   rtb.RoleTemplateName,
+					UserPrincipalName:  subject.user,
+					GroupPrincipalName: subject.group,
+				})
 			}
 		}
 	}
@@ -108,41 +180,72 @@ func (h *handler) onProjectRTB(rtb *v1.RoleTemplateBinding, sel labels.Selector)
 	return result, nil
 }
 
-func (h *handler) onClusterRTB(rtb *v1.RoleTemplateBinding, sel labels.Selector) ([]runtime.Object, error) {
+func (h *handler) onClusterRTB(rtb *v1.RoleTemplateBinding, sel labels.Selector, subjects []principal) ([]runtime.Object, error) {
 	clusters, err := h.clusters.List(rtb.Namespace, sel)
 	if err != nil {
 		return nil, err
 	}
+	if rtb.BindingScope.Name != "" && !clusterListed(clusters, rtb.BindingScope.Name) {
+		cluster, err := h.clusters.Get(rtb.Namespace, rtb.BindingScope.Name)
+		if err == nil {
+			clusters = append(clusters, cluster)
+		} else if !apierror.IsNotFound(err) {
+			return nil, err
+		}
+	}
+
+	return clusterRoleTemplateBindings(rtb.Name, rtb.RoleTemplateName, subjects, clusters), nil
+}
 
+// clusterRoleTemplateBindings binds roleTemplateName to subjects in clusters, owner is the name of the binding
+// they are generated for
+func clusterRoleTemplateBindings(owner, roleTemplateName string, subjects []principal, clusters []*v1.Cluster) []runtime.Object {
 	var result []runtime.Object
 
 	for _, cluster := range clusters {
-		for _, subject := range rtb.Subjects {
-			var (
-				err  error
-				crtb = &v3.ClusterRoleTemplateBinding{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      name.SafeConcatName(rtb.Name, "binding"),
-						Namespace: cluster.Status.ClusterName,
-					},
-					ClusterName:      cluster.Status.ClusterName,
-					RoleTemplateName: rtb.RoleTemplateName,
-				}
-			)
-
-			if subject.Kind == "User" {
-				crtb.UserPrincipalName, err = h.lookup.LookupUser(subject.Name)
-			} else if subject.Kind == "Group" {
-				crtb.GroupPrincipalName, err = h.lookup.LookupGroup(subject.Name)
-			}
+		// the namespace of the binding is created with the management cluster
+		if cluster.Status.ClusterName == "" {
+			continue
+		}
+		for _, subject := range subjects {
+			result = append(result, &v3.ClusterRoleTemplateBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      bindingName(owner, subject.index),
+					Namespace: cluster.Status.ClusterName,
+				},
+				ClusterName:        cluster.Status.ClusterName,
+				RoleTemplateName:   roleTemplateName,
+				UserPrincipalName:  subject.user,
+				GroupPrincipalName: subject.group,
+			})
+		}
+	}
 
-			if err != nil {
-				return nil, err
-			}
+	return result
+}
+
+// bindingName keeps the name of the binding of the first subject so existing bindings are not recreated
+func bindingName(owner string, subject int) string {
+	if subject == 0 {
+		return name.SafeConcatName(owner, "binding")
+	}
+	return name.SafeConcatName(owner, "binding", strconv.Itoa(subject))
+}
 
-			result = append(result, crtb)
+func clusterListed(clusters []*v1.Cluster, name string) bool {
+	for _, cluster := range clusters {
+		if cluster.Name == name {
+			return true
 		}
 	}
+	return false
+}
 
-	return result, nil
+func projectListed(projects []*v1.Project, name string) bool {
+	for _, project := range projects {
+		if project.Name == name {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"context"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/clients"
+	"github.com/rancher/rancher-operator/pkg/condition/kstatus"
+	rocontrollers "github.com/rancher/rancher-operator/pkg/generated/controllers/rancher.cattle.io/v1"
+	"github.com/rancher/wrangler/pkg/generic"
+	"github.com/rancher/wrangler/pkg/name"
+	"github.com/rancher/wrangler/pkg/relatedresource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func registerGlobal(ctx context.Context, clients *clients.Clients, h *handler) {
+	rocontrollers.RegisterGlobalRoleTemplateBindingGeneratingHandler(ctx,
+		clients.GlobalRoleTemplateBinding(),
+		clients.Apply.
+			WithCacheTypes(clients.Management.ClusterRoleTemplateBinding()),
+		"",
+		"global-role-template-binding",
+		h.onGlobalRoleTemplateBinding,
+		&generic.GeneratingHandlerOptions{
+			AllowClusterScoped: true,
+		})
+
+	relatedresource.Watch(ctx, "global-role-template-binding-cluster", h.globalBindings,
+		clusterScoped(clients.GlobalRoleTemplateBinding().Enqueue), clients.Cluster())
+}
+
+// clusterScoped enqueues the cluster scoped objects of a controller for relatedresource, which passes a namespace
+type clusterScoped func(name string)
+
+func (c clusterScoped) Enqueue(_, name string) {
+	c(name)
+}
+
+func (h *handler) onGlobalRoleTemplateBinding(grtb *v1.GlobalRoleTemplateBinding, status v1.GlobalRoleTemplateBindingStatus) ([]runtime.Object, v1.GlobalRoleTemplateBindingStatus, error) {
+	kstatus.SetObservedGeneration(&status.ObservedGeneration, grtb)
+
+	if grtb.ClusterSelector == nil || grtb.RoleTemplateName == "" {
+		return nil, status, nil
+	}
+
+	sel, err := metav1.LabelSelectorAsSelector(grtb.ClusterSelector)
+	if err != nil {
+		return nil, status, err
+	}
+
+	clusters, err := h.clusters.List("", sel)
+	if err != nil {
+		return nil, status, err
+	}
+
+	subjects, missing, err := h.resolveSubjects(grtb.Subjects)
+	if err != nil {
+		return nil, status, err
+	}
+	setSubjectsResolved(&status, missing)
+	if len(missing) > 0 {
+		h.grtbController.EnqueueAfter(grtb.Name, principalRetryInterval)
+	}
+
+	// prefixed so the bindings do not collide with those of a RoleTemplateBinding of the same name
+	return clusterRoleTemplateBindings(name.SafeConcatName("global", grtb.Name), grtb.RoleTemplateName, subjects, clusters), status, nil
+}
+
+// globalBindings returns every global binding when a cluster changes, any of them can select it
+func (h *handler) globalBindings(namespace, name string, obj runtime.Object) ([]relatedresource.Key, error) {
+	if _, ok := obj.(*v1.Cluster); !ok && obj != nil {
+		return nil, nil
+	}
+
+	grtbs, err := h.grtbs.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []relatedresource.Key
+	for _, grtb := range grtbs {
+		keys = append(keys, relatedresource.Key{
+			Name: grtb.Name,
+		})
+	}
+	return keys, nil
+}
@@ -0,0 +1,156 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/clients"
+	"github.com/rancher/rancher-operator/pkg/condition/kstatus"
+	rocontrollers "github.com/rancher/rancher-operator/pkg/generated/controllers/rancher.cattle.io/v1"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/wrangler/pkg/generic"
+	"github.com/rancher/wrangler/pkg/relatedresource"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	byPrincipal = "by-principal"
+
+	ownerGVKAnnotation  = "objectset.rio.cattle.io/owner-gvk"
+	ownerNameAnnotation = "objectset.rio.cattle.io/owner-name"
+)
+
+func registerGlobalRoles(ctx context.Context, clients *clients.Clients, h *handler) {
+	clients.Management.User().Cache().AddIndexer(byPrincipal, func(obj *v3.User) ([]string, error) {
+		return obj.PrincipalIDs, nil
+	})
+
+	rocontrollers.RegisterGlobalRoleGeneratingHandler(ctx,
+		clients.GlobalRole(),
+		clients.Apply.
+			WithCacheTypes(clients.Management.GlobalRole()),
+		"",
+		"global-role",
+		onGlobalRole,
+		&generic.GeneratingHandlerOptions{
+			AllowClusterScoped: true,
+		})
+
+	rocontrollers.RegisterGlobalRoleBindingGeneratingHandler(ctx,
+		clients.GlobalRoleBinding(),
+		clients.Apply.
+			WithCacheTypes(clients.Management.GlobalRoleBinding()),
+		"",
+		"global-role-binding",
+		h.onGlobalRoleBinding,
+		&generic.GeneratingHandlerOptions{
+			AllowClusterScoped: true,
+		})
+
+	// changes made in the UI are reverted by applying the role or binding again
+	relatedresource.Watch(ctx, "global-role-drift", ownedBy("GlobalRole"),
+		clusterScoped(clients.GlobalRole().Enqueue), clients.Management.GlobalRole())
+	relatedresource.Watch(ctx, "global-role-binding-drift", ownedBy("GlobalRoleBinding"),
+		clusterScoped(clients.GlobalRoleBinding().Enqueue), clients.Management.GlobalRoleBinding())
+}
+
+// ownedBy resolves a generated object to the cluster scoped object of kind it was applied for
+func ownedBy(kind string) relatedresource.Resolver {
+	gvk := v1.SchemeGroupVersion.WithKind(kind).String()
+	return func(namespace, name string, obj runtime.Object) ([]relatedresource.Key, error) {
+		if obj == nil {
+			return nil, nil
+		}
+		m, err := meta.Accessor(obj)
+		if err != nil {
+			return nil, err
+		}
+		annotations := m.GetAnnotations()
+		if annotations[ownerGVKAnnotation] != gvk || annotations[ownerNameAnnotation] == "" {
+			return nil, nil
+		}
+		return []relatedresource.Key{{Name: annotations[ownerNameAnnotation]}}, nil
+	}
+}
+
+func onGlobalRole(gr *v1.GlobalRole, status v1.GlobalRoleStatus) ([]runtime.Object, v1.GlobalRoleStatus, error) {
+	status.ObservedGeneration = gr.Generation
+
+	displayName := gr.DisplayName
+	if displayName == "" {
+		displayName = gr.Name
+	}
+
+	return []runtime.Object{
+		&v3.GlobalRole{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: gr.Name,
+			},
+			DisplayName:    displayName,
+			Description:    gr.Annotations["field.cattle.io/description"],
+			Rules:          gr.Rules,
+			NewUserDefault: gr.NewUserDefault,
+		},
+	}, status, nil
+}
+
+func (h *handler) onGlobalRoleBinding(grb *v1.GlobalRoleBinding, status v1.GlobalRoleBindingStatus) ([]runtime.Object, v1.GlobalRoleBindingStatus, error) {
+	kstatus.SetObservedGeneration(&status.ObservedGeneration, grb)
+
+	if grb.GlobalRoleName == "" {
+		return nil, status, nil
+	}
+
+	subjects, missing, err := h.resolveSubjects(grb.Subjects)
+	if err != nil {
+		return nil, status, err
+	}
+
+	var result []runtime.Object
+	for _, subject := range subjects {
+		binding := &v3.GlobalRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: bindingName(grb.Name, subject.index),
+			},
+			GlobalRoleName:     grb.GlobalRoleName,
+			GroupPrincipalName: subject.group,
+		}
+		if subject.user != "" {
+			// global role bindings reference the management user, which exists once the principal logged in
+			userName, err := h.userForPrincipal(subject.user)
+			if err != nil {
+				return nil, status, err
+			} else if userName == "" {
+				missing = append(missing, fmt.Sprintf("user %s", grb.Subjects[subject.index].Name))
+				continue
+			}
+			binding.UserName = userName
+		}
+		result = append(result, binding)
+	}
+
+	setSubjectsResolved(&status, missing)
+	if len(missing) > 0 {
+		h.grbController.EnqueueAfter(grb.Name, principalRetryInterval)
+	}
+
+	return result, status, nil
+}
+
+func (h *handler) userForPrincipal(principalID string) (string, error) {
+	if strings.HasPrefix(principalID, "local://") {
+		return strings.TrimPrefix(principalID, "local://"), nil
+	}
+	users, err := h.users.GetByIndex(byPrincipal, principalID)
+	if err != nil {
+		return "", err
+	}
+	if len(users) == 0 {
+		return "", nil
+	}
+	return users[0].Name, nil
+}
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"fmt"
 
 	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
 	"github.com/rancher/rancher-operator/pkg/clients"
@@ -26,15 +27,29 @@ func RegisterRoleTemplate(ctx context.Context, clients *clients.Clients) {
 }
 
 func onRoleTemplateChange(rt *v1.RoleTemplate, status v1.RoleTemplateStatus) ([]runtime.Object, v1.RoleTemplateStatus, error) {
+	if rt.Context != "" && rt.Context != "cluster" && rt.Context != "project" {
+		return nil, status, fmt.Errorf("context %q of role template %s must be cluster or project", rt.Context, rt.Name)
+	}
+
+	status.ObservedGeneration = rt.Generation
+
+	displayName := rt.DisplayName
+	if displayName == "" {
+		displayName = rt.Name
+	}
+
 	return []runtime.Object{
 		&v3.RoleTemplate{
 			TypeMeta: metav1.TypeMeta{},
 			ObjectMeta: metav1.ObjectMeta{
 				Name: rt.Name,
 			},
-			DisplayName: rt.Name,
-			Description: rt.Annotations["field.cattle.io/description"],
-			Rules:       rt.Rules,
+			DisplayName:       displayName,
+			Description:       rt.Annotations["field.cattle.io/description"],
+			Rules:             rt.Rules,
+			Context:           rt.Context,
+			RoleTemplateNames: rt.RoleTemplateNames,
+			Locked:            rt.Locked,
 		},
 	}, status, nil
 }
@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/principals"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// principalRetryInterval is how often subjects that were not found are looked up again, they can be created in
+// the auth provider at any time
+const principalRetryInterval = time.Minute
+
+// principal is a subject resolved to a Rancher principal ID, index is its position in the subjects of the binding
+type principal struct {
+	index int
+	user  string
+	group string
+}
+
+// resolveSubjects looks up the principal ID of each subject, subjects that are not found are returned as missing
+// so the bindings of the others are still generated
+func (h *handler) resolveSubjects(subjects []rbacv1.Subject) ([]principal, []string, error) {
+	var (
+		resolved []principal
+		missing  []string
+	)
+	for i, subject := range subjects {
+		var (
+			p   = principal{index: i}
+			err error
+		)
+		switch subject.Kind {
+		case "User":
+			p.user, err = h.lookup.LookupUser(subject.Name)
+		case "Group":
+			p.group, err = h.lookup.LookupGroup(subject.Name)
+		default:
+			continue
+		}
+		if principals.IsNotFound(err) {
+			missing = append(missing, fmt.Sprintf("%s %s", strings.ToLower(subject.Kind), subject.Name))
+			continue
+		} else if err != nil {
+			return nil, nil, err
+		}
+		resolved = append(resolved, p)
+	}
+	return resolved, missing, nil
+}
+
+// setSubjectsResolved records the subjects that were not found in status, the status of any binding type
+func setSubjectsResolved(status interface{}, missing []string) {
+	if len(missing) == 0 {
+		v1.RoleTemplateBindingConditionSubjectsResolved.True(status)
+		v1.RoleTemplateBindingConditionSubjectsResolved.Message(status, "")
+		return
+	}
+	v1.RoleTemplateBindingConditionSubjectsResolved.False(status)
+	v1.RoleTemplateBindingConditionSubjectsResolved.Message(status, "principals not found for "+strings.Join(missing, ", "))
+}
@@ -0,0 +1,49 @@
+package cluster
+
+import (
+	"time"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/wrangler/pkg/condition"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// agentCheckInterval is how often the agent connection of provisioned clusters is checked, the v3 cluster is not
+// always updated when the tunnel drops
+const agentCheckInterval = 5 * time.Minute
+
+// agentStatus mirrors the Connected condition of the v3 cluster, Rancher versions without it fall back to Ready
+func (h *handler) agentStatus(cluster *v1.Cluster, status v1.ClusterStatus, existing *v3.Cluster) v1.ClusterStatus {
+	source := condition.Cond("Connected")
+	if source.GetStatus(existing) == "" {
+		source = condition.Cond("Ready")
+	}
+	if source.GetStatus(existing) == "" {
+		return status
+	}
+
+	connected := source.IsTrue(existing)
+	if connected && !status.AgentConnected && status.AgentLastHeartbeat != "" {
+		h.recorder.Event(cluster, corev1.EventTypeNormal, "AgentConnected", "Cluster agent reconnected")
+	} else if !connected && status.AgentConnected {
+		h.recorder.Eventf(cluster, corev1.EventTypeWarning, "AgentDisconnected", "Cluster agent disconnected: %s",
+			source.GetMessage(existing))
+	}
+
+	status.AgentConnected = connected
+	if connected {
+		// only refreshed by the periodic check, updating it on every reconcile would trigger another one
+		if last, err := time.Parse(time.RFC3339, status.AgentLastHeartbeat); err != nil || time.Since(last) >= agentCheckInterval/2 {
+			status.AgentLastHeartbeat = time.Now().UTC().Format(time.RFC3339)
+		}
+		v1.ClusterConditionAgentConnected.True(&status)
+		v1.ClusterConditionAgentConnected.Message(&status, "")
+	} else {
+		v1.ClusterConditionAgentConnected.False(&status)
+		v1.ClusterConditionAgentConnected.Message(&status, source.GetMessage(existing))
+	}
+
+	h.clusters.EnqueueAfter(cluster.Namespace, cluster.Name, agentCheckInterval)
+	return status
+}
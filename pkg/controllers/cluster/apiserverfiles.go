@@ -0,0 +1,98 @@
+package cluster
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/wrangler/pkg/yaml"
+	corev1 "k8s.io/api/core/v1"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// withAPIServerFiles returns a copy of cluster with the files of spec.apiServerFiles set in the kube-apiserver
+// config of RKE, which writes them to the control plane nodes. They replace the policy and admission configuration
+// set inline in the RKE config.
+func (h *handler) withAPIServerFiles(cluster *v1.Cluster) (*v1.Cluster, error) {
+	files := cluster.Spec.APIServerFiles
+	if files == nil || cluster.Spec.RancherKubernetesEngineConfig == nil {
+		return cluster, nil
+	}
+
+	policy, err := h.apiServerFile(cluster.Namespace, files.AuditPolicy, "Policy")
+	if err != nil {
+		return cluster, err
+	}
+	admission, err := h.apiServerFile(cluster.Namespace, files.AdmissionConfiguration, "AdmissionConfiguration")
+	if err != nil {
+		return cluster, err
+	}
+	if policy == nil && admission == nil {
+		return cluster, nil
+	}
+
+	cluster = cluster.DeepCopy()
+	kubeAPI := &cluster.Spec.RancherKubernetesEngineConfig.Services.KubeAPI
+
+	// decoded into the RKE types with their JSON fields, the audit log settings other than the policy are kept
+	patch := map[string]interface{}{}
+	if policy != nil {
+		if kubeAPI.AuditLog != nil && kubeAPI.AuditLog.Configuration != nil {
+			kubeAPI.AuditLog.Configuration.Policy = nil
+		}
+		patch["auditLog"] = map[string]interface{}{
+			"enabled": true,
+			"configuration": map[string]interface{}{
+				"policy": policy.Object,
+			},
+		}
+	}
+	if admission != nil {
+		kubeAPI.AdmissionConfiguration = nil
+		patch["admissionConfiguration"] = admission.Object
+	}
+
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return cluster, err
+	}
+	if err := json.Unmarshal(data, kubeAPI); err != nil {
+		return cluster, newFailure(failureReasonInvalidConfiguration, fmt.Errorf("spec.apiServerFiles: %w", err))
+	}
+	return cluster, nil
+}
+
+// apiServerFile reads the object of kind from the referenced key, nil without a reference or if an optional secret
+// doesn't exist
+func (h *handler) apiServerFile(namespace string, ref *corev1.SecretKeySelector, kind string) (*unstructured.Unstructured, error) {
+	if ref == nil {
+		return nil, nil
+	}
+	// the referenced secret is created by the user, it is not in the cache
+	secret, err := h.secrets.Get(namespace, ref.Name, metav1.GetOptions{})
+	if apierror.IsNotFound(err) && ref.Optional != nil && *ref.Optional {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	data, ok := secret.Data[ref.Key]
+	if !ok {
+		return nil, fmt.Errorf("key %s not found in secret %s/%s", ref.Key, namespace, ref.Name)
+	}
+
+	objs, err := yaml.ToObjects(bytes.NewReader(data))
+	if err != nil {
+		return nil, newFailure(failureReasonInvalidConfiguration, fmt.Errorf("secret %s/%s key %s: %w", namespace, ref.Name, ref.Key, err))
+	}
+	if len(objs) != 1 || objs[0].GetObjectKind().GroupVersionKind().Kind != kind {
+		return nil, newFailure(failureReasonInvalidConfiguration, fmt.Errorf("secret %s/%s key %s must hold a single %s", namespace, ref.Name, ref.Key, kind))
+	}
+	obj, ok := objs[0].(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("secret %s/%s key %s: unexpected object %T", namespace, ref.Name, ref.Key, objs[0])
+	}
+	return obj, nil
+}
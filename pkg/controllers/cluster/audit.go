@@ -0,0 +1,83 @@
+package cluster
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	corev1 "k8s.io/api/core/v1"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// The annotations below are set on generated v3 clusters to reconstruct which v1 change produced their spec
+const (
+	sourceGenerationAnnotation = "rancher.cattle.io/source-generation"
+	sourceManagerAnnotation    = "rancher.cattle.io/source-manager"
+	specHashAnnotation         = "rancher.cattle.io/spec-hash"
+)
+
+// auditAnnotations returns the annotations of cluster plus the audit annotations for spec. The hash is taken
+// before encrypted values are decrypted so it never covers plaintext secrets.
+func auditAnnotations(cluster *v1.Cluster, spec v3.ClusterSpec) (map[string]string, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(data)
+
+	annotations := map[string]string{}
+	for k, v := range cluster.Annotations {
+		annotations[k] = v
+	}
+	annotations[specHashAnnotation] = hex.EncodeToString(hash[:])
+	annotations[sourceGenerationAnnotation] = strconv.FormatInt(cluster.Generation, 10)
+	if manager := specManager(cluster); manager != "" {
+		annotations[sourceManagerAnnotation] = manager
+	}
+	return annotations, nil
+}
+
+// specManager returns the field manager that most recently changed the spec of cluster
+func specManager(cluster *v1.Cluster) string {
+	var (
+		manager string
+		latest  int64
+	)
+	for _, entry := range cluster.ManagedFields {
+		if entry.FieldsV1 == nil || entry.Time == nil || !bytes.Contains(entry.FieldsV1.Raw, []byte(`"f:spec"`)) {
+			continue
+		}
+		if t := entry.Time.Unix(); manager == "" || t >= latest {
+			manager, latest = entry.Manager, t
+		}
+	}
+	return manager
+}
+
+// auditSpecChange records an event when the spec pushed to an existing v3 cluster changes
+func (h *handler) auditSpecChange(cluster *v1.Cluster, newCluster *v3.Cluster) error {
+	existing, err := h.rclusterCache.Get(newCluster.Name)
+	if apierror.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	hash := newCluster.Annotations[specHashAnnotation]
+	if existing.Annotations[specHashAnnotation] == hash {
+		return nil
+	}
+
+	manager := newCluster.Annotations[sourceManagerAnnotation]
+	if manager == "" {
+		manager = "unknown"
+	}
+	h.recorder.Eventf(cluster, corev1.EventTypeNormal, "SpecChanged",
+		"Updating management cluster %s to spec %s from generation %d changed by %s",
+		newCluster.Name, hash, cluster.Generation, manager)
+	return nil
+}
@@ -0,0 +1,96 @@
+package cluster
+
+import (
+	"encoding/json"
+	"fmt"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// autoscalingOptionsTag prefixes the node group tags the cluster autoscaler reads its per node group options from
+const autoscalingOptionsTag = "k8s.io/cluster-autoscaler/node-template/autoscaling-options/"
+
+// withAutoscaling sets the bounds of spec.autoscaling on the EKS node groups. The desired size of the node groups EKS
+// already runs is taken from the management cluster, so the sizes the autoscaler chose are not reverted. A hibernated
+// cluster keeps its node groups at zero.
+func (h *handler) withAutoscaling(cluster *v1.Cluster, rClusterName string, spec v3.ClusterSpec) (v3.ClusterSpec, error) {
+	if len(cluster.Spec.Autoscaling) == 0 || spec.EKSConfig == nil || cluster.Spec.Hibernate {
+		return spec, nil
+	}
+
+	running := map[string]int64{}
+	existing, err := h.rclusterCache.Get(rClusterName)
+	if err != nil && !apierror.IsNotFound(err) {
+		return spec, err
+	}
+	if err == nil && existing.Status.EKSStatus.UpstreamSpec != nil {
+		for _, group := range existing.Status.EKSStatus.UpstreamSpec.NodeGroups {
+			if group.NodegroupName != nil && group.DesiredSize != nil {
+				running[*group.NodegroupName] = *group.DesiredSize
+			}
+		}
+	}
+
+	autoscaled := map[string]v1.NodeGroupAutoscaling{}
+	for _, autoscaling := range cluster.Spec.Autoscaling {
+		if autoscaling.Enabled {
+			autoscaled[autoscaling.NodeGroupName] = autoscaling
+		}
+	}
+
+	spec.EKSConfig = spec.EKSConfig.DeepCopy()
+	for i := range spec.EKSConfig.NodeGroups {
+		group := &spec.EKSConfig.NodeGroups[i]
+		if group.NodegroupName == nil {
+			continue
+		}
+		autoscaling, ok := autoscaled[*group.NodegroupName]
+		if !ok {
+			continue
+		}
+
+		desired := autoscaling.MinSize
+		if size, ok := running[*group.NodegroupName]; ok {
+			desired = size
+		} else if group.DesiredSize != nil {
+			desired = *group.DesiredSize
+		}
+		if desired < autoscaling.MinSize {
+			desired = autoscaling.MinSize
+		}
+		if desired > autoscaling.MaxSize {
+			desired = autoscaling.MaxSize
+		}
+		min, max := autoscaling.MinSize, autoscaling.MaxSize
+		group.MinSize = &min
+		group.MaxSize = &max
+		group.DesiredSize = &desired
+
+		// merged into the tags of the node group, the other tags are kept
+		tags := map[string]string{}
+		for option, value := range map[string]string{
+			"scaledownunneededtime":         autoscaling.ScaleDownUnneededTime,
+			"scaledownunreadytime":          autoscaling.ScaleDownUnreadyTime,
+			"scaledownutilizationthreshold": autoscaling.ScaleDownUtilizationThreshold,
+		} {
+			if value != "" {
+				tags[autoscalingOptionsTag+option] = value
+			}
+		}
+		if len(tags) == 0 {
+			continue
+		}
+		data, err := json.Marshal(map[string]interface{}{
+			"tags": tags,
+		})
+		if err != nil {
+			return spec, err
+		}
+		if err := json.Unmarshal(data, group); err != nil {
+			return spec, newFailure(failureReasonInvalidConfiguration, fmt.Errorf("spec.autoscaling: %w", err))
+		}
+	}
+	return spec, nil
+}
@@ -0,0 +1,61 @@
+package cluster
+
+import (
+	"sync"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/wrangler/pkg/relatedresource"
+	"github.com/sirupsen/logrus"
+)
+
+// relatedBatch collects the v3 clusters changed within delay and resolves the clusters to requeue for all of them
+// at once. A watch storm, like every v3 cluster being updated during a Rancher upgrade, requeues each cluster once
+// instead of once per event.
+type relatedBatch struct {
+	delay   time.Duration
+	resolve func(rCluster *v3.Cluster) ([]relatedresource.Key, error)
+	enqueue func(namespace, name string)
+
+	lock sync.Mutex
+	// pending holds the last seen object of each v3 cluster changed since the last flush
+	pending map[string]*v3.Cluster
+}
+
+// add records a changed v3 cluster, the first one after a flush schedules the next
+func (b *relatedBatch) add(rCluster *v3.Cluster) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if len(b.pending) == 0 {
+		b.pending = map[string]*v3.Cluster{}
+		time.AfterFunc(b.delay, b.flush)
+	}
+	b.pending[rCluster.Name] = rCluster
+}
+
+func (b *relatedBatch) flush() {
+	b.lock.Lock()
+	pending := b.pending
+	b.pending = nil
+	b.lock.Unlock()
+
+	keys := map[relatedresource.Key]bool{}
+	for _, rCluster := range pending {
+		related, err := b.resolve(rCluster)
+		if err != nil {
+			// retried with the next batch
+			logrus.Errorf("failed to resolve the clusters of management cluster %s: %v", rCluster.Name, err)
+			b.add(rCluster)
+			continue
+		}
+		for _, key := range related {
+			keys[key] = true
+		}
+	}
+
+	for key := range keys {
+		if key.Name != "" {
+			b.enqueue(key.Namespace, key.Name)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package cluster
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/wrangler/pkg/relatedresource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRelatedBatch(t *testing.T) {
+	var (
+		lock     sync.Mutex
+		resolved []string
+		enqueued []string
+		done     = make(chan struct{}, 1)
+	)
+	b := &relatedBatch{
+		delay: 10 * time.Millisecond,
+		resolve: func(rCluster *v3.Cluster) ([]relatedresource.Key, error) {
+			lock.Lock()
+			defer lock.Unlock()
+			resolved = append(resolved, rCluster.Name)
+			return []relatedresource.Key{{Namespace: "fleet-default", Name: "test"}}, nil
+		},
+		enqueue: func(namespace, name string) {
+			lock.Lock()
+			defer lock.Unlock()
+			enqueued = append(enqueued, namespace+"/"+name)
+			done <- struct{}{}
+		},
+	}
+
+	for _, name := range []string{"c-one", "c-two", "c-one"} {
+		b.add(&v3.Cluster{ObjectMeta: metav1.ObjectMeta{Name: name}})
+	}
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("batch was not flushed")
+	}
+	// give a second flush the chance to show up
+	time.Sleep(50 * time.Millisecond)
+
+	lock.Lock()
+	defer lock.Unlock()
+	sort.Strings(resolved)
+	if want := []string{"c-one", "c-two"}; !reflect.DeepEqual(resolved, want) {
+		t.Errorf("resolved %v, want every changed management cluster once %v", resolved, want)
+	}
+	if want := []string{"fleet-default/test"}; !reflect.DeepEqual(enqueued, want) {
+		t.Errorf("enqueued %v, want %v", enqueued, want)
+	}
+}
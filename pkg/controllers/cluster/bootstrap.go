@@ -0,0 +1,228 @@
+package cluster
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/tracing"
+	"github.com/rancher/wrangler/pkg/apply"
+	"github.com/rancher/wrangler/pkg/name"
+	"github.com/rancher/wrangler/pkg/yaml"
+	corev1 "k8s.io/api/core/v1"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	bootstrapRetryInterval       = time.Minute
+	bootstrapManifestSetIDPrefix = "bootstrap"
+)
+
+// onBootstrap applies spec.bootstrapManifests and spec.bootstrapCharts to the downstream cluster with the
+// generated kubeconfig once the cluster is ready. A manifest is applied again when its content changes, each has
+// its own apply set so objects removed from it are deleted downstream.
+func (h *handler) onBootstrap(key string, cluster *v1.Cluster) (*v1.Cluster, error) {
+	if cluster == nil || cluster.DeletionTimestamp != nil || !cluster.Status.Ready || cluster.Status.ClientSecretName == "" ||
+		(len(cluster.Spec.BootstrapManifests) == 0 && len(cluster.Status.BootstrapManifests) == 0 &&
+			len(cluster.Spec.BootstrapCharts) == 0 && len(cluster.Status.BootstrapCharts) == 0) {
+		return cluster, nil
+	}
+
+	manifests, failed, err := h.bootstrapManifests(cluster)
+	if err != nil {
+		return cluster, err
+	}
+	charts, failedCharts, err := h.bootstrapCharts(cluster)
+	if err != nil {
+		return cluster, err
+	}
+	failed = append(failed, failedCharts...)
+
+	message := ""
+	if len(failed) > 0 {
+		message = strings.Join(failed, ", ")
+		h.clusters.EnqueueAfter(cluster.Namespace, cluster.Name, bootstrapRetryInterval)
+	}
+	if bootstrapStatusEqual(cluster.Status.BootstrapManifests, manifests) && bootstrapChartStatusEqual(cluster.Status.BootstrapCharts, charts) &&
+		v1.ClusterConditionBootstrapped.GetMessage(cluster) == message && v1.ClusterConditionBootstrapped.GetStatus(cluster) != "" {
+		return cluster, nil
+	}
+
+	cluster = cluster.DeepCopy()
+	cluster.Status.BootstrapManifests = manifests
+	cluster.Status.BootstrapCharts = charts
+	if len(failed) > 0 {
+		h.recorder.Eventf(cluster, corev1.EventTypeWarning, "BootstrapFailed", "Failed to bootstrap the cluster: %s", message)
+		v1.ClusterConditionBootstrapped.False(cluster)
+	} else {
+		v1.ClusterConditionBootstrapped.True(cluster)
+	}
+	v1.ClusterConditionBootstrapped.Message(cluster, message)
+	return h.clusters.UpdateStatus(cluster)
+}
+
+// bootstrapManifests applies the changed manifests and returns their status and the failed ones
+func (h *handler) bootstrapManifests(cluster *v1.Cluster) ([]v1.BootstrapManifestStatus, []string, error) {
+	previous := map[string]v1.BootstrapManifestStatus{}
+	for _, status := range cluster.Status.BootstrapManifests {
+		previous[status.Kind+"/"+status.Name] = status
+	}
+
+	var (
+		statuses []v1.BootstrapManifestStatus
+		failed   []string
+	)
+	for _, manifest := range cluster.Spec.BootstrapManifests {
+		kind := bootstrapManifestKind(manifest)
+		status := previous[kind+"/"+manifest.Name]
+		status.Kind, status.Name = kind, manifest.Name
+
+		if kind != "ConfigMap" && kind != "Secret" {
+			status.Error = fmt.Sprintf("kind %q must be ConfigMap or Secret", kind)
+			failed = append(failed, fmt.Sprintf("%s %s: %s", kind, manifest.Name, status.Error))
+			statuses = append(statuses, status)
+			continue
+		}
+
+		data, err := h.bootstrapManifestData(cluster.Namespace, kind, manifest.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+		if data == nil {
+			status.Error = fmt.Sprintf("%s %s not found", kind, manifest.Name)
+		} else if hash := bootstrapManifestHash(data); hash != status.Hash || status.Error != "" {
+			if err := h.applyBootstrapManifest(cluster, kind, manifest.Name, data); err != nil {
+				status.Error = err.Error()
+			} else {
+				status.Hash = hash
+				status.AppliedAt = time.Now().UTC().Format(time.RFC3339)
+				status.Error = ""
+			}
+		}
+		if status.Error != "" {
+			failed = append(failed, fmt.Sprintf("%s %s: %s", kind, manifest.Name, status.Error))
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, failed, nil
+}
+
+func bootstrapManifestKind(manifest v1.BootstrapManifest) string {
+	if manifest.Kind == "" {
+		return "ConfigMap"
+	}
+	return manifest.Kind
+}
+
+// bootstrapManifestData returns the keys of the config map or secret, nil if it doesn't exist
+func (h *handler) bootstrapManifestData(namespace, kind, name string) (map[string][]byte, error) {
+	if kind == "ConfigMap" {
+		configMap, err := h.configMapCache.Get(namespace, name)
+		if apierror.IsNotFound(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		data := map[string][]byte{}
+		for k, v := range configMap.Data {
+			data[k] = []byte(v)
+		}
+		return data, nil
+	}
+
+	// the manifests secret is created by the user, it is not in the cache
+	secret, err := h.secrets.Get(namespace, name, metav1.GetOptions{})
+	if apierror.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return secret.Data, nil
+}
+
+// bootstrapManifestHash covers the keys in order, the objects are applied in the same order
+func bootstrapManifestHash(data map[string][]byte) string {
+	hash := sha256.New()
+	for _, key := range sortedKeys(data) {
+		hash.Write([]byte(key))
+		hash.Write([]byte{0})
+		hash.Write(data[key])
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func sortedKeys(data map[string][]byte) []string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (h *handler) applyBootstrapManifest(cluster *v1.Cluster, kind, manifestName string, data map[string][]byte) error {
+	var objs []runtime.Object
+	for _, key := range sortedKeys(data) {
+		keyObjs, err := yaml.ToObjects(bytes.NewReader(data[key]))
+		if err != nil {
+			return fmt.Errorf("key %s: %w", key, err)
+		}
+		objs = append(objs, keyObjs...)
+	}
+
+	return h.applyDownstream(cluster, name.SafeConcatName(bootstrapManifestSetIDPrefix, strings.ToLower(kind), manifestName), objs...)
+}
+
+// applyDownstream applies objs as the apply set setID to the downstream cluster with the generated kubeconfig,
+// without objects the set is deleted
+func (h *handler) applyDownstream(cluster *v1.Cluster, setID string, objs ...runtime.Object) error {
+	cfg, err := h.downstreamConfig(cluster)
+	if err != nil {
+		return err
+	}
+
+	return h.limiter.Do(cluster.Status.ClusterName, func() (err error) {
+		span := tracing.Start(cluster.Namespace, cluster.Name, "bootstrap.apply")
+		defer func() { tracing.End(span, err) }()
+
+		apply, err := apply.NewForConfig(cfg)
+		if err != nil {
+			return err
+		}
+		return apply.
+			WithDynamicLookup().
+			WithSetID(setID).
+			ApplyObjects(objs...)
+	})
+}
+
+// downstreamConfig returns the config of the generated kubeconfig of cluster
+func (h *handler) downstreamConfig(cluster *v1.Cluster) (*rest.Config, error) {
+	secret, err := h.secretCache.Get(cluster.Namespace, cluster.Status.ClientSecretName)
+	if err != nil {
+		return nil, err
+	}
+	return clientcmd.RESTConfigFromKubeConfig(secret.Data["value"])
+}
+
+func bootstrapStatusEqual(a, b []v1.BootstrapManifestStatus) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,157 @@
+package cluster
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/wrangler/pkg/name"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	bootstrapChartSetIDPrefix = "bootstrap-chart"
+	bootstrapChartNamespace   = "kube-system"
+	bootstrapChartValuesKey   = "values.yaml"
+)
+
+// bootstrapCharts applies a HelmChart for each changed chart and deletes the ones removed from the spec, the helm
+// controller of the downstream cluster installs, upgrades and uninstalls the releases. It returns their status
+// and the failed ones.
+func (h *handler) bootstrapCharts(cluster *v1.Cluster) ([]v1.BootstrapChartStatus, []string, error) {
+	previous := map[string]v1.BootstrapChartStatus{}
+	for _, status := range cluster.Status.BootstrapCharts {
+		previous[status.Name] = status
+	}
+
+	var (
+		statuses []v1.BootstrapChartStatus
+		failed   []string
+	)
+	for _, chart := range cluster.Spec.BootstrapCharts {
+		release := bootstrapChartRelease(chart)
+		status := previous[release]
+		delete(previous, release)
+		status.Name = release
+
+		values, err := h.bootstrapChartValues(cluster.Namespace, chart)
+		if err != nil {
+			return nil, nil, err
+		}
+		if values == nil {
+			status.Error = fmt.Sprintf("values secret %s not found", chart.ValuesSecretName)
+		} else if hash := bootstrapChartHash(chart, values); hash != status.Hash || status.Error != "" {
+			if err := h.applyDownstream(cluster, bootstrapChartSetID(release), helmChart(release, chart, values)); err != nil {
+				status.Error = err.Error()
+			} else {
+				status.Version = chart.Version
+				status.Hash = hash
+				status.AppliedAt = time.Now().UTC().Format(time.RFC3339)
+				status.Error = ""
+			}
+		}
+		if status.Error != "" {
+			failed = append(failed, fmt.Sprintf("chart %s: %s", release, status.Error))
+		}
+		statuses = append(statuses, status)
+	}
+
+	// the status of a removed chart is kept until its HelmChart is deleted
+	for _, status := range cluster.Status.BootstrapCharts {
+		if _, removed := previous[status.Name]; !removed {
+			continue
+		}
+		if err := h.applyDownstream(cluster, bootstrapChartSetID(status.Name)); err != nil {
+			status.Error = err.Error()
+			failed = append(failed, fmt.Sprintf("chart %s: %s", status.Name, status.Error))
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses, failed, nil
+}
+
+func bootstrapChartRelease(chart v1.BootstrapChart) string {
+	if chart.Name == "" {
+		return chart.Chart
+	}
+	return chart.Name
+}
+
+func bootstrapChartSetID(release string) string {
+	return name.SafeConcatName(bootstrapChartSetIDPrefix, release)
+}
+
+// bootstrapChartValues returns the values.yaml key of the values secret, empty without one and nil if the secret
+// doesn't exist
+func (h *handler) bootstrapChartValues(namespace string, chart v1.BootstrapChart) ([]byte, error) {
+	if chart.ValuesSecretName == "" {
+		return []byte{}, nil
+	}
+	// the values secret is created by the user, it is not in the cache
+	secret, err := h.secrets.Get(namespace, chart.ValuesSecretName, metav1.GetOptions{})
+	if apierror.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if values := secret.Data[bootstrapChartValuesKey]; values != nil {
+		return values, nil
+	}
+	return []byte{}, nil
+}
+
+func bootstrapChartHash(chart v1.BootstrapChart, values []byte) string {
+	hash := sha256.New()
+	for _, field := range []string{chart.Repo, chart.Chart, chart.Version, chart.TargetNamespace} {
+		hash.Write([]byte(field))
+		hash.Write([]byte{0})
+	}
+	hash.Write(values)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func helmChart(release string, chart v1.BootstrapChart, values []byte) *unstructured.Unstructured {
+	spec := map[string]interface{}{
+		"chart": chart.Chart,
+	}
+	if chart.Repo != "" {
+		spec["repo"] = chart.Repo
+	}
+	if chart.Version != "" {
+		spec["version"] = chart.Version
+	}
+	if chart.TargetNamespace != "" {
+		spec["targetNamespace"] = chart.TargetNamespace
+	}
+	if len(values) > 0 {
+		spec["valuesContent"] = string(values)
+	}
+
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "helm.cattle.io/v1",
+			"kind":       "HelmChart",
+			"metadata": map[string]interface{}{
+				"name":      release,
+				"namespace": bootstrapChartNamespace,
+			},
+			"spec": spec,
+		},
+	}
+}
+
+func bootstrapChartStatusEqual(a, b []v1.BootstrapChartStatus) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,63 @@
+package cluster
+
+import (
+	"fmt"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	rketypes "github.com/rancher/rke/types"
+	"github.com/rancher/wrangler/pkg/condition"
+	corev1 "k8s.io/api/core/v1"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Changing this annotation to a new value, such as the current date, rotates the certificates of all services of an
+// RKE cluster once. The CA is kept.
+const rotateCertificatesAnnotation = "rancher.cattle.io/rotate-certificates"
+
+// onRotateCertificates requests the rotation from Rancher, which clears the rotate certificates config of the
+// management cluster again once it is done
+func (h *handler) onRotateCertificates(key string, cluster *v1.Cluster) (*v1.Cluster, error) {
+	if cluster == nil || cluster.Spec.RancherKubernetesEngineConfig == nil || cluster.Status.ClusterName == "" {
+		return cluster, nil
+	}
+
+	request := cluster.Annotations[rotateCertificatesAnnotation]
+	if request == "" || (request == cluster.Status.CertificateRotation && !v1.ClusterConditionCertificatesRotated.IsFalse(cluster)) {
+		return cluster, nil
+	}
+
+	rCluster, err := h.rclusterCache.Get(cluster.Status.ClusterName)
+	if apierror.IsNotFound(err) {
+		return cluster, nil
+	} else if err != nil {
+		return cluster, err
+	}
+	if rCluster.Spec.RancherKubernetesEngineConfig == nil {
+		return cluster, nil
+	}
+
+	if request != cluster.Status.CertificateRotation {
+		rCluster = rCluster.DeepCopy()
+		rCluster.Spec.RancherKubernetesEngineConfig.RotateCertificates = &rketypes.RotateCertificates{}
+		if _, err := h.rclusters.Update(rCluster); err != nil {
+			return cluster, err
+		}
+		h.recorder.Eventf(cluster, corev1.EventTypeNormal, "RotatingCertificates", "Rotating the certificates of cluster %s", rCluster.Name)
+
+		cluster = cluster.DeepCopy()
+		cluster.Status.CertificateRotation = request
+		v1.ClusterConditionCertificatesRotated.False(cluster)
+		v1.ClusterConditionCertificatesRotated.Message(cluster, fmt.Sprintf("rotating certificates requested as %s", request))
+		return h.clusters.UpdateStatus(cluster)
+	}
+
+	if rCluster.Spec.RancherKubernetesEngineConfig.RotateCertificates != nil || !condition.Cond("Ready").IsTrue(rCluster) {
+		return cluster, nil
+	}
+
+	h.recorder.Eventf(cluster, corev1.EventTypeNormal, "CertificatesRotated", "The certificates of cluster %s are rotated", rCluster.Name)
+	cluster = cluster.DeepCopy()
+	v1.ClusterConditionCertificatesRotated.True(cluster)
+	v1.ClusterConditionCertificatesRotated.Message(cluster, "")
+	return h.clusters.UpdateStatus(cluster)
+}
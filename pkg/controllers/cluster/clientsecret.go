@@ -0,0 +1,147 @@
+package cluster
+
+import (
+	"bytes"
+	"time"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/metrics"
+	"github.com/rancher/rancher-operator/pkg/tenancy"
+	"github.com/rancher/rancher-operator/pkg/tracing"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const kubeConfigRetryInterval = 30 * time.Second
+
+// clientSecret decides the kubeconfig secret to publish. The secret is first generated when the cluster becomes
+// ready and from then on is kept while the cluster is not ready, unless revokeKubeConfigOnNotReady is set.
+func (h *handler) clientSecret(cluster *v1.Cluster, status v1.ClusterStatus, ready bool) (*corev1.Secret, v1.ClusterStatus, error) {
+	switch {
+	case ready:
+		revoked, err := h.tokenRevoked(cluster, status)
+		if err != nil {
+			return nil, status, err
+		}
+
+		secret, err := h.kubeconfigManager.GetKubeConfig(cluster, status)
+		if err != nil || secret == nil {
+			return nil, status, err
+		}
+		saved, err := h.kubeconfigManager.GetSavedKubeConfig(cluster)
+		if err != nil {
+			return nil, status, err
+		}
+		var verified bool
+		status, verified = h.validateKubeConfig(cluster, status, secret, saved)
+		if !verified {
+			// keep publishing the last kubeconfig, consumers never get an unverified replacement
+			return saved, status, nil
+		}
+		switch {
+		case saved == nil:
+			h.recorder.Eventf(cluster, corev1.EventTypeNormal, "KubeConfigCreated", "Wrote kubeconfig secret %s", secret.Name)
+		case !bytes.Equal(saved.Data["value"], secret.Data["value"]):
+			h.recorder.Eventf(cluster, corev1.EventTypeNormal, "KubeConfigRotated", "Updated kubeconfig secret %s", secret.Name)
+			metrics.KubeConfigRotations.Inc()
+		}
+		status.ClientSecretName = secret.Name
+		v1.ClusterConditionClientSecretValid.True(&status)
+		if revoked {
+			v1.ClusterConditionClientSecretValid.Message(&status, "regenerated because the previous token was revoked or expired")
+		} else {
+			v1.ClusterConditionClientSecretValid.Message(&status, "")
+		}
+		return secret, status, nil
+	case status.ClientSecretName == "":
+		// not generated yet
+		return nil, status, nil
+	case cluster.Spec.RevokeKubeConfigOnNotReady:
+		if err := h.kubeconfigManager.RevokeToken(cluster); err != nil {
+			return nil, status, err
+		}
+		status.ClientSecretName = ""
+		v1.ClusterConditionClientSecretValid.False(&status)
+		v1.ClusterConditionClientSecretValid.Message(&status, "kubeconfig revoked because the cluster is not ready")
+		return nil, status, nil
+	}
+
+	secret, err := h.kubeconfigManager.GetSavedKubeConfig(cluster)
+	if err != nil {
+		return nil, status, err
+	}
+	v1.ClusterConditionClientSecretValid.Unknown(&status)
+	v1.ClusterConditionClientSecretValid.Message(&status, "cluster is not ready")
+	return secret, status, nil
+}
+
+// kubeConfigSecretNamespaces splits kubeConfigSecretNamespaces into the namespaces the kubeconfig secret may be
+// replicated to and those whose ClusterReferenceGrants don't allow it
+func (h *handler) kubeConfigSecretNamespaces(cluster *v1.Cluster) ([]string, []string, error) {
+	var allowed, denied []string
+	for _, namespace := range cluster.Spec.KubeConfigSecretNamespaces {
+		ok, err := tenancy.CanReplicateKubeConfig(cluster.Namespace, namespace, h.listGrants)
+		if err != nil {
+			return nil, nil, err
+		}
+		if ok {
+			allowed = append(allowed, namespace)
+		} else {
+			denied = append(denied, namespace)
+		}
+	}
+	return allowed, denied, nil
+}
+
+// kubeConfigPending explains why clientSecret returned no secret to publish
+func kubeConfigPending(status v1.ClusterStatus, ready bool) string {
+	switch {
+	case v1.ClusterConditionClientSecretValid.IsFalse(&status):
+		return v1.ClusterConditionClientSecretValid.GetMessage(&status)
+	case ready && v1.ClusterConditionKubeConfigVerified.IsFalse(&status):
+		return "waiting for the kubeconfig to be verified: " + v1.ClusterConditionKubeConfigVerified.GetMessage(&status)
+	case ready:
+		return "waiting for the kubeconfig to be generated"
+	}
+	return "the kubeconfig is generated once the cluster is ready"
+}
+
+// validateKubeConfig tests secret against the cluster unless it is unchanged from the already verified saved secret
+func (h *handler) validateKubeConfig(cluster *v1.Cluster, status v1.ClusterStatus, secret, saved *corev1.Secret) (v1.ClusterStatus, bool) {
+	if saved != nil && bytes.Equal(saved.Data["value"], secret.Data["value"]) &&
+		v1.ClusterConditionKubeConfigVerified.IsTrue(&status) {
+		return status, true
+	}
+
+	err := h.limiter.Do(status.ClusterName, func() (err error) {
+		span := tracing.Start(cluster.Namespace, cluster.Name, "kubeconfig.validate")
+		defer func() { tracing.End(span, err) }()
+		return h.kubeconfigManager.Validate(secret)
+	})
+	if err != nil {
+		v1.ClusterConditionKubeConfigVerified.False(&status)
+		v1.ClusterConditionKubeConfigVerified.Message(&status, err.Error())
+		h.clusters.EnqueueAfter(cluster.Namespace, cluster.Name, kubeConfigRetryInterval)
+		return status, false
+	}
+
+	v1.ClusterConditionKubeConfigVerified.True(&status)
+	v1.ClusterConditionKubeConfigVerified.Message(&status, "")
+	return status, true
+}
+
+// tokenRevoked reports whether the Rancher token in the published kubeconfig was revoked or expired, in which case
+// the kubeconfig is regenerated with a new token
+func (h *handler) tokenRevoked(cluster *v1.Cluster, status v1.ClusterStatus) (bool, error) {
+	if status.ClientSecretName == "" || cluster.Spec.KubeConfigFormat == v1.KubeConfigFormatExec {
+		return false, nil
+	}
+
+	valid, err := h.kubeconfigManager.TokenValid(cluster)
+	if err != nil || valid {
+		return false, err
+	}
+
+	h.recorder.Eventf(cluster, corev1.EventTypeWarning, "TokenRevoked",
+		"Token for kubeconfig secret %s was revoked or expired, regenerating", status.ClientSecretName)
+	return true, nil
+}
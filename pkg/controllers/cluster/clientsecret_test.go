@@ -0,0 +1,214 @@
+package cluster
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/downstream"
+	rocontrollers "github.com/rancher/rancher-operator/pkg/generated/controllers/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/kubeconfig"
+	"github.com/rancher/rancher-operator/pkg/kubeconfig/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/record"
+)
+
+func clientSecretHandler(provider *fake.Provider) (*handler, *fakeClusters) {
+	clusters := &fakeClusters{}
+	return &handler{
+		clusters:          clusters,
+		kubeconfigManager: provider,
+		limiter:           downstream.NewLimiter(1, 1),
+		recorder:          record.NewFakeRecorder(10),
+	}, clusters
+}
+
+func TestClientSecretReady(t *testing.T) {
+	provider := fake.NewProvider()
+	h, _ := clientSecretHandler(provider)
+	cluster := testCluster()
+	status := v1.ClusterStatus{ClusterName: "c-test"}
+
+	// not generated before the cluster is ready
+	secret, status, err := h.clientSecret(cluster, status, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if secret != nil || status.ClientSecretName != "" {
+		t.Fatalf("got secret %v and client secret name %q before the cluster is ready", secret, status.ClientSecretName)
+	}
+
+	secret, status, err = h.clientSecret(cluster, status, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if secret == nil {
+		t.Fatal("no secret once the cluster is ready")
+	}
+	if want := kubeconfig.GetKubeConfigSecretName(cluster); secret.Name != want || status.ClientSecretName != want {
+		t.Errorf("got secret %s and client secret name %s, want %s", secret.Name, status.ClientSecretName, want)
+	}
+	if !v1.ClusterConditionClientSecretValid.IsTrue(&status) || !v1.ClusterConditionKubeConfigVerified.IsTrue(&status) {
+		t.Errorf("ClientSecretValid is %q and KubeConfigVerified is %q, want True",
+			v1.ClusterConditionClientSecretValid.GetStatus(&status), v1.ClusterConditionKubeConfigVerified.GetStatus(&status))
+	}
+
+	// applied by the generating handler
+	provider.SaveKubeConfig(cluster, secret)
+
+	// the published secret is kept while the cluster is not ready
+	kept, status, err := h.clientSecret(cluster, status, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kept == nil || string(kept.Data["value"]) != string(secret.Data["value"]) {
+		t.Errorf("got secret %v while not ready, want the published one", kept)
+	}
+	if !v1.ClusterConditionClientSecretValid.IsUnknown(&status) {
+		t.Errorf("ClientSecretValid is %q while not ready, want Unknown", v1.ClusterConditionClientSecretValid.GetStatus(&status))
+	}
+}
+
+func TestClientSecretRevokeOnNotReady(t *testing.T) {
+	provider := fake.NewProvider()
+	h, _ := clientSecretHandler(provider)
+	cluster := testCluster()
+	cluster.Spec.RevokeKubeConfigOnNotReady = true
+
+	_, status, err := h.clientSecret(cluster, v1.ClusterStatus{ClusterName: "c-test"}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	secret, status, err := h.clientSecret(cluster, status, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if secret != nil || status.ClientSecretName != "" {
+		t.Errorf("got secret %v and client secret name %q after revoking", secret, status.ClientSecretName)
+	}
+	if valid, _ := provider.TokenValid(cluster); valid {
+		t.Error("token is still valid after revoking")
+	}
+	if !v1.ClusterConditionClientSecretValid.IsFalse(&status) {
+		t.Errorf("ClientSecretValid is %q after revoking, want False", v1.ClusterConditionClientSecretValid.GetStatus(&status))
+	}
+}
+
+func TestClientSecretNotVerified(t *testing.T) {
+	provider := fake.NewProvider()
+	provider.ValidateErr = errors.New("connection refused")
+	h, clusters := clientSecretHandler(provider)
+	cluster := testCluster()
+
+	secret, status, err := h.clientSecret(cluster, v1.ClusterStatus{ClusterName: "c-test"}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if secret != nil || status.ClientSecretName != "" {
+		t.Errorf("published unverified secret %v", secret)
+	}
+	if !v1.ClusterConditionKubeConfigVerified.IsFalse(&status) {
+		t.Errorf("KubeConfigVerified is %q, want False", v1.ClusterConditionKubeConfigVerified.GetStatus(&status))
+	}
+	if len(clusters.enqueued) != 1 {
+		t.Errorf("requeued %v, want the cluster once", clusters.enqueued)
+	}
+}
+
+func TestClientSecretRotateAfterFailedValidation(t *testing.T) {
+	provider := fake.NewProvider()
+	h, _ := clientSecretHandler(provider)
+	cluster := testCluster()
+
+	published, status, err := h.clientSecret(cluster, v1.ClusterStatus{ClusterName: "c-test"}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider.SaveKubeConfig(cluster, published)
+
+	// the token is revoked and the kubeconfig of its replacement can't be verified yet
+	if err := provider.RevokeToken(cluster); err != nil {
+		t.Fatal(err)
+	}
+	provider.ValidateErr = errors.New("connection refused")
+
+	secret, status, err := h.clientSecret(cluster, status, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if secret == nil || string(secret.Data["token"]) != string(published.Data["token"]) {
+		t.Fatalf("got secret %v, want the published one kept", secret)
+	}
+	if !v1.ClusterConditionKubeConfigVerified.IsFalse(&status) {
+		t.Errorf("KubeConfigVerified is %q, want False", v1.ClusterConditionKubeConfigVerified.GetStatus(&status))
+	}
+
+	// the next attempt reuses the new token instead of the revoked one still in the published secret
+	provider.ValidateErr = nil
+	secret, status, err = h.clientSecret(cluster, status, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if secret == nil || string(secret.Data["token"]) == string(published.Data["token"]) {
+		t.Fatalf("got secret %v, want one with the new token", secret)
+	}
+	if err := provider.Validate(secret); err != nil {
+		t.Errorf("published secret is not valid: %v", err)
+	}
+	if !v1.ClusterConditionKubeConfigVerified.IsTrue(&status) || !v1.ClusterConditionClientSecretValid.IsTrue(&status) {
+		t.Errorf("KubeConfigVerified is %q and ClientSecretValid is %q, want True",
+			v1.ClusterConditionKubeConfigVerified.GetStatus(&status), v1.ClusterConditionClientSecretValid.GetStatus(&status))
+	}
+}
+
+// fakeGrantCache only implements List
+type fakeGrantCache struct {
+	rocontrollers.ClusterReferenceGrantCache
+	objs []*v1.ClusterReferenceGrant
+}
+
+func (f *fakeGrantCache) List(namespace string, _ labels.Selector) ([]*v1.ClusterReferenceGrant, error) {
+	var result []*v1.ClusterReferenceGrant
+	for _, obj := range f.objs {
+		if obj.Namespace == namespace {
+			result = append(result, obj)
+		}
+	}
+	return result, nil
+}
+
+func TestKubeConfigSecretNamespaces(t *testing.T) {
+	grant := func(namespace string, kubeConfigSecrets bool, from ...string) *v1.ClusterReferenceGrant {
+		grant := &v1.ClusterReferenceGrant{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "grant"},
+			Spec:       v1.ClusterReferenceGrantSpec{KubeConfigSecrets: kubeConfigSecrets},
+		}
+		for _, namespace := range from {
+			grant.Spec.From = append(grant.Spec.From, v1.ClusterReferenceGrantFrom{Namespace: namespace})
+		}
+		return grant
+	}
+	h := &handler{
+		grantCache: &fakeGrantCache{objs: []*v1.ClusterReferenceGrant{
+			grant("granted", true, "fleet-default"),
+			grant("reference-only", false, "fleet-default"),
+			grant("other-namespace", true, "other"),
+		}},
+	}
+	cluster := testCluster()
+	cluster.Spec.KubeConfigSecretNamespaces = []string{"granted", "reference-only", "other-namespace", "no-grant", cluster.Namespace}
+
+	allowed, denied, err := h.kubeConfigSecretNamespaces(cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"granted", cluster.Namespace}; !reflect.DeepEqual(allowed, want) {
+		t.Errorf("got allowed namespaces %v, want %v", allowed, want)
+	}
+	if want := []string{"reference-only", "other-namespace", "no-grant"}; !reflect.DeepEqual(denied, want) {
+		t.Errorf("got denied namespaces %v, want %v", denied, want)
+	}
+}
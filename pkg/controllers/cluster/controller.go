@@ -2,84 +2,253 @@ package cluster
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rancher/norman/types/convert"
 	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
 	"github.com/rancher/rancher-operator/pkg/clients"
+	"github.com/rancher/rancher-operator/pkg/condition/kstatus"
+	"github.com/rancher/rancher-operator/pkg/downstream"
+	"github.com/rancher/rancher-operator/pkg/encryption"
+	"github.com/rancher/rancher-operator/pkg/features"
 	mgmtcontrollers "github.com/rancher/rancher-operator/pkg/generated/controllers/management.cattle.io/v3"
 	rocontrollers "github.com/rancher/rancher-operator/pkg/generated/controllers/rancher.cattle.io/v1"
 	"github.com/rancher/rancher-operator/pkg/kubeconfig"
+	"github.com/rancher/rancher-operator/pkg/operatorconfig"
+	"github.com/rancher/rancher-operator/pkg/options"
+	"github.com/rancher/rancher-operator/pkg/validation"
 	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/wrangler/pkg/apply"
 	"github.com/rancher/wrangler/pkg/condition"
 	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
 	"github.com/rancher/wrangler/pkg/generic"
-	"github.com/rancher/wrangler/pkg/kstatus"
-	"github.com/rancher/wrangler/pkg/name"
 	"github.com/rancher/wrangler/pkg/relatedresource"
+	corev1 "k8s.io/api/core/v1"
 	apierror "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
 )
 
 const (
-	byCluster = "by-cluster"
+	byCluster   = "by-cluster"
+	byReference = "by-reference"
+
+	// The names below are persisted in the objects the operator creates. The generating handler name is the
+	// apply set ID of the v3 clusters and secrets, and the remove handler name is the finalizer on v1 clusters.
+	// Renaming them orphans or deletes existing objects, add the old set ID to legacySetIDs when that is needed.
+	removeHandlerName        = "cluster-remove"
+	supportBundleHandlerName = "cluster-support-bundle"
+	generatingHandlerName    = "cluster-create"
+	failureHandlerName       = "cluster-failure"
+	dryRunHandlerName        = "cluster-dry-run"
+	certificatesHandlerName  = "cluster-rotate-certificates"
+	hibernateHandlerName     = "cluster-hibernate"
+	drainHandlerName         = "cluster-drain-before-delete"
+	exportHandlerName        = "cluster-export"
+	reprovisionHandlerName   = "cluster-reprovision"
+	imagesHandlerName        = "cluster-images"
+	bootstrapHandlerName     = "cluster-bootstrap"
+	sshKeyHandlerName        = "cluster-ssh-key"
+
+	conditionHistoryHandlerName = "cluster-condition-history"
+
+	// v3 clusters and nodes are very chatty, related events within this window are collapsed into one reconcile
+	relatedEnqueueDelay = 2 * time.Second
 )
 
+var (
+	clusterGVK = v1.SchemeGroupVersion.WithKind("Cluster").String()
+)
+
+type delayedEnqueuer struct {
+	clusters rocontrollers.ClusterController
+	delay    time.Duration
+}
+
+func (d *delayedEnqueuer) Enqueue(namespace, name string) {
+	d.clusters.EnqueueAfter(namespace, name, d.delay)
+}
+
+// operatorOwned returns true for v3 clusters generated or claimed by a v1 Cluster
+func operatorOwned(cluster *v3.Cluster) bool {
+	return cluster.Annotations[apply.LabelGVK] == clusterGVK ||
+		cluster.Labels[claimedLabelName] != ""
+}
+
 type handler struct {
-	rclusterCache     mgmtcontrollers.ClusterCache
-	rclusters         mgmtcontrollers.ClusterClient
-	clusterTokenCache mgmtcontrollers.ClusterRegistrationTokenCache
-	clusterTokens     mgmtcontrollers.ClusterRegistrationTokenClient
-	clusters          rocontrollers.ClusterController
-	secretCache       corecontrollers.SecretCache
-	kubeconfigManager *kubeconfig.Manager
+	deletionGracePeriod time.Duration
+	provisioningTimeout time.Duration
+	tokenTTL            time.Duration
+	argoCDNamespace     string
+	versionSkewPolicy   string
+	instance            string
+	standalone          bool
+	managementApply     apply.Apply
+	config              *operatorconfig.Config
+	decrypters          encryption.Decrypters
+	discovery           discovery.ServerVersionInterface
+	dynamic             dynamic.Interface
+	rclusterCache       mgmtcontrollers.ClusterCache
+	rclusters           mgmtcontrollers.ClusterClient
+	clusterTokenCache   mgmtcontrollers.ClusterRegistrationTokenCache
+	clusterTokens       mgmtcontrollers.ClusterRegistrationTokenClient
+	nodeCache           mgmtcontrollers.NodeCache
+	nodes               mgmtcontrollers.NodeClient
+	nodePoolCache       mgmtcontrollers.NodePoolCache
+	nodePools           mgmtcontrollers.NodePoolClient
+	workspaceCache      mgmtcontrollers.FleetWorkspaceCache
+	settingCache        mgmtcontrollers.SettingCache
+	fleetWorkspaces     map[string]string
+	allowedWorkspaces   []string
+	clusters            rocontrollers.ClusterController
+	clusterGroupCache   rocontrollers.ClusterGroupCache
+	exportCache         rocontrollers.ClusterExportCache
+	grantCache          rocontrollers.ClusterReferenceGrantCache
+	secretCache         corecontrollers.SecretCache
+	secrets             corecontrollers.SecretClient
+	configMaps          corecontrollers.ConfigMapClient
+	configMapCache      corecontrollers.ConfigMapCache
+	events              typedcorev1.EventsGetter
+	kubeconfigManager   kubeconfig.Provider
+	limiter             *downstream.Limiter
+	recorder            record.EventRecorder
+	priority            *prioritizer
+
+	// failures holds the reason of the last failed reconcile by namespace/name
+	failures sync.Map
 }
 
 func Register(
 	ctx context.Context,
-	clients *clients.Clients) {
+	clients *clients.Clients,
+	opts options.Options,
+	decrypters encryption.Decrypters,
+	limiter *downstream.Limiter,
+	config *operatorconfig.Config) {
 	h := handler{
-		rclusterCache:     clients.Management.Cluster().Cache(),
-		rclusters:         clients.Management.Cluster(),
-		clusterTokenCache: clients.Management.ClusterRegistrationToken().Cache(),
-		clusterTokens:     clients.Management.ClusterRegistrationToken(),
-		clusters:          clients.Cluster(),
-		secretCache:       clients.Core.Secret().Cache(),
-		kubeconfigManager: kubeconfig.New(clients),
+		versionSkewPolicy:   opts.VersionSkewPolicy,
+		instance:            opts.InstanceName,
+		standalone:          clients.Standalone,
+		managementApply:     clients.ManagementApply,
+		config:              config,
+		decrypters:          decrypters,
+		discovery:           clients.ManagementK8s.Discovery(),
+		dynamic:             dynamic.NewForConfigOrDie(clients.ManagementConfig),
+		deletionGracePeriod: opts.DeletionGracePeriod,
+		provisioningTimeout: opts.ProvisioningTimeout,
+		tokenTTL:            opts.RegistrationTokenTTL,
+		argoCDNamespace:     opts.ArgoCDNamespace,
+		rclusterCache:       clients.Management.Cluster().Cache(),
+		rclusters:           clients.Management.Cluster(),
+		clusterTokenCache:   clients.Management.ClusterRegistrationToken().Cache(),
+		clusterTokens:       clients.Management.ClusterRegistrationToken(),
+		nodeCache:           clients.Management.Node().Cache(),
+		nodes:               clients.Management.Node(),
+		nodePoolCache:       clients.Management.NodePool().Cache(),
+		nodePools:           clients.Management.NodePool(),
+		workspaceCache:      clients.Management.FleetWorkspace().Cache(),
+		settingCache:        clients.Management.Setting().Cache(),
+		fleetWorkspaces:     opts.FleetWorkspaces,
+		allowedWorkspaces:   opts.AllowedFleetWorkspaces,
+		clusters:            clients.Cluster(),
+		clusterGroupCache:   clients.ClusterGroup().Cache(),
+		exportCache:         clients.ClusterExport().Cache(),
+		grantCache:          clients.ClusterReferenceGrant().Cache(),
+		secretCache:         clients.Core.Secret().Cache(),
+		secrets:             clients.Core.Secret(),
+		configMaps:          clients.Core.ConfigMap(),
+		configMapCache:      clients.Core.ConfigMap().Cache(),
+		events:              clients.K8s.CoreV1(),
+		kubeconfigManager:   kubeconfig.New(clients, opts.KubeConfigCABundleFile, limiter, config),
+		limiter:             limiter,
+		recorder:            clients.Recorder,
+		priority:            newPrioritizer(clients.Cluster().Informer(), clients.Management.Cluster().Cache()),
 	}
 
-	clients.Cluster().OnChange(ctx, "cluster-update", h.onChange)
-	rocontrollers.RegisterClusterGeneratingHandler(ctx,
+	clients.Cluster().OnRemove(ctx, removeHandlerName, instrument(removeHandlerName, h.onRemove))
+	clients.Cluster().OnChange(ctx, supportBundleHandlerName, instrument(supportBundleHandlerName, h.onSupportBundle))
+	clients.Cluster().OnChange(ctx, conditionHistoryHandlerName, instrument(conditionHistoryHandlerName, h.onConditionHistory))
+	// registered before the generating handler so the images are listed before the v3 cluster is first applied
+	clients.Cluster().OnChange(ctx, imagesHandlerName, instrument(imagesHandlerName, h.onImages))
+	registerGeneratingHandler(ctx,
 		clients.Cluster(),
 		clients.Apply.WithCacheTypes(clients.Management.Cluster(),
 			clients.Core.Secret()),
-		"Created",
-		"cluster-create",
-		h.generateCluster,
+		v1.ClusterConditionReconciled,
+		generatingHandlerName,
+		instrumentGenerating(generatingHandlerName, h.recordFailure(h.generateCluster)),
 		&generic.GeneratingHandlerOptions{
 			AllowClusterScoped: true,
 		},
 	)
+	clients.Cluster().OnChange(ctx, failureHandlerName, instrument(failureHandlerName, h.onFailure))
+	clients.Cluster().OnChange(ctx, dryRunHandlerName, instrument(dryRunHandlerName, h.onDryRun))
+	clients.Cluster().OnChange(ctx, certificatesHandlerName, instrument(certificatesHandlerName, h.onRotateCertificates))
+	clients.Cluster().OnChange(ctx, hibernateHandlerName, instrument(hibernateHandlerName, h.onHibernate))
+	clients.Cluster().OnChange(ctx, drainHandlerName, instrument(drainHandlerName, h.onDrainBeforeDelete))
+	clients.Cluster().OnChange(ctx, reprovisionHandlerName, instrument(reprovisionHandlerName, h.onReprovision))
+	clients.Cluster().OnChange(ctx, bootstrapHandlerName, instrument(bootstrapHandlerName, h.onBootstrap))
+	clients.Cluster().OnChange(ctx, sshKeyHandlerName, instrument(sshKeyHandlerName, h.onSSHKey))
+	rocontrollers.RegisterClusterExportStatusHandler(ctx,
+		clients.ClusterExport(),
+		"",
+		exportHandlerName,
+		h.onExport)
 
 	clusterCache := clients.Cluster().Cache()
+	prometheus.MustRegister(phaseCollector{
+		clusterCache: clusterCache,
+	})
+
+	batch := &relatedBatch{
+		delay:   relatedEnqueueDelay,
+		resolve: h.relatedClusters,
+		enqueue: clients.Cluster().Enqueue,
+	}
 	relatedresource.Watch(ctx, "cluster-watch", func(namespace, name string, obj runtime.Object) ([]relatedresource.Key, error) {
-		cluster, ok := obj.(*v3.Cluster)
-		if !ok {
+		if cluster, ok := obj.(*v3.Cluster); ok {
+			batch.add(cluster)
+		}
+		return nil, nil
+	}, clients.Cluster(), clients.Management.Cluster())
+
+	// Regenerate kubeconfigs when their token is revoked, the token is named after the provisioning user
+	relatedresource.Watch(ctx, "cluster-token-watch", func(namespace, name string, obj runtime.Object) ([]relatedresource.Key, error) {
+		if token, ok := obj.(*v3.Token); ok && token.Labels[kubeconfig.TokenKindLabel] != kubeconfig.TokenKindProvisioning {
 			return nil, nil
 		}
-		operatorClusters, err := clusterCache.GetByIndex(byCluster, cluster.Name)
-		if err != nil || len(operatorClusters) == 0 {
-			// ignore
+		clusterNamespace, clusterName, ok := h.kubeconfigManager.ClusterForUser(name)
+		if !ok {
 			return nil, nil
 		}
 		return []relatedresource.Key{
 			{
-				Namespace: operatorClusters[0].Namespace,
-				Name:      operatorClusters[0].Name,
+				Namespace: clusterNamespace,
+				Name:      clusterName,
 			},
 		}, nil
-	}, clients.Cluster(), clients.Management.Cluster())
+	}, clients.Cluster(), clients.Management.Token())
+
+	relatedresource.Watch(ctx, "cluster-group-watch", h.groupClusters, clients.Cluster(), clients.ClusterGroup())
+	relatedresource.Watch(ctx, "cluster-reference-grant-watch", h.grantClusters, clients.Cluster(), clients.ClusterReferenceGrant())
+	// nodes are updated often, like v3 clusters
+	relatedresource.Watch(ctx, "cluster-node-pool-watch", h.poolClusters, &delayedEnqueuer{
+		clusters: clients.Cluster(),
+		delay:    relatedEnqueueDelay,
+	}, clients.Management.NodePool(), clients.Management.Node())
+
+	relatedresource.Watch(ctx, "cluster-export-watch", h.clusterExports, clients.ClusterExport(), clients.Cluster())
+	relatedresource.Watch(ctx, "cluster-reference-watch", h.referencingClusters, clients.Cluster(),
+		clients.Core.ConfigMap(), clients.Core.Secret())
 
 	clusterCache.AddIndexer(byCluster, func(obj *v1.Cluster) ([]string, error) {
 		if obj.Status.ClusterName == "" {
@@ -87,66 +256,271 @@ func Register(
 		}
 		return []string{obj.Status.ClusterName}, nil
 	})
+	clusterCache.AddIndexer(byReference, func(obj *v1.Cluster) ([]string, error) {
+		return References(obj), nil
+	})
+	h.exportCache.AddIndexer(byCluster, func(obj *v1.ClusterExport) ([]string, error) {
+		return []string{obj.Namespace + "/" + obj.Spec.ClusterName}, nil
+	})
+	h.secretCache.AddIndexer(byOwner, func(obj *corev1.Secret) ([]string, error) {
+		return appliedFor(obj.ObjectMeta), nil
+	})
+	h.rclusterCache.AddIndexer(byOwner, func(obj *v3.Cluster) ([]string, error) {
+		return appliedFor(obj.ObjectMeta), nil
+	})
 }
 
-func (h *handler) onChange(key string, cluster *v1.Cluster) (*v1.Cluster, error) {
-	if cluster == nil {
-		return cluster, nil
+// References returns the config maps and secrets the cluster reads its bootstrap manifests, chart values, SSH key
+// and API server files from, as <namespace>/<kind>/<name>
+func References(obj *v1.Cluster) []string {
+	var keys []string
+	for _, manifest := range obj.Spec.BootstrapManifests {
+		keys = append(keys, fmt.Sprintf("%s/%s/%s", obj.Namespace, bootstrapManifestKind(manifest), manifest.Name))
 	}
-
-	if cluster.Spec.ControlPlaneEndpoint == nil {
-		// just set to something, this doesn't really make sense to me
-		cluster = cluster.DeepCopy()
-		cluster.Spec.ControlPlaneEndpoint = &v1.Endpoint{
-			Host: "localhost",
-			Port: 6443,
+	for _, chart := range obj.Spec.BootstrapCharts {
+		if chart.ValuesSecretName != "" {
+			keys = append(keys, fmt.Sprintf("%s/Secret/%s", obj.Namespace, chart.ValuesSecretName))
 		}
-		return h.clusters.Update(cluster)
 	}
-	return cluster, nil
+	if obj.Spec.SSHKey != nil {
+		keys = append(keys, fmt.Sprintf("%s/Secret/%s", obj.Namespace, sshKeySecretName(obj)))
+	}
+	if files := obj.Spec.APIServerFiles; files != nil {
+		for _, ref := range []*corev1.SecretKeySelector{files.AuditPolicy, files.AdmissionConfiguration} {
+			if ref != nil {
+				keys = append(keys, fmt.Sprintf("%s/Secret/%s", obj.Namespace, ref.Name))
+			}
+		}
+	}
+	return keys
+}
+
+// relatedClusters returns the clusters of a changed v3 cluster, the ones it was generated for or claimed by, or
+// the referencing clusters it may be bound to
+func (h *handler) relatedClusters(rCluster *v3.Cluster) ([]relatedresource.Key, error) {
+	if !operatorOwned(rCluster) {
+		return h.unboundReferences(rCluster)
+	}
+	return h.clustersOf(rCluster.Name)
+}
+
+// clustersOf returns every cluster with status.clusterName set to the management cluster. More than one
+// cluster can reference the same management cluster, all of them are requeued so the claim conflict is reported.
+func (h *handler) clustersOf(rClusterName string) ([]relatedresource.Key, error) {
+	clusters, err := h.clusters.Cache().GetByIndex(byCluster, rClusterName)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]relatedresource.Key, 0, len(clusters))
+	for _, cluster := range clusters {
+		keys = append(keys, relatedresource.Key{
+			Namespace: cluster.Namespace,
+			Name:      cluster.Name,
+		})
+	}
+	return keys, nil
 }
 
 func (h *handler) generateCluster(cluster *v1.Cluster, status v1.ClusterStatus) ([]runtime.Object, v1.ClusterStatus, error) {
+	if err := h.migrateSetIDs(cluster); err != nil {
+		return nil, status, err
+	}
+
+	// skipping keeps the applied objects and status as they are, returning no objects would prune them
+	if dryRun(cluster) {
+		h.priority.skipped(cluster)
+		return nil, status, generic.ErrSkip
+	}
+
+	// applying would update the management cluster that a reprovision request is deleting
+	if reprovisioning(cluster) {
+		h.priority.skipped(cluster)
+		return nil, status, generic.ErrSkip
+	}
+
+	// a settled cluster is applied again once the clusters waiting for their first or changed apply are done
+	if h.config.Get().Enabled(features.ResyncPriority) && h.priority.deferResync(cluster) {
+		h.clusters.EnqueueAfter(cluster.Namespace, cluster.Name, resyncDeferInterval)
+		return nil, status, generic.ErrSkip
+	}
+
+	// the groups are only merged into a copy, the generated objects are still owned by the cluster
+	original := cluster
+	cluster, err := h.withClusterGroups(cluster)
+	if err != nil {
+		return nil, status, err
+	}
+	cluster, err = h.withAPIServerFiles(cluster)
+	if err != nil {
+		return nil, status, err
+	}
+
+	// the webhook rejects these as well, this catches clusters created while it was not installed
+	if errs := validation.ProviderConfig(cluster); len(errs) > 0 {
+		return nil, status, newFailure(failureReasonInvalidConfiguration, fmt.Errorf("%s", strings.Join(errs, "; ")))
+	}
+
+	kstatus.SetObservedGeneration(&status.ObservedGeneration, cluster)
+
+	var objs []runtime.Object
+	spec, ok := rancherClusterSpec(cluster)
 	switch {
 	case cluster.Spec.ImportedConfig != nil:
-		return h.importCluster(cluster, status, v3.ClusterSpec{
+		objs, status, err = h.importCluster(cluster, status, spec)
+	case cluster.Spec.ReferencedConfig != nil:
+		objs, status, err = h.referenceCluster(cluster, status)
+	case ok:
+		objs, status, err = h.createCluster(cluster, status, spec)
+	}
+	if err != nil {
+		return objs, status, err
+	}
+
+	if interval := h.config.Get().ClusterResyncInterval; interval > 0 {
+		h.clusters.EnqueueAfter(cluster.Namespace, cluster.Name, interval)
+	}
+
+	// returning no objects prunes the applied ones as well, so this is checked for every cluster
+	return h.checkOwnership(original, objs, status)
+}
+
+// rancherClusterSpec returns the spec of the v3 cluster generated for cluster, false if no v3 cluster is generated
+func rancherClusterSpec(cluster *v1.Cluster) (v3.ClusterSpec, bool) {
+	spec, ok := providerClusterSpec(cluster)
+	if ok {
+		spec.DefaultPodSecurityPolicyTemplateName = cluster.Spec.DefaultPodSecurityPolicyTemplateName
+		spec.AgentEnvVars = cluster.Spec.AgentEnvVars
+		spec.AgentImageOverride = cluster.Spec.AgentImageOverride
+		spec = withUpgradeStrategy(spec, cluster.Spec.UpgradeStrategy)
+		spec = withEtcdSnapshotSchedule(spec, cluster.Spec.EtcdSnapshotSchedule)
+		spec = withHibernation(spec, cluster.Spec.Hibernate)
+		spec = withProxy(spec, cluster.Spec.Proxy)
+	}
+	return spec, ok
+}
+
+// providerClusterSpec returns the provider specific part of the v3 cluster spec
+func providerClusterSpec(cluster *v1.Cluster) (v3.ClusterSpec, bool) {
+	switch {
+	case cluster.Spec.ImportedConfig != nil:
+		return v3.ClusterSpec{
 			ImportedConfig: &v3.ImportedConfig{},
-		})
+		}, true
 	case cluster.Spec.ReferencedConfig != nil:
-		return h.referenceCluster(cluster, status)
+		return v3.ClusterSpec{}, false
 	case cluster.Spec.RancherKubernetesEngineConfig != nil:
-		return h.createCluster(cluster, status, v3.ClusterSpec{
+		return v3.ClusterSpec{
 			ClusterSpecBase: v3.ClusterSpecBase{
 				RancherKubernetesEngineConfig: cluster.Spec.RancherKubernetesEngineConfig,
 				LocalClusterAuthEndpoint:      cluster.Spec.LocalClusterAuthEndpoint,
 			},
-		})
+		}, true
 	case cluster.Spec.EKSConfig != nil:
-		return h.createCluster(cluster, status, v3.ClusterSpec{
+		return v3.ClusterSpec{
 			EKSConfig: cluster.Spec.EKSConfig,
-		})
+		}, true
 	case cluster.Spec.K3SConfig != nil:
-		return h.createCluster(cluster, status, v3.ClusterSpec{
+		return v3.ClusterSpec{
 			K3sConfig: cluster.Spec.K3SConfig,
-		})
+		}, true
 	case cluster.Spec.RKE2Config != nil:
-		return h.createCluster(cluster, status, v3.ClusterSpec{
+		return v3.ClusterSpec{
 			Rke2Config: cluster.Spec.RKE2Config,
-		})
+		}, true
 	default:
-		return nil, status, nil
+		return v3.ClusterSpec{}, false
 	}
 }
 
 func (h *handler) createCluster(cluster *v1.Cluster, status v1.ClusterStatus, spec v3.ClusterSpec) ([]runtime.Object, v1.ClusterStatus, error) {
+	status, err := h.checkVersionSkew(cluster, status)
+	if err != nil {
+		return nil, status, err
+	}
+
+	rClusterName, err := h.rancherClusterName(cluster, status)
+	if err != nil {
+		return nil, status, err
+	}
+
+	spec, status, err = h.upgrade(cluster, status, rClusterName, spec)
+	if err != nil {
+		return nil, status, err
+	}
+
+	spec, err = h.etcdSnapshotCredentials(cluster, spec)
+	if err != nil {
+		return nil, status, err
+	}
+
+	spec, err = h.sshKeyCredentials(cluster, spec)
+	if err != nil {
+		return nil, status, err
+	}
+
+	spec, err = h.withAutoscaling(cluster, rClusterName, spec)
+	if err != nil {
+		return nil, status, err
+	}
+
+	spec.FleetWorkspaceName, err = h.validFleetWorkspaceName(cluster)
+	if err != nil {
+		return nil, status, err
+	}
+
+	newCluster, data, err := renderCluster(cluster, rClusterName, spec)
+	if err != nil {
+		return nil, status, err
+	}
+
+	if err := h.auditSpecChange(cluster, newCluster); err != nil {
+		return nil, status, err
+	}
+
+	// Encrypted values are only turned into plaintext in the rendered v3 cluster, never in the v1 object
+	if err := h.decrypters.DecryptValues(data); err != nil {
+		return nil, status, err
+	}
+
+	data, status, err = h.holdChanges(cluster, status, rClusterName, data)
+	if err != nil {
+		return nil, status, err
+	}
+
+	objs, status, err := h.dryRunApply(cluster, status, rClusterName, data)
+	if err != nil {
+		return nil, status, err
+	}
+
+	return h.updateStatus(objs, cluster, status, newCluster)
+}
+
+func renderCluster(cluster *v1.Cluster, rClusterName string, spec v3.ClusterSpec) (*v3.Cluster, map[string]interface{}, error) {
 	spec.DisplayName = cluster.Name
 	spec.Description = cluster.Annotations["field.cattle.io/description"]
-	spec.FleetWorkspaceName = cluster.Namespace
+	if spec.FleetWorkspaceName == "" {
+		spec.FleetWorkspaceName = cluster.Namespace
+	}
+	annotations, err := auditAnnotations(cluster, spec)
+	if err != nil {
+		return nil, nil, err
+	}
+	// spec.fleetLabels are passed on to the fleet cluster generated for the management cluster
+	if len(cluster.Spec.FleetLabels) > 0 {
+		fleetLabels, err := json.Marshal(cluster.Spec.FleetLabels)
+		if err != nil {
+			return nil, nil, err
+		}
+		annotations[v1.FleetLabelsAnnotation] = string(fleetLabels)
+	}
+	if enabled := cluster.Spec.EnableFleetAgent; enabled != nil && !*enabled {
+		annotations[v1.FleetAgentAnnotation] = "false"
+	}
 	newCluster := &v3.Cluster{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:        name.SafeConcatName("c", cluster.Namespace, cluster.Name),
+			Name:        rClusterName,
 			Labels:      cluster.Labels,
-			Annotations: cluster.Annotations,
+			Annotations: annotations,
 		},
 		Spec: spec,
 	}
@@ -154,49 +528,168 @@ func (h *handler) createCluster(cluster *v1.Cluster, status v1.ClusterStatus, sp
 	// We do this so that we don't clobber status because the rancher object is pretty dirty and doesn't have a status subresource
 	data, err := convert.EncodeToMap(newCluster)
 	if err != nil {
-		return nil, status, err
+		return nil, nil, err
 	}
 	data = map[string]interface{}{
 		"metadata": data["metadata"],
 		"spec":     data["spec"],
 	}
+	// the EncryptionKeyRotation controller sets rotateEncryptionKey and Rancher resets it, applying it would undo both
+	if spec, ok := data["spec"].(map[string]interface{}); ok {
+		if rke, ok := spec["rancherKubernetesEngineConfig"].(map[string]interface{}); ok {
+			delete(rke, "rotateEncryptionKey")
+		}
+	}
 	data["kind"] = "Cluster"
 	data["apiVersion"] = "management.cattle.io/v3"
 
-	return h.updateStatus([]runtime.Object{&unstructured.Unstructured{Object: data}}, cluster, status, newCluster)
+	return newCluster, data, nil
 }
 
 func (h *handler) updateStatus(objs []runtime.Object, cluster *v1.Cluster, status v1.ClusterStatus, rCluster *v3.Cluster) ([]runtime.Object, v1.ClusterStatus, error) {
 	ready := false
+	provisioned := ""
 	existing, err := h.rclusterCache.Get(rCluster.Name)
-	if err != nil && !apierror.IsNotFound(err) {
+	if apierror.IsNotFound(err) && status.ClusterName == "" && objs != nil {
+		h.recorder.Eventf(cluster, corev1.EventTypeNormal, "Creating", "Creating management cluster %s", rCluster.Name)
+	} else if err != nil && !apierror.IsNotFound(err) {
 		return nil, status, err
 	} else if err == nil {
 		if condition.Cond("Ready").IsTrue(existing) {
 			ready = true
 		}
+		provisioned = condition.Cond("Provisioned").GetStatus(existing)
+		status.Provider = existing.Status.Driver
+		if existing.Status.Version != nil {
+			status.KubernetesVersion = existing.Status.Version.GitVersion
+		}
+		status = h.agentStatus(cluster, status, existing)
+	}
+
+	// Mirror the provisioning state of the v3 cluster so it can be waited on
+	if provisioned != "" {
+		message := condition.Cond("Provisioned").GetMessage(existing)
+		if provisioned == "False" && message != "" && message != v1.ClusterConditionProvisioned.GetMessage(&status) {
+			h.recorder.Event(cluster, corev1.EventTypeWarning, "ProvisioningFailed", message)
+		}
+		v1.ClusterConditionProvisioned.SetStatus(&status, provisioned)
+		v1.ClusterConditionProvisioned.Message(&status, message)
+	} else {
+		v1.ClusterConditionProvisioned.Unknown(&status)
 	}
 
-	// Never set ready back to false because we will end up deleting the secret
-	status.Ready = status.Ready || ready
-	status.ObservedGeneration = cluster.Generation
+	if ready && !status.Ready {
+		h.recorder.Eventf(cluster, corev1.EventTypeNormal, "Ready", "Cluster %s is ready", rCluster.Name)
+	} else if !ready && status.Ready {
+		h.recorder.Eventf(cluster, corev1.EventTypeWarning, "NotReady", "Cluster %s is no longer ready", rCluster.Name)
+	}
+
+	status.Ready = ready
 	status.ClusterName = rCluster.Name
 	if ready {
 		kstatus.SetActive(&status)
+		v1.ClusterConditionReady.True(&status)
+	} else if message, stalled := h.stalled(cluster, status); stalled {
+		kstatus.SetError(&status, message)
+		v1.ClusterConditionReady.False(&status)
 	} else {
 		kstatus.SetTransitioning(&status, "")
+		v1.ClusterConditionReady.False(&status)
 	}
 
-	if status.Ready {
-		secret, err := h.kubeconfigManager.GetKubeConfig(cluster, status)
+	status.Phase = phase(cluster, status, existing != nil)
+
+	secret, status, err := h.clientSecret(cluster, status, ready)
+	if err != nil {
+		return nil, status, err
+	}
+	if secret == nil {
+		v1.ClusterConditionKubeConfigReady.False(&status)
+		v1.ClusterConditionKubeConfigReady.Message(&status, kubeConfigPending(status, ready))
+		return objs, status, nil
+	}
+	namespaces, denied, err := h.kubeConfigSecretNamespaces(cluster)
+	if err != nil {
+		return nil, status, err
+	}
+	v1.ClusterConditionKubeConfigReady.True(&status)
+	if len(denied) > 0 {
+		v1.ClusterConditionKubeConfigReady.Message(&status, fmt.Sprintf("not replicated to namespaces %s, no ClusterReferenceGrant allows kubeConfigSecrets from %s",
+			strings.Join(denied, ", "), cluster.Namespace))
+	} else {
+		v1.ClusterConditionKubeConfigReady.Message(&status, "")
+	}
+
+	objs = append(objs, secret)
+
+	// Replicas are part of the same apply set so they are updated and pruned with the original. Fleet reads the
+	// kubeconfig from the namespace of the fleet cluster, its workspace, in the cluster Rancher runs in.
+	if h.standalone {
+		if err := h.applyFleetSecret(cluster, rCluster.Spec.FleetWorkspaceName, secret); err != nil {
+			return nil, status, err
+		}
+	} else {
+		namespaces = append([]string{rCluster.Spec.FleetWorkspaceName}, namespaces...)
+	}
+	replicated := map[string]bool{secret.Namespace: true}
+	for _, namespace := range namespaces {
+		if namespace == "" || replicated[namespace] {
+			continue
+		}
+		replicated[namespace] = true
+		replica := secret.DeepCopy()
+		replica.Namespace = namespace
+		objs = append(objs, replica)
+	}
+
+	additional, err := kubeconfig.AdditionalKubeConfigs(cluster, secret)
+	if err != nil {
+		return nil, status, err
+	}
+	for _, additionalSecret := range additional {
+		objs = append(objs, additionalSecret)
+	}
+
+	if h.argoCDNamespace != "" {
+		argoSecret, err := kubeconfig.ArgoCDSecret(h.argoCDNamespace, cluster, secret)
 		if err != nil {
 			return nil, status, err
 		}
-		if secret != nil {
-			objs = append(objs, secret)
+		objs = append(objs, argoSecret)
+	}
+
+	if ready && cluster.Spec.SmokeTest != nil && !v1.ClusterConditionVerified.IsTrue(&status) {
+		status, err = h.verify(cluster, status, secret)
+		if err != nil {
+			return nil, status, err
 		}
-		status.ClientSecretName = secret.Name
 	}
 
 	return objs, status, nil
 }
+
+// referencingClusters requeues the clusters referencing a changed config map or secret
+func (h *handler) referencingClusters(namespace, name string, obj runtime.Object) ([]relatedresource.Key, error) {
+	var kind string
+	switch obj.(type) {
+	case *corev1.ConfigMap:
+		kind = "ConfigMap"
+	case *corev1.Secret:
+		kind = "Secret"
+	default:
+		return nil, nil
+	}
+
+	clusters, err := h.clusters.Cache().GetByIndex(byReference, fmt.Sprintf("%s/%s/%s", namespace, kind, name))
+	if err != nil {
+		return nil, err
+	}
+	var keys []relatedresource.Key
+	for _, cluster := range clusters {
+		keys = append(keys, relatedresource.Key{
+			Namespace: cluster.Namespace,
+			Name:      cluster.Name,
+		})
+	}
+	return keys, nil
+}
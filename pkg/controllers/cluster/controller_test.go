@@ -0,0 +1,150 @@
+package cluster
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	rocontrollers "github.com/rancher/rancher-operator/pkg/generated/controllers/rancher.cattle.io/v1"
+	"github.com/rancher/wrangler/pkg/relatedresource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type fakeClusters struct {
+	rocontrollers.ClusterController
+	cache *fakeClusterCache
+	// enqueued holds the namespace/name of the clusters requeued with EnqueueAfter
+	enqueued []string
+}
+
+func (f *fakeClusters) Cache() rocontrollers.ClusterCache {
+	return f.cache
+}
+
+func (f *fakeClusters) EnqueueAfter(namespace, name string, _ time.Duration) {
+	f.enqueued = append(f.enqueued, namespace+"/"+name)
+}
+
+// fakeClusterCache only implements the byCluster index
+type fakeClusterCache struct {
+	rocontrollers.ClusterCache
+	objs []*v1.Cluster
+	err  error
+}
+
+func (f *fakeClusterCache) GetByIndex(indexName, key string) ([]*v1.Cluster, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if indexName != byCluster {
+		return nil, errors.New("unknown index " + indexName)
+	}
+	var result []*v1.Cluster
+	for _, obj := range f.objs {
+		if obj.Status.ClusterName == key {
+			result = append(result, obj)
+		}
+	}
+	return result, nil
+}
+
+func clusterFor(namespace, name, rClusterName string) *v1.Cluster {
+	return &v1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Status: v1.ClusterStatus{
+			ClusterName: rClusterName,
+		},
+	}
+}
+
+func testCluster() *v1.Cluster {
+	return &v1.Cluster{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1.SchemeGroupVersion.String(),
+			Kind:       "Cluster",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "fleet-default",
+		},
+	}
+}
+
+func TestClustersOf(t *testing.T) {
+	indexErr := errors.New("index failed")
+	tests := []struct {
+		name     string
+		clusters []*v1.Cluster
+		err      error
+		want     []relatedresource.Key
+		wantErr  error
+	}{
+		{
+			name: "no cluster",
+			clusters: []*v1.Cluster{
+				clusterFor("fleet-default", "other", "c-other"),
+			},
+			want: []relatedresource.Key{},
+		},
+		{
+			name: "single owner",
+			clusters: []*v1.Cluster{
+				clusterFor("fleet-default", "test", "c-test"),
+				clusterFor("fleet-default", "other", "c-other"),
+			},
+			want: []relatedresource.Key{
+				{Namespace: "fleet-default", Name: "test"},
+			},
+		},
+		{
+			name: "multiple owners",
+			clusters: []*v1.Cluster{
+				clusterFor("fleet-default", "test", "c-test"),
+				clusterFor("fleet-local", "copy", "c-test"),
+			},
+			want: []relatedresource.Key{
+				{Namespace: "fleet-default", Name: "test"},
+				{Namespace: "fleet-local", Name: "copy"},
+			},
+		},
+		{
+			name: "index error",
+			clusters: []*v1.Cluster{
+				clusterFor("fleet-default", "test", "c-test"),
+			},
+			err:     indexErr,
+			wantErr: indexErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &handler{
+				clusters: &fakeClusters{
+					cache: &fakeClusterCache{
+						objs: tt.clusters,
+						err:  tt.err,
+					},
+				},
+			}
+			got, err := h.clustersOf("c-test")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if got != nil {
+					t.Errorf("got keys %v with an error", got)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got keys %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package cluster
+
+import (
+	"fmt"
+	"time"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/decommission"
+	"github.com/rancher/wrangler/pkg/generic"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	decommissionPollInterval   = 10 * time.Second
+	defaultDecommissionTimeout = 30 * time.Minute
+)
+
+// decommission deletes the downstream resources selected by spec.decommission. generic.ErrSkip is returned
+// until these are gone, or the timeout is reached, so the finalizer is kept.
+func (h *handler) decommission(cluster *v1.Cluster) (*v1.Cluster, error) {
+	if v1.ClusterConditionDecommissioned.IsTrue(cluster) || v1.ClusterConditionDecommissioned.IsFalse(cluster) {
+		return cluster, nil
+	}
+
+	timeout := defaultDecommissionTimeout
+	if cluster.Spec.Decommission.TimeoutSeconds > 0 {
+		timeout = time.Duration(cluster.Spec.Decommission.TimeoutSeconds) * time.Second
+	}
+	if time.Since(cluster.DeletionTimestamp.Time) > h.deletionGracePeriod+timeout {
+		return h.setDecommissioned(cluster, false, fmt.Sprintf("timed out after %s, %s", timeout,
+			v1.ClusterConditionDecommissioned.GetMessage(cluster)))
+	}
+
+	if cluster.Status.ClientSecretName == "" {
+		return h.setDecommissioned(cluster, false, "skipped, no kubeconfig was generated for the cluster")
+	}
+
+	secret, err := h.secretCache.Get(cluster.Namespace, cluster.Status.ClientSecretName)
+	if apierror.IsNotFound(err) {
+		return h.setDecommissioned(cluster, false, "skipped, kubeconfig secret not found")
+	} else if err != nil {
+		return cluster, err
+	}
+
+	cfg, err := clientcmd.RESTConfigFromKubeConfig(secret.Data["value"])
+	if err != nil {
+		return cluster, err
+	}
+
+	var result decommission.Result
+	err = h.limiter.Do(cluster.Status.ClusterName, func() (err error) {
+		result, err = decommission.Run(cfg, cluster.Spec.Decommission)
+		return err
+	})
+	if err != nil {
+		return cluster, err
+	}
+
+	if result.Done {
+		return h.setDecommissioned(cluster, true, result.Message)
+	}
+
+	if v1.ClusterConditionDecommissioned.GetMessage(cluster) != result.Message {
+		cluster = cluster.DeepCopy()
+		v1.ClusterConditionDecommissioned.Unknown(cluster)
+		v1.ClusterConditionDecommissioned.Message(cluster, result.Message)
+		updated, err := h.clusters.UpdateStatus(cluster)
+		if err != nil {
+			return cluster, err
+		}
+		cluster = updated
+	}
+	h.clusters.EnqueueAfter(cluster.Namespace, cluster.Name, decommissionPollInterval)
+	return cluster, generic.ErrSkip
+}
+
+func (h *handler) setDecommissioned(cluster *v1.Cluster, done bool, message string) (*v1.Cluster, error) {
+	cluster = cluster.DeepCopy()
+	if done {
+		v1.ClusterConditionDecommissioned.True(cluster)
+	} else {
+		v1.ClusterConditionDecommissioned.False(cluster)
+	}
+	v1.ClusterConditionDecommissioned.Message(cluster, message)
+	updated, err := h.clusters.UpdateStatus(cluster)
+	if err != nil {
+		return cluster, err
+	}
+	return updated, nil
+}
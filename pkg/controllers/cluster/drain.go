@@ -0,0 +1,89 @@
+package cluster
+
+import (
+	"encoding/json"
+	"reflect"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	rketypes "github.com/rancher/rke/types"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func drainInput(opts *v1.DrainOptions) *rketypes.NodeDrainInput {
+	return &rketypes.NodeDrainInput{
+		Force:            opts.Force,
+		IgnoreDaemonSets: opts.IgnoreDaemonSets,
+		DeleteLocalData:  opts.DeleteLocalData,
+		GracePeriod:      opts.GracePeriodSeconds,
+		Timeout:          opts.TimeoutSeconds,
+	}
+}
+
+// drainPatch sets drainBeforeDelete of a management node pool along with the annotation marking it as set by the
+// operator. The field is newer than the management.cattle.io types the operator is built with, so it is patched
+// and the annotation tells whether it is set.
+func drainPatch(drain bool) ([]byte, error) {
+	var managed interface{}
+	if drain {
+		managed = "true"
+	}
+	return json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{
+				v1.DrainManagedAnnotation: managed,
+			},
+		},
+		"spec": map[string]interface{}{
+			"drainBeforeDelete": drain,
+		},
+	})
+}
+
+// onDrainBeforeDelete enables drainBeforeDelete on the node pools of the management cluster and sets the drain
+// input of its nodes, which Rancher uses when a node is deleted
+func (h *handler) onDrainBeforeDelete(key string, cluster *v1.Cluster) (*v1.Cluster, error) {
+	if cluster == nil || cluster.Status.ClusterName == "" || cluster.DeletionTimestamp != nil {
+		return cluster, nil
+	}
+	drain := cluster.Spec.DrainBeforeDelete
+
+	pools, err := h.nodePoolCache.List(cluster.Status.ClusterName, labels.Everything())
+	if err != nil {
+		return cluster, err
+	}
+	for _, pool := range pools {
+		if _, managed := pool.Annotations[v1.DrainManagedAnnotation]; managed == (drain != nil) {
+			continue
+		}
+		patch, err := drainPatch(drain != nil)
+		if err != nil {
+			return cluster, err
+		}
+		if _, err := h.nodePools.Patch(pool.Namespace, pool.Name, types.MergePatchType, patch); err != nil {
+			return cluster, err
+		}
+	}
+
+	if drain == nil {
+		return cluster, nil
+	}
+
+	input := drainInput(drain)
+	nodes, err := h.nodeCache.List(cluster.Status.ClusterName, labels.Everything())
+	if err != nil {
+		return cluster, err
+	}
+	for _, node := range nodes {
+		if node.Spec.NodePoolName == "" || reflect.DeepEqual(node.Spec.NodeDrainInput, input) {
+			continue
+		}
+		node = node.DeepCopy()
+		node.Spec.NodeDrainInput = input
+		if _, err := h.nodes.Update(node); err != nil {
+			return cluster, err
+		}
+	}
+
+	return cluster, nil
+}
@@ -0,0 +1,151 @@
+package cluster
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/rancher/norman/types/convert"
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/kubeconfig"
+	"github.com/rancher/wrangler/pkg/name"
+	"github.com/rancher/wrangler/pkg/yaml"
+	corev1 "k8s.io/api/core/v1"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Setting this annotation to "true" stops applying changes to the v3 cluster and previews them in the
+// <cluster>-dry-run config map instead
+const dryRunAnnotation = "rancher.cattle.io/dry-run"
+
+func dryRun(cluster *v1.Cluster) bool {
+	return cluster.Annotations[dryRunAnnotation] == "true"
+}
+
+func (h *handler) onDryRun(key string, cluster *v1.Cluster) (*v1.Cluster, error) {
+	if cluster == nil || cluster.DeletionTimestamp != nil || !dryRun(cluster) {
+		return cluster, nil
+	}
+
+	merged, err := h.withClusterGroups(cluster)
+	if err != nil {
+		return cluster, err
+	}
+	merged, err = h.withAPIServerFiles(merged)
+	if err != nil {
+		return cluster, err
+	}
+
+	spec, ok := rancherClusterSpec(merged)
+	if !ok || cluster.Spec.ImportedConfig != nil {
+		return cluster, nil
+	}
+
+	// Rendered without decrypting, the preview never holds plaintext credentials
+	spec.FleetWorkspaceName = h.fleetWorkspaceName(cluster)
+	newCluster, data, err := renderCluster(merged, h.rancherClusterNameOrDefault(cluster), spec)
+	if err != nil {
+		return cluster, err
+	}
+	rendered, err := yaml.ToBytes([]runtime.Object{&unstructured.Unstructured{Object: data}})
+	if err != nil {
+		return cluster, err
+	}
+
+	changed, err := h.changedFields(newCluster.Name, data)
+	if err != nil {
+		return cluster, err
+	}
+
+	namespaces, _, err := h.kubeConfigSecretNamespaces(cluster)
+	if err != nil {
+		return cluster, err
+	}
+	secrets := []string{cluster.Namespace + "/" + kubeconfig.GetKubeConfigSecretName(cluster)}
+	for _, namespace := range namespaces {
+		secrets = append(secrets, namespace+"/"+kubeconfig.GetKubeConfigSecretName(cluster))
+	}
+	if spec.FleetWorkspaceName != cluster.Namespace {
+		secrets = append(secrets, spec.FleetWorkspaceName+"/"+kubeconfig.GetKubeConfigSecretName(cluster))
+	}
+
+	hash := newCluster.Annotations[specHashAnnotation]
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name.SafeConcatName(cluster.Name, "dry-run"),
+			Namespace: cluster.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(cluster, v1.SchemeGroupVersion.WithKind("Cluster")),
+			},
+		},
+		Data: map[string]string{
+			"specHash":                 hash,
+			"rendered-v3-cluster.yaml": string(rendered),
+			"changed-fields":           strings.Join(changed, "\n"),
+			"kubeconfig-secrets":       strings.Join(secrets, "\n"),
+		},
+	}
+
+	existing, err := h.configMaps.Get(configMap.Namespace, configMap.Name, metav1.GetOptions{})
+	if apierror.IsNotFound(err) {
+		_, err = h.configMaps.Create(configMap)
+	} else if err == nil && !reflect.DeepEqual(existing.Data, configMap.Data) {
+		existing = existing.DeepCopy()
+		existing.Data = configMap.Data
+		_, err = h.configMaps.Update(existing)
+	} else if err == nil {
+		// unchanged preview
+		return cluster, nil
+	}
+	if err != nil {
+		return cluster, err
+	}
+
+	h.recorder.Eventf(cluster, corev1.EventTypeNormal, "DryRun",
+		"Rendered management cluster %s spec %s with %d changed field(s), not applied, see config map %s",
+		newCluster.Name, hash, len(changed), configMap.Name)
+	return cluster, nil
+}
+
+// changedFields lists the spec paths that differ between the rendered and the live v3 cluster, values are left
+// out since the live cluster holds decrypted credentials
+func (h *handler) changedFields(clusterName string, data map[string]interface{}) ([]string, error) {
+	live, err := h.rclusterCache.Get(clusterName)
+	if apierror.IsNotFound(err) {
+		return []string{"spec (created)"}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	liveData, err := convert.EncodeToMap(live)
+	if err != nil {
+		return nil, err
+	}
+
+	renderedSpec, _ := data["spec"].(map[string]interface{})
+	liveSpec, _ := liveData["spec"].(map[string]interface{})
+	var changed []string
+	diff("spec", renderedSpec, liveSpec, &changed)
+	sort.Strings(changed)
+	return changed, nil
+}
+
+func diff(path string, rendered, live interface{}, changed *[]string) {
+	renderedMap, ok := rendered.(map[string]interface{})
+	liveMap, liveOK := live.(map[string]interface{})
+	if !ok || !liveOK {
+		if !reflect.DeepEqual(rendered, live) {
+			*changed = append(*changed, path)
+		}
+		return
+	}
+
+	// only fields set by the operator are compared, Rancher defaults the rest
+	for key, value := range renderedMap {
+		diff(fmt.Sprintf("%s.%s", path, key), value, liveMap[key], changed)
+	}
+}
@@ -0,0 +1,63 @@
+package cluster
+
+import (
+	"fmt"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	rketypes "github.com/rancher/rke/types"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// withEtcdSnapshotSchedule maps spec.etcdSnapshotSchedule to the etcd backup config of RKE, the S3 credentials are
+// only added by etcdSnapshotCredentials so they are not part of dry runs and support bundles
+func withEtcdSnapshotSchedule(spec v3.ClusterSpec, schedule *v1.EtcdSnapshotSchedule) v3.ClusterSpec {
+	if schedule == nil || spec.RancherKubernetesEngineConfig == nil {
+		return spec
+	}
+
+	spec.RancherKubernetesEngineConfig = spec.RancherKubernetesEngineConfig.DeepCopy()
+	enabled := true
+	backup := &rketypes.BackupConfig{
+		Enabled:       &enabled,
+		IntervalHours: schedule.IntervalHours,
+		Retention:     schedule.Retention,
+	}
+	if existing := spec.RancherKubernetesEngineConfig.Services.Etcd.BackupConfig; existing != nil {
+		backup.SafeTimestamp = existing.SafeTimestamp
+		backup.Timeout = existing.Timeout
+	}
+	if s3 := schedule.S3; s3 != nil {
+		backup.S3BackupConfig = &rketypes.S3BackupConfig{
+			BucketName: s3.BucketName,
+			Region:     s3.Region,
+			Endpoint:   s3.Endpoint,
+			Folder:     s3.Folder,
+		}
+	}
+	spec.RancherKubernetesEngineConfig.Services.Etcd.BackupConfig = backup
+	return spec
+}
+
+// etcdSnapshotCredentials reads the S3 access and secret key of spec.etcdSnapshotSchedule into the backup config
+func (h *handler) etcdSnapshotCredentials(cluster *v1.Cluster, spec v3.ClusterSpec) (v3.ClusterSpec, error) {
+	schedule := cluster.Spec.EtcdSnapshotSchedule
+	if schedule == nil || schedule.S3 == nil || spec.RancherKubernetesEngineConfig == nil ||
+		spec.RancherKubernetesEngineConfig.Services.Etcd.BackupConfig == nil {
+		return spec, nil
+	}
+
+	secret, err := h.secrets.Get(cluster.Namespace, schedule.S3.CredentialsSecretName, metav1.GetOptions{})
+	if apierror.IsNotFound(err) {
+		return spec, fmt.Errorf("waiting for etcd snapshot credentials secret %s/%s", cluster.Namespace, schedule.S3.CredentialsSecretName)
+	} else if err != nil {
+		return spec, err
+	}
+
+	spec.RancherKubernetesEngineConfig = spec.RancherKubernetesEngineConfig.DeepCopy()
+	s3 := spec.RancherKubernetesEngineConfig.Services.Etcd.BackupConfig.S3BackupConfig
+	s3.AccessKey = string(secret.Data["accessKey"])
+	s3.SecretKey = string(secret.Data["secretKey"])
+	return spec, nil
+}
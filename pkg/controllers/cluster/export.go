@@ -0,0 +1,136 @@
+package cluster
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/wrangler/pkg/relatedresource"
+	"github.com/rancher/wrangler/pkg/yaml"
+	corev1 "k8s.io/api/core/v1"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// onExport renders the management cluster like a dry run, so it holds no plaintext credentials, and writes it
+// to the target of the export. The target is owned by the export.
+func (h *handler) onExport(export *v1.ClusterExport, status v1.ClusterExportStatus) (v1.ClusterExportStatus, error) {
+	status.ObservedGeneration = export.Generation
+	if status.ExportedAt != "" {
+		return status, nil
+	}
+
+	target := export.Spec.Target
+	if target == "" {
+		target = v1.ClusterExportTargetConfigMap
+	}
+	if target != v1.ClusterExportTargetConfigMap && target != v1.ClusterExportTargetSecret {
+		return notExported(status, fmt.Sprintf("spec.target %q must be ConfigMap or Secret", export.Spec.Target)), nil
+	}
+
+	cluster, err := h.clusters.Cache().Get(export.Namespace, export.Spec.ClusterName)
+	if apierror.IsNotFound(err) {
+		return notExported(status, fmt.Sprintf("waiting for cluster %s", export.Spec.ClusterName)), nil
+	} else if err != nil {
+		return status, err
+	}
+
+	merged, err := h.withClusterGroups(cluster)
+	if err != nil {
+		return status, err
+	}
+	merged, err = h.withAPIServerFiles(merged)
+	if err != nil {
+		return status, err
+	}
+	spec, ok := rancherClusterSpec(merged)
+	if !ok {
+		return notExported(status, fmt.Sprintf("cluster %s has no management cluster generated by the operator", cluster.Name)), nil
+	}
+	spec.FleetWorkspaceName = h.fleetWorkspaceName(cluster)
+	newCluster, data, err := renderCluster(merged, h.rancherClusterNameOrDefault(cluster), spec)
+	if err != nil {
+		return status, err
+	}
+	rendered, err := yaml.ToBytes([]runtime.Object{&unstructured.Unstructured{Object: data}})
+	if err != nil {
+		return status, err
+	}
+
+	meta := metav1.ObjectMeta{
+		Name:      export.Name,
+		Namespace: export.Namespace,
+		OwnerReferences: []metav1.OwnerReference{
+			*metav1.NewControllerRef(export, v1.SchemeGroupVersion.WithKind("ClusterExport")),
+		},
+	}
+	values := map[string]string{
+		"cluster":                  cluster.Name,
+		"clusterGeneration":        strconv.FormatInt(cluster.Generation, 10),
+		"specHash":                 newCluster.Annotations[specHashAnnotation],
+		"rendered-v3-cluster.yaml": string(rendered),
+	}
+	var existing metav1.Object
+	if target == v1.ClusterExportTargetSecret {
+		_, err = h.secrets.Create(&corev1.Secret{
+			ObjectMeta: meta,
+			StringData: values,
+		})
+		if apierror.IsAlreadyExists(err) {
+			existing, err = h.secrets.Get(export.Namespace, export.Name, metav1.GetOptions{})
+		}
+	} else {
+		_, err = h.configMaps.Create(&corev1.ConfigMap{
+			ObjectMeta: meta,
+			Data:       values,
+		})
+		if apierror.IsAlreadyExists(err) {
+			existing, err = h.configMaps.Get(export.Namespace, export.Name, metav1.GetOptions{})
+		}
+	}
+	if err != nil {
+		return status, err
+	}
+	// an existing target of this export was written by an earlier attempt whose status update failed
+	if existing != nil && !metav1.IsControlledBy(existing, export) {
+		return notExported(status, fmt.Sprintf("%s %s already exists", target, export.Name)), nil
+	}
+
+	status.ClusterGeneration = cluster.Generation
+	status.SpecHash = newCluster.Annotations[specHashAnnotation]
+	status.ExportedAt = time.Now().UTC().Format(time.RFC3339)
+	v1.ClusterExportConditionExported.True(&status)
+	v1.ClusterExportConditionExported.Message(&status, "")
+	return status, nil
+}
+
+func notExported(status v1.ClusterExportStatus, message string) v1.ClusterExportStatus {
+	v1.ClusterExportConditionExported.False(&status)
+	v1.ClusterExportConditionExported.Message(&status, message)
+	return status
+}
+
+// clusterExports requeues the pending exports of a cluster once it exists
+func (h *handler) clusterExports(namespace, name string, obj runtime.Object) ([]relatedresource.Key, error) {
+	if _, ok := obj.(*v1.Cluster); !ok {
+		return nil, nil
+	}
+	exports, err := h.exportCache.GetByIndex(byCluster, namespace+"/"+name)
+	if err != nil {
+		return nil, err
+	}
+	var keys []relatedresource.Key
+	for _, export := range exports {
+		if export.Status.ExportedAt != "" {
+			continue
+		}
+		keys = append(keys, relatedresource.Key{
+			Namespace: export.Namespace,
+			Name:      export.Name,
+		})
+	}
+	return keys, nil
+}
@@ -0,0 +1,224 @@
+package cluster
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	rocontrollers "github.com/rancher/rancher-operator/pkg/generated/controllers/rancher.cattle.io/v1"
+	"github.com/rancher/wrangler/pkg/generic"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	failureReasonInvalidConfiguration = "InvalidConfiguration"
+	failureReasonUnsupportedVersion   = "UnsupportedVersion"
+	failureReasonClaimConflict        = "ClaimConflict"
+	failureReasonForbidden            = "Forbidden"
+	failureReasonAdmissionDenied      = "AdmissionDenied"
+	failureReasonQuotaExceeded        = "QuotaExceeded"
+	failureReasonAlreadyExists        = "AlreadyExists"
+	failureReasonApplyFailed          = "ApplyFailed"
+	failureReasonReconcileError       = "ReconcileError"
+
+	retryClassConflict  = "conflict"
+	retryClassQuota     = "quota"
+	retryClassTransient = "transient"
+	retryClassTerminal  = "terminal"
+
+	// legacyCreatedCondition was set by the generating handler before it was renamed to Reconciled
+	legacyCreatedCondition = "Created"
+)
+
+// failure is an error with a known status.failureReason
+type failure struct {
+	reason string
+	err    error
+}
+
+func newFailure(reason string, err error) error {
+	return &failure{
+		reason: reason,
+		err:    err,
+	}
+}
+
+func (f *failure) Error() string {
+	return f.err.Error()
+}
+
+func (f *failure) Unwrap() error {
+	return f.err
+}
+
+// retryStrategy is the backoff of a class of errors. Terminal errors mark the cluster Failed, they are only retried
+// rarely in case something other than the cluster, like the permissions of the operator, was fixed.
+type retryStrategy struct {
+	base     time.Duration
+	max      time.Duration
+	terminal bool
+}
+
+var retryStrategies = map[string]retryStrategy{
+	retryClassConflict:  {base: time.Second, max: time.Minute},
+	retryClassQuota:     {base: time.Minute, max: 15 * time.Minute},
+	retryClassTransient: {base: 5 * time.Second, max: 5 * time.Minute},
+	retryClassTerminal:  {base: 30 * time.Minute, max: 30 * time.Minute, terminal: true},
+}
+
+func (s retryStrategy) delay(attempts int) time.Duration {
+	delay := s.base
+	for i := 1; i < attempts && delay < s.max; i++ {
+		delay *= 2
+	}
+	if delay > s.max {
+		return s.max
+	}
+	return delay
+}
+
+// failureRecord is the last failed reconcile of a cluster generation
+type failureRecord struct {
+	err        error
+	reason     string
+	class      string
+	generation int64
+	attempts   int
+	retryAt    time.Time
+}
+
+func failureReason(err error) string {
+	var f *failure
+	switch {
+	case errors.As(err, &f):
+		return f.reason
+	case strings.Contains(err.Error(), "denied the request"):
+		return failureReasonAdmissionDenied
+	case apierror.IsForbidden(err) && strings.Contains(err.Error(), "exceeded quota"):
+		return failureReasonQuotaExceeded
+	case apierror.IsForbidden(err):
+		return failureReasonForbidden
+	case apierror.IsAlreadyExists(err):
+		return failureReasonAlreadyExists
+	case apierror.IsInvalid(err):
+		return failureReasonInvalidConfiguration
+	}
+	return failureReasonReconcileError
+}
+
+// retryClass picks how a failed reconcile is retried. Conflicts with other writers and exceeded quotas resolve
+// on their own, errors of the spec or the permissions of the operator need a change first.
+func retryClass(err error) string {
+	switch failureReason(err) {
+	case failureReasonInvalidConfiguration, failureReasonUnsupportedVersion, failureReasonForbidden, failureReasonAdmissionDenied:
+		return retryClassTerminal
+	case failureReasonQuotaExceeded:
+		return retryClassQuota
+	case failureReasonClaimConflict, failureReasonAlreadyExists:
+		return retryClassConflict
+	}
+	if apierror.IsConflict(err) {
+		return retryClassConflict
+	}
+	return retryClassTransient
+}
+
+// recordFailure remembers the reason handler failed with, wrangler reverts the status returned with an error and
+// only keeps the message in the Reconciled condition. Until the retry strategy of the error allows the next
+// attempt the recorded error is returned without calling handler, so requeues of the workqueue are cheap. A new
+// generation of the cluster is tried right away.
+func (h *handler) recordFailure(handler rocontrollers.ClusterGeneratingHandler) rocontrollers.ClusterGeneratingHandler {
+	return func(cluster *v1.Cluster, status v1.ClusterStatus) ([]runtime.Object, v1.ClusterStatus, error) {
+		key := cluster.Namespace + "/" + cluster.Name
+		// the count in the status survives restarts, a new generation starts over
+		attempts := cluster.Status.RetryCount
+		if recorded, ok := h.failures.Load(key); ok {
+			last := recorded.(failureRecord)
+			attempts = 0
+			if last.generation == cluster.Generation {
+				// the retry was enqueued when the error was recorded
+				if time.Now().Before(last.retryAt) {
+					return nil, status, last.err
+				}
+				attempts = last.attempts
+			}
+		}
+
+		objs, status, err := handler(cluster, status)
+		if err == nil || err == generic.ErrSkip {
+			h.failures.Delete(key)
+			return objs, status, err
+		}
+
+		record := failureRecord{
+			err:        err,
+			reason:     failureReason(err),
+			class:      retryClass(err),
+			generation: cluster.Generation,
+			attempts:   attempts + 1,
+		}
+		delay := retryStrategies[record.class].delay(record.attempts)
+		record.retryAt = time.Now().Add(delay)
+		h.clusters.EnqueueAfter(cluster.Namespace, cluster.Name, delay)
+		h.failures.Store(key, record)
+		return objs, status, err
+	}
+}
+
+// onFailure mirrors the Reconciled condition into status.failureReason, status.failureMessage and
+// status.retryCount. The phase is Failed for errors that are not retried and for failed applies.
+func (h *handler) onFailure(key string, cluster *v1.Cluster) (*v1.Cluster, error) {
+	if cluster == nil {
+		return cluster, nil
+	}
+
+	var reason, message string
+	var retryCount int
+	phase := cluster.Status.Phase
+	if v1.ClusterConditionReconciled.IsFalse(cluster) {
+		phase = v1.ClusterPhaseFailed
+		// the handler succeeded, so applying its objects failed
+		reason = failureReasonApplyFailed
+		if recorded, ok := h.failures.Load(key); ok {
+			record := recorded.(failureRecord)
+			reason = record.reason
+			retryCount = record.attempts
+			if !retryStrategies[record.class].terminal {
+				phase = retryingPhase(cluster)
+			}
+		}
+		message = v1.ClusterConditionReconciled.GetMessage(cluster)
+	}
+
+	legacy := -1
+	for i, cond := range cluster.Status.Conditions {
+		if cond.Type == legacyCreatedCondition {
+			legacy = i
+		}
+	}
+
+	if cluster.Status.FailureReason == reason && cluster.Status.FailureMessage == message &&
+		cluster.Status.RetryCount == retryCount && cluster.Status.Phase == phase && legacy < 0 {
+		return cluster, nil
+	}
+
+	cluster = cluster.DeepCopy()
+	cluster.Status.FailureReason = reason
+	cluster.Status.FailureMessage = message
+	cluster.Status.RetryCount = retryCount
+	cluster.Status.Phase = phase
+	if legacy >= 0 {
+		cluster.Status.Conditions = append(cluster.Status.Conditions[:legacy], cluster.Status.Conditions[legacy+1:]...)
+	}
+	return h.clusters.UpdateStatus(cluster)
+}
+
+// retryingPhase keeps the phase of a cluster whose reconcile is retried, unless an earlier error marked it Failed
+func retryingPhase(cluster *v1.Cluster) string {
+	if cluster.Status.Phase != v1.ClusterPhaseFailed {
+		return cluster.Status.Phase
+	}
+	return phase(cluster, cluster.Status, cluster.Status.ClusterName != "")
+}
@@ -0,0 +1,100 @@
+package cluster
+
+import (
+	"context"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/condition"
+	rocontrollers "github.com/rancher/rancher-operator/pkg/generated/controllers/rancher.cattle.io/v1"
+	"github.com/rancher/wrangler/pkg/apply"
+	"github.com/rancher/wrangler/pkg/generic"
+	"github.com/rancher/wrangler/pkg/kv"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+// registerGeneratingHandler is rocontrollers.RegisterClusterGeneratingHandler for the metav1.Condition conditions of
+// the cluster status, the generated one can only set wrangler's genericcondition.GenericCondition
+func registerGeneratingHandler(ctx context.Context, controller rocontrollers.ClusterController, apply apply.Apply,
+	cond condition.Cond, name string, handler rocontrollers.ClusterGeneratingHandler, opts *generic.GeneratingHandlerOptions) {
+	h := &generatingHandler{
+		client:    controller,
+		apply:     apply,
+		condition: cond,
+		name:      name,
+		handler:   handler,
+	}
+	if opts != nil {
+		h.opts = *opts
+	}
+	controller.OnChange(ctx, name, h.remove)
+	controller.OnChange(ctx, name, h.sync)
+}
+
+type generatingHandler struct {
+	client    rocontrollers.ClusterClient
+	apply     apply.Apply
+	opts      generic.GeneratingHandlerOptions
+	condition condition.Cond
+	name      string
+	handler   rocontrollers.ClusterGeneratingHandler
+}
+
+func (h *generatingHandler) remove(key string, obj *v1.Cluster) (*v1.Cluster, error) {
+	if obj != nil {
+		return obj, nil
+	}
+
+	obj = &v1.Cluster{}
+	obj.Namespace, obj.Name = kv.RSplit(key, "/")
+	obj.SetGroupVersionKind(v1.SchemeGroupVersion.WithKind("Cluster"))
+
+	return nil, generic.ConfigureApplyForObject(h.apply, obj, &h.opts).
+		WithOwner(obj).
+		WithSetID(h.name).
+		ApplyObjects()
+}
+
+func (h *generatingHandler) sync(key string, obj *v1.Cluster) (*v1.Cluster, error) {
+	if obj == nil {
+		return obj, nil
+	}
+
+	origStatus := obj.Status.DeepCopy()
+	obj = obj.DeepCopy()
+	newStatus, err := h.handle(obj, obj.Status)
+	if err != nil {
+		// Revert to old status on error
+		newStatus = *origStatus.DeepCopy()
+	}
+
+	if errors.IsConflict(err) {
+		h.condition.SetError(&newStatus, "", nil)
+	} else {
+		h.condition.SetError(&newStatus, "", err)
+	}
+	if !equality.Semantic.DeepEqual(origStatus, &newStatus) {
+		var newErr error
+		obj.Status = newStatus
+		newObj, newErr := h.client.UpdateStatus(obj)
+		if err == nil {
+			err = newErr
+		}
+		if newErr == nil {
+			obj = newObj
+		}
+	}
+	return obj, err
+}
+
+func (h *generatingHandler) handle(obj *v1.Cluster, status v1.ClusterStatus) (v1.ClusterStatus, error) {
+	objs, newStatus, err := h.handler(obj, status)
+	if err != nil {
+		return newStatus, err
+	}
+
+	return newStatus, generic.ConfigureApplyForObject(h.apply, obj, &h.opts).
+		WithOwner(obj).
+		WithSetID(h.name).
+		ApplyObjects(objs...)
+}
@@ -0,0 +1,98 @@
+package cluster
+
+import (
+	"sort"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/wrangler/pkg/relatedresource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// clusterGroups returns the groups cluster is a member of in name order
+func (h *handler) clusterGroups(cluster *v1.Cluster) ([]*v1.ClusterGroup, error) {
+	groups, err := h.clusterGroupCache.List(cluster.Namespace, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Name < groups[j].Name
+	})
+
+	var result []*v1.ClusterGroup
+	for _, group := range groups {
+		if group.Spec.Selector == nil {
+			continue
+		}
+		sel, err := metav1.LabelSelectorAsSelector(group.Spec.Selector)
+		if err != nil {
+			return nil, err
+		}
+		if sel.Matches(labels.Set(cluster.Labels)) {
+			result = append(result, group)
+		}
+	}
+	return result, nil
+}
+
+// withClusterGroups returns a copy of cluster with the settings of its groups merged into the spec, the settings
+// of the cluster are kept
+func (h *handler) withClusterGroups(cluster *v1.Cluster) (*v1.Cluster, error) {
+	groups, err := h.clusterGroups(cluster)
+	if err != nil || len(groups) == 0 {
+		return cluster, err
+	}
+
+	cluster = cluster.DeepCopy()
+	for _, group := range groups {
+		versions := group.Spec.KubernetesVersions
+		if rke := cluster.Spec.RancherKubernetesEngineConfig; rke != nil && rke.Version == "" {
+			rke.Version = versions.RKE
+		}
+		if k3s := cluster.Spec.K3SConfig; k3s != nil && k3s.Version == "" {
+			k3s.Version = versions.K3s
+		}
+		if rke2 := cluster.Spec.RKE2Config; rke2 != nil && rke2.Version == "" {
+			rke2.Version = versions.RKE2
+		}
+
+		for _, env := range group.Spec.AgentEnvVars {
+			if !hasEnvVar(cluster, env.Name) {
+				cluster.Spec.AgentEnvVars = append(cluster.Spec.AgentEnvVars, env)
+			}
+		}
+	}
+	return cluster, nil
+}
+
+func hasEnvVar(cluster *v1.Cluster, name string) bool {
+	for _, env := range cluster.Spec.AgentEnvVars {
+		if env.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// groupClusters returns every cluster in the namespace of a changed group, members that no longer match are
+// rendered again as well
+func (h *handler) groupClusters(namespace, name string, obj runtime.Object) ([]relatedresource.Key, error) {
+	if _, ok := obj.(*v1.ClusterGroup); !ok && obj != nil {
+		return nil, nil
+	}
+
+	clusters, err := h.clusters.Cache().List(namespace, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []relatedresource.Key
+	for _, cluster := range clusters {
+		keys = append(keys, relatedresource.Key{
+			Namespace: cluster.Namespace,
+			Name:      cluster.Name,
+		})
+	}
+	return keys, nil
+}
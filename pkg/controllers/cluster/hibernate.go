@@ -0,0 +1,109 @@
+package cluster
+
+import (
+	"strconv"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/wrangler/pkg/relatedresource"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// withHibernation scales the EKS node groups of a hibernated cluster to zero, the sizes in the cluster spec are
+// applied again once it wakes up
+func withHibernation(spec v3.ClusterSpec, hibernate bool) v3.ClusterSpec {
+	if !hibernate || spec.EKSConfig == nil {
+		return spec
+	}
+
+	spec.EKSConfig = spec.EKSConfig.DeepCopy()
+	var zero int64
+	for i := range spec.EKSConfig.NodeGroups {
+		spec.EKSConfig.NodeGroups[i].DesiredSize = &zero
+		spec.EKSConfig.NodeGroups[i].MinSize = &zero
+	}
+	return spec
+}
+
+func workerPool(pool *v3.NodePool) bool {
+	return pool.Spec.Worker && !pool.Spec.ControlPlane && !pool.Spec.Etcd
+}
+
+// onHibernate scales the worker node pools of an RKE cluster to zero and back, the counts are kept in an
+// annotation on each pool so pools added while hibernated are scaled down too
+func (h *handler) onHibernate(key string, cluster *v1.Cluster) (*v1.Cluster, error) {
+	if cluster == nil || cluster.Status.ClusterName == "" {
+		return cluster, nil
+	}
+
+	pools, err := h.nodePoolCache.List(cluster.Status.ClusterName, labels.Everything())
+	if err != nil {
+		return cluster, err
+	}
+
+	workers := 0
+	for _, pool := range pools {
+		if !workerPool(pool) {
+			continue
+		}
+		workers++
+
+		saved, hibernated := pool.Annotations[v1.HibernateQuantityAnnotation]
+		switch {
+		case cluster.Spec.Hibernate && !hibernated:
+			pool = pool.DeepCopy()
+			if pool.Annotations == nil {
+				pool.Annotations = map[string]string{}
+			}
+			pool.Annotations[v1.HibernateQuantityAnnotation] = strconv.Itoa(pool.Spec.Quantity)
+			pool.Spec.Quantity = 0
+		case !cluster.Spec.Hibernate && hibernated:
+			quantity, err := strconv.Atoi(saved)
+			if err != nil {
+				return cluster, err
+			}
+			pool = pool.DeepCopy()
+			delete(pool.Annotations, v1.HibernateQuantityAnnotation)
+			pool.Spec.Quantity = quantity
+		default:
+			continue
+		}
+		if _, err := h.nodePools.Update(pool); err != nil {
+			return cluster, err
+		}
+	}
+
+	hibernated, message := "False", ""
+	switch {
+	case cluster.Spec.Hibernate && cluster.Spec.EKSConfig != nil:
+		hibernated, message = "True", "the node groups are scaled to zero"
+	case cluster.Spec.Hibernate && workers > 0:
+		hibernated, message = "True", "the worker node pools are scaled to zero"
+	case cluster.Spec.Hibernate:
+		message = "only EKS clusters and clusters with worker node pools can hibernate"
+	}
+
+	if v1.ClusterConditionHibernated.GetStatus(cluster) == hibernated && v1.ClusterConditionHibernated.GetMessage(cluster) == message {
+		return cluster, nil
+	}
+	if !cluster.Spec.Hibernate && v1.ClusterConditionHibernated.GetStatus(cluster) == "" {
+		return cluster, nil
+	}
+
+	cluster = cluster.DeepCopy()
+	v1.ClusterConditionHibernated.SetStatus(cluster, hibernated)
+	v1.ClusterConditionHibernated.Message(cluster, message)
+	return h.clusters.UpdateStatus(cluster)
+}
+
+// poolClusters requeues the cluster of a node pool or node, which are in the namespace named after their management
+// cluster
+func (h *handler) poolClusters(namespace, name string, obj runtime.Object) ([]relatedresource.Key, error) {
+	switch obj.(type) {
+	case *v3.NodePool, *v3.Node:
+	default:
+		return nil, nil
+	}
+	return h.clustersOf(namespace)
+}
@@ -0,0 +1,76 @@
+package cluster
+
+import (
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+// maxConditionHistory bounds status.conditionHistory, the oldest transitions are dropped first
+const maxConditionHistory = 50
+
+// onConditionHistory records the condition changes of every handler in status.conditionHistory, it is included in
+// support bundles with the rest of the cluster
+func (h *handler) onConditionHistory(key string, cluster *v1.Cluster) (*v1.Cluster, error) {
+	if cluster == nil {
+		return cluster, nil
+	}
+
+	status := cluster.Status.DeepCopy()
+	recordConditionHistory(status)
+	if equality.Semantic.DeepEqual(&cluster.Status, status) {
+		return cluster, nil
+	}
+
+	cluster = cluster.DeepCopy()
+	cluster.Status = *status
+	return h.clusters.UpdateStatus(cluster)
+}
+
+// recordConditionHistory appends the conditions whose status differs from their last recorded transition. It compares
+// against the history instead of the previous status so no handler has to report its changes.
+func recordConditionHistory(status *v1.ClusterStatus) {
+	last := map[string]v1.ConditionTransition{}
+	for _, transition := range status.ConditionHistory {
+		last[transition.Type] = transition
+	}
+
+	for _, cond := range status.Conditions {
+		if previous, ok := last[cond.Type]; ok && previous.Status == cond.Status {
+			continue
+		}
+		status.ConditionHistory = append(status.ConditionHistory, v1.ConditionTransition{
+			Type:               cond.Type,
+			Status:             cond.Status,
+			Reason:             cond.Reason,
+			Message:            cond.Message,
+			ObservedGeneration: cond.ObservedGeneration,
+			Time:               cond.LastTransitionTime,
+		})
+	}
+
+	status.ConditionHistory = trimConditionHistory(status.ConditionHistory)
+}
+
+// trimConditionHistory drops the oldest transitions beyond maxConditionHistory. The latest transition of each
+// condition is kept, otherwise it would be recorded again on the next call.
+func trimConditionHistory(history []v1.ConditionTransition) []v1.ConditionTransition {
+	extra := len(history) - maxConditionHistory
+	if extra <= 0 {
+		return history
+	}
+
+	latest := map[string]int{}
+	for i, transition := range history {
+		latest[transition.Type] = i
+	}
+
+	result := make([]v1.ConditionTransition, 0, maxConditionHistory)
+	for i, transition := range history {
+		if extra > 0 && latest[transition.Type] != i {
+			extra--
+			continue
+		}
+		result = append(result, transition)
+	}
+	return result
+}
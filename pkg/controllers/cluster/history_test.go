@@ -0,0 +1,53 @@
+package cluster
+
+import (
+	"fmt"
+	"testing"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+)
+
+func TestRecordConditionHistory(t *testing.T) {
+	status := v1.ClusterStatus{}
+	v1.ClusterConditionVerified.False(&status)
+	recordConditionHistory(&status)
+	recordConditionHistory(&status)
+	if len(status.ConditionHistory) != 1 {
+		t.Fatalf("got history %v, want the one transition recorded once", status.ConditionHistory)
+	}
+
+	v1.ClusterConditionVerified.True(&status)
+	recordConditionHistory(&status)
+	if len(status.ConditionHistory) != 2 {
+		t.Fatalf("got history %v, want both transitions", status.ConditionHistory)
+	}
+	if got := status.ConditionHistory[1]; got.Type != "Verified" || got.Status != "True" {
+		t.Errorf("got %+v, want Verified True", got)
+	}
+}
+
+func TestRecordConditionHistoryBounded(t *testing.T) {
+	status := v1.ClusterStatus{}
+	v1.ClusterConditionClientSecretValid.True(&status)
+	recordConditionHistory(&status)
+	for i := 0; i < maxConditionHistory*2; i++ {
+		v1.ClusterConditionVerified.SetStatusBool(&status, i%2 == 0)
+		v1.ClusterConditionVerified.Message(&status, fmt.Sprint(i))
+		recordConditionHistory(&status)
+	}
+	if len(status.ConditionHistory) != maxConditionHistory {
+		t.Fatalf("got %d transitions, want %d", len(status.ConditionHistory), maxConditionHistory)
+	}
+	if got := status.ConditionHistory[0]; got.Type != "ClientSecretValid" {
+		t.Errorf("dropped the only ClientSecretValid transition, oldest is %+v", got)
+	}
+	if got := status.ConditionHistory[maxConditionHistory-1]; got.Message != fmt.Sprint(maxConditionHistory*2-1) {
+		t.Errorf("got latest transition %+v, want the last Verified change", got)
+	}
+
+	// nothing changed, nothing is recorded
+	recordConditionHistory(&status)
+	if len(status.ConditionHistory) != maxConditionHistory || status.ConditionHistory[0].Type != "ClientSecretValid" {
+		t.Errorf("history changed without a transition: %v", status.ConditionHistory[0])
+	}
+}
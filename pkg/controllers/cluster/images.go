@@ -0,0 +1,168 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	mgmtcontrollers "github.com/rancher/rancher-operator/pkg/generated/controllers/management.cattle.io/v3"
+	"github.com/rancher/rancher-operator/pkg/settings"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	rketypes "github.com/rancher/rke/types"
+	"github.com/rancher/wrangler/pkg/name"
+	corev1 "k8s.io/api/core/v1"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Rancher keeps the RKE system images of every supported kubernetes version in a RkeK8sSystemImage named after it
+const systemImagesNamespace = "cattle-global-data"
+
+var rkeSystemImageResource = v3.SchemeGroupVersion.WithResource("rkek8ssystemimages")
+
+// onImages lists the images the cluster needs in the <cluster>-images config map, so an air-gapped registry can
+// be filled before provisioning starts. Like the dry run preview it is written before anything is applied, images
+// of dry run clusters are listed as well.
+func (h *handler) onImages(key string, cluster *v1.Cluster) (*v1.Cluster, error) {
+	if cluster == nil || cluster.DeletionTimestamp != nil || cluster.Spec.ReferencedConfig != nil {
+		return cluster, nil
+	}
+
+	merged, err := h.withClusterGroups(cluster)
+	if err != nil {
+		return cluster, err
+	}
+	images, unknown, err := h.systemImages(merged)
+	if err != nil {
+		return cluster, err
+	}
+
+	data := map[string]string{
+		"images.txt": strings.Join(images, "\n") + "\n",
+	}
+	if unknown != "" {
+		data["unknown"] = unknown
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name.SafeConcatName(cluster.Name, "images"),
+			Namespace: cluster.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(cluster, v1.SchemeGroupVersion.WithKind("Cluster")),
+			},
+		},
+		Data: data,
+	}
+
+	existing, err := h.configMaps.Get(configMap.Namespace, configMap.Name, metav1.GetOptions{})
+	if apierror.IsNotFound(err) {
+		_, err = h.configMaps.Create(configMap)
+	} else if err == nil && !reflect.DeepEqual(existing.Data, configMap.Data) {
+		existing = existing.DeepCopy()
+		existing.Data = configMap.Data
+		_, err = h.configMaps.Update(existing)
+	}
+	return cluster, err
+}
+
+// systemImages returns the sorted images of the cluster and, if some of them can't be listed, why not
+func (h *handler) systemImages(cluster *v1.Cluster) ([]string, string, error) {
+	images := map[string]bool{}
+
+	agentImage := cluster.Spec.AgentImageOverride
+	if agentImage == "" {
+		var err error
+		if agentImage, err = setting(h.settingCache, "agent-image"); err != nil {
+			return nil, "", err
+		}
+	}
+	images[agentImage] = true
+
+	if cluster.Spec.LocalClusterAuthEndpoint.Enabled {
+		authImage, err := setting(h.settingCache, "auth-image")
+		if err != nil {
+			return nil, "", err
+		}
+		images[authImage] = true
+	}
+
+	var unknown string
+	switch {
+	case cluster.Spec.RancherKubernetesEngineConfig != nil:
+		rkeImages, version, err := h.rkeSystemImages(cluster.Spec.RancherKubernetesEngineConfig)
+		if err != nil {
+			return nil, "", err
+		}
+		if rkeImages == nil {
+			unknown = fmt.Sprintf("the system images of kubernetes version %s are unknown to Rancher", version)
+		}
+		for _, image := range rkeImages {
+			images[image] = true
+		}
+	case cluster.Spec.K3SConfig != nil && cluster.Spec.K3SConfig.Version != "":
+		images["rancher/k3s-upgrade:"+strings.ReplaceAll(cluster.Spec.K3SConfig.Version, "+", "-")] = true
+	case cluster.Spec.RKE2Config != nil && cluster.Spec.RKE2Config.Version != "":
+		images["rancher/rke2-upgrade:"+strings.ReplaceAll(cluster.Spec.RKE2Config.Version, "+", "-")] = true
+	}
+
+	var result []string
+	for image := range images {
+		if image != "" {
+			result = append(result, image)
+		}
+	}
+	sort.Strings(result)
+	return result, unknown, nil
+}
+
+// rkeSystemImages returns the system images of the RKE version with the ones set in the config replacing them,
+// nil if Rancher doesn't know the version
+func (h *handler) rkeSystemImages(config *rketypes.RancherKubernetesEngineConfig) ([]string, string, error) {
+	version := config.Version
+	if version == "" {
+		var err error
+		if version, err = setting(h.settingCache, "k8s-version"); err != nil {
+			return nil, "", err
+		}
+	}
+
+	obj, err := h.dynamic.Resource(rkeSystemImageResource).Namespace(systemImagesNamespace).Get(context.TODO(), version, metav1.GetOptions{})
+	if apierror.IsNotFound(err) {
+		return nil, version, nil
+	} else if err != nil {
+		return nil, version, err
+	}
+	var systemImage v3.RkeK8sSystemImage
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &systemImage); err != nil {
+		return nil, version, err
+	}
+
+	var images []string
+	defaults, overrides := reflect.ValueOf(systemImage.SystemImages), reflect.ValueOf(config.SystemImages)
+	for i := 0; i < defaults.NumField(); i++ {
+		if defaults.Field(i).Kind() != reflect.String {
+			continue
+		}
+		image := overrides.Field(i).String()
+		if image == "" {
+			image = defaults.Field(i).String()
+		}
+		if image != "" {
+			images = append(images, image)
+		}
+	}
+	return images, version, nil
+}
+
+// setting returns the value of a Rancher setting, empty if it doesn't exist
+func setting(cache mgmtcontrollers.SettingCache, key string) (string, error) {
+	value, err := settings.Get(cache, key)
+	if apierror.IsNotFound(err) {
+		return "", nil
+	}
+	return value, err
+}
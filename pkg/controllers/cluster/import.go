@@ -5,13 +5,17 @@ import (
 	"crypto/x509"
 	"fmt"
 	"net/http"
+	"sort"
 	"time"
 
 	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/tracing"
 	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
 	"github.com/rancher/wrangler/pkg/apply"
 	"github.com/rancher/wrangler/pkg/generic"
+	"github.com/rancher/wrangler/pkg/name"
 	"github.com/rancher/wrangler/pkg/yaml"
+	corev1 "k8s.io/api/core/v1"
 	apierror "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -25,11 +29,27 @@ func (h *handler) importCluster(cluster *v1.Cluster, status v1.ClusterStatus, sp
 		return nil, status, err
 	}
 
-	if status.AgentDeployed || status.ClusterName == "" {
+	if status.ClusterName == "" {
 		return objs, status, nil
 	}
 
-	ok, err := h.deployAgent(cluster, status)
+	token, err := h.registrationToken(cluster, status)
+	if err != nil || token == nil {
+		return objs, status, err
+	}
+
+	secret, err := h.registrationSecret(cluster, token)
+	if err != nil {
+		return objs, status, err
+	}
+	objs = append(objs, secret)
+	status.RegistrationSecretName = secret.Name
+
+	if status.AgentDeployed {
+		return objs, status, nil
+	}
+
+	ok, err := h.deployAgent(cluster, status, token.Status.Token)
 	if err != nil {
 		return objs, status, err
 	}
@@ -38,22 +58,43 @@ func (h *handler) importCluster(cluster *v1.Cluster, status v1.ClusterStatus, sp
 	return objs, status, nil
 }
 
-func (h *handler) deployAgent(cluster *v1.Cluster, status v1.ClusterStatus) (bool, error) {
+// registrationToken returns the current registration token of the management cluster, nil while none has a value
+// yet. A new token is created once the current one is older than the token TTL and the older ones are deleted
+// once it has a value, so the registration manifest of a cluster stops working after about twice the TTL.
+func (h *handler) registrationToken(cluster *v1.Cluster, status v1.ClusterStatus) (*v3.ClusterRegistrationToken, error) {
 	if _, err := h.rclusterCache.Get(status.ClusterName); apierror.IsNotFound(err) {
 		h.clusters.EnqueueAfter(cluster.Namespace, cluster.Name, 2*time.Second)
 		// wait until the cluster is created
-		return false, nil
+		return nil, nil
 	} else if err != nil {
-		return false, err
+		return nil, err
 	}
 
 	tokens, err := h.clusterTokenCache.List(status.ClusterName, labels.Everything())
 	if err != nil {
-		return false, err
-	}
-
-	if len(tokens) == 0 {
-		_, err := h.clusterTokens.Create(&v3.ClusterRegistrationToken{
+		return nil, err
+	}
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[j].CreationTimestamp.Before(&tokens[i].CreationTimestamp)
+	})
+
+	var current *v3.ClusterRegistrationToken
+	pending := false
+	for _, token := range tokens {
+		if token.Status.Token == "" {
+			pending = true
+			continue
+		}
+		current = token
+		break
+	}
+
+	expired := current != nil && h.tokenTTL > 0 && time.Since(current.CreationTimestamp.Time) >= h.tokenTTL
+	if !pending && (current == nil || expired) {
+		if expired {
+			h.recorder.Eventf(cluster, corev1.EventTypeNormal, "RotatingRegistrationToken", "Registration token %s expired, creating a new one", current.Name)
+		}
+		if _, err := h.clusterTokens.Create(&v3.ClusterRegistrationToken{
 			ObjectMeta: metav1.ObjectMeta{
 				GenerateName: "import-",
 				Namespace:    status.ClusterName,
@@ -61,22 +102,70 @@ func (h *handler) deployAgent(cluster *v1.Cluster, status v1.ClusterStatus) (boo
 			Spec: v3.ClusterRegistrationTokenSpec{
 				ClusterName: status.ClusterName,
 			},
-		})
+		}); err != nil {
+			return nil, err
+		}
+		pending = true
+	}
+	if pending || current == nil {
 		h.clusters.EnqueueAfter(cluster.Namespace, cluster.Name, 2*time.Second)
-		return false, err
+		return current, nil
 	}
 
-	tokenValue := tokens[0].Status.Token
-	if tokenValue == "" {
-		h.clusters.EnqueueAfter(cluster.Namespace, cluster.Name, 2*time.Second)
-		return false, nil
+	for _, token := range tokens {
+		if token == current || !token.CreationTimestamp.Before(&current.CreationTimestamp) {
+			continue
+		}
+		if err := h.clusterTokens.Delete(token.Namespace, token.Name, nil); err != nil && !apierror.IsNotFound(err) {
+			return nil, err
+		}
+	}
+
+	if h.tokenTTL > 0 {
+		h.clusters.EnqueueAfter(cluster.Namespace, cluster.Name, h.tokenTTL-time.Since(current.CreationTimestamp.Time))
+	}
+	return current, nil
+}
+
+// registrationSecret holds the current registration token and the manifest to apply to the imported cluster
+func (h *handler) registrationSecret(cluster *v1.Cluster, token *v3.ClusterRegistrationToken) (*corev1.Secret, error) {
+	serverURL, _, err := h.kubeconfigManager.GetServerURLAndCA()
+	if err != nil {
+		return nil, err
 	}
+	manifestURL := importManifestURL(serverURL, token.Status.Token)
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name.SafeConcatName(cluster.Name, "registration"),
+			Namespace: cluster.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(cluster, v1.SchemeGroupVersion.WithKind("Cluster")),
+			},
+		},
+		Data: map[string][]byte{
+			"token":       []byte(token.Status.Token),
+			"tokenName":   []byte(token.Name),
+			"manifestUrl": []byte(manifestURL),
+			"command":     []byte("kubectl apply -f " + manifestURL),
+		},
+	}, nil
+}
+
+func importManifestURL(serverURL, token string) string {
+	return fmt.Sprintf("%s/v3/import/%s.yaml", serverURL, token)
+}
 
-	return true, h.deploy(cluster, cluster.Namespace, cluster.Spec.ImportedConfig.KubeConfigSecret, tokenValue)
+func (h *handler) deployAgent(cluster *v1.Cluster, status v1.ClusterStatus, token string) (bool, error) {
+	return true, h.limiter.Do(status.ClusterName, func() (err error) {
+		span := tracing.Start(cluster.Namespace, cluster.Name, "agent.deploy")
+		defer func() { tracing.End(span, err) }()
+		return h.deploy(cluster, cluster.Namespace, cluster.Spec.ImportedConfig.KubeConfigSecret, token)
+	})
 }
 
 func (h *handler) deploy(cluster *v1.Cluster, secretNamespace, secretName string, token string) error {
-	secret, err := h.secretCache.Get(secretNamespace, secretName)
+	secret, err := h.secrets.Get(secretNamespace, secretName, metav1.GetOptions{})
 	if apierror.IsNotFound(err) {
 		h.clusters.EnqueueAfter(cluster.Namespace, cluster.Name, 2*time.Second)
 		return generic.ErrSkip
@@ -104,7 +193,7 @@ func (h *handler) deploy(cluster *v1.Cluster, secretNamespace, secretName string
 		return err
 	}
 
-	resp, err := httpClient.Get(fmt.Sprintf("%s/v3/import/%s.yaml", serverURL, token))
+	resp, err := httpClient.Get(importManifestURL(serverURL, token))
 	if err != nil {
 		return err
 	}
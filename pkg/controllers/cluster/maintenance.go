@@ -0,0 +1,155 @@
+package cluster
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// holdChanges applies the last applied management cluster again while spec.maintenanceWindow is closed. Held back
+// changes are reported in the PendingChanges condition and applied once the next window opens.
+func (h *handler) holdChanges(cluster *v1.Cluster, status v1.ClusterStatus, rClusterName string, data map[string]interface{}) (map[string]interface{}, v1.ClusterStatus, error) {
+	if schedule := cluster.Spec.MaintenanceWindow; schedule == nil || len(schedule.Windows) == 0 {
+		return data, noPendingChanges(status), nil
+	}
+
+	existing, err := h.rclusterCache.Get(rClusterName)
+	if apierror.IsNotFound(err) {
+		// new clusters are created right away
+		return data, noPendingChanges(status), nil
+	} else if err != nil {
+		return nil, status, err
+	}
+
+	applied, err := appliedObject(existing)
+	if err != nil || applied == nil {
+		return data, noPendingChanges(status), err
+	}
+
+	changed, err := specChanged(data, applied)
+	if err != nil || !changed {
+		return data, noPendingChanges(status), err
+	}
+
+	next, open, err := nextMaintenanceWindow(cluster.Spec.MaintenanceWindow, time.Now())
+	if err != nil {
+		return nil, status, err
+	}
+	if open {
+		return data, noPendingChanges(status), nil
+	}
+
+	v1.ClusterConditionPendingChanges.True(&status)
+	v1.ClusterConditionPendingChanges.Message(&status, fmt.Sprintf("spec changes are applied in the maintenance window at %s",
+		next.Format(time.RFC3339)))
+	h.clusters.EnqueueAfter(cluster.Namespace, cluster.Name, time.Until(next)+time.Second)
+	return applied, status, nil
+}
+
+// nextMaintenanceWindow returns true if spec.maintenanceWindow is open at now or not set, otherwise the start of
+// the next window. It decides when both spec changes and new kubernetes versions are applied.
+func nextMaintenanceWindow(schedule *v1.MaintenanceSchedule, now time.Time) (time.Time, bool, error) {
+	if schedule == nil || len(schedule.Windows) == 0 {
+		return now, true, nil
+	}
+	location, err := time.LoadLocation(schedule.TimeZone)
+	if err != nil {
+		return now, false, newFailure(failureReasonInvalidConfiguration, fmt.Errorf("invalid spec.maintenanceWindow.timeZone: %w", err))
+	}
+	now = now.In(location)
+
+	var next time.Time
+	for _, window := range schedule.Windows {
+		start, err := time.Parse("15:04", window.Start)
+		if err != nil {
+			continue
+		}
+		days := map[time.Weekday]bool{}
+		for _, day := range window.Days {
+			if d, ok := v1.Weekday(day); ok {
+				days[d] = true
+			}
+		}
+
+		// a window that started yesterday may still be open
+		for offset := -1; offset <= 7; offset++ {
+			day := now.AddDate(0, 0, offset)
+			begin := time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), 0, 0, now.Location())
+			if len(days) > 0 && !days[begin.Weekday()] {
+				continue
+			}
+			end := begin.Add(time.Duration(window.DurationMinutes) * time.Minute)
+			if !now.Before(begin) && now.Before(end) {
+				return now, true, nil
+			}
+			if begin.After(now) && (next.IsZero() || begin.Before(next)) {
+				next = begin
+			}
+		}
+	}
+
+	if next.IsZero() {
+		// only invalid windows, which the webhook rejects
+		return now, true, nil
+	}
+	return next, false, nil
+}
+
+func noPendingChanges(status v1.ClusterStatus) v1.ClusterStatus {
+	if v1.ClusterConditionPendingChanges.GetStatus(&status) != "" {
+		v1.ClusterConditionPendingChanges.False(&status)
+		v1.ClusterConditionPendingChanges.Message(&status, "")
+	}
+	return status
+}
+
+// appliedObject decodes the object apply last applied for the management cluster from its annotation, which is
+// gzipped and base64 encoded once it gets large
+func appliedObject(rCluster *v3.Cluster) (map[string]interface{}, error) {
+	value := rCluster.Annotations[appliedAnnotation]
+	if value == "" {
+		return nil, nil
+	}
+
+	data := []byte(value)
+	if !strings.HasPrefix(value, "{") {
+		compressed, err := base64.RawStdEncoding.DecodeString(value)
+		if err != nil {
+			return nil, err
+		}
+		r, err := gzip.NewReader(bytes.NewReader(compressed))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		if data, err = ioutil.ReadAll(r); err != nil {
+			return nil, err
+		}
+	}
+
+	obj := map[string]interface{}{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return obj, dec.Decode(&obj)
+}
+
+func specChanged(data, applied map[string]interface{}) (bool, error) {
+	desired, err := json.Marshal(data["spec"])
+	if err != nil {
+		return false, err
+	}
+	last, err := json.Marshal(applied["spec"])
+	if err != nil {
+		return false, err
+	}
+	return !bytes.Equal(desired, last), nil
+}
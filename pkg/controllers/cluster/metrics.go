@@ -0,0 +1,106 @@
+package cluster
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/debug"
+	rocontrollers "github.com/rancher/rancher-operator/pkg/generated/controllers/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/logging"
+	"github.com/rancher/rancher-operator/pkg/metrics"
+	"github.com/rancher/rancher-operator/pkg/tracing"
+	"github.com/rancher/wrangler/pkg/generic"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var clusterPhaseDesc = prometheus.NewDesc("rancher_operator_clusters",
+	"Number of clusters by phase", []string{"phase"}, nil)
+
+func instrument(name string, handler rocontrollers.ClusterHandler) rocontrollers.ClusterHandler {
+	return func(key string, cluster *v1.Cluster) (*v1.Cluster, error) {
+		start := time.Now()
+		end := tracing.StartReconcile(name, key)
+		done := debug.Start(name, key)
+		result, err := handler(key, cluster)
+		done()
+		end(reconcileError(err))
+		metrics.ObserveReconcile(name, start, err)
+		logReconcile(logging.ForReconcile(name, key), start, err)
+		return result, err
+	}
+}
+
+func instrumentGenerating(name string, handler rocontrollers.ClusterGeneratingHandler) rocontrollers.ClusterGeneratingHandler {
+	return func(cluster *v1.Cluster, status v1.ClusterStatus) ([]runtime.Object, v1.ClusterStatus, error) {
+		key := cluster.Namespace + "/" + cluster.Name
+		start := time.Now()
+		end := tracing.StartReconcile(name, key)
+		done := debug.Start(name, key)
+		objs, status, err := handler(cluster, status)
+		done()
+		end(reconcileError(err))
+		metrics.ObserveReconcile(name, start, err)
+		logReconcile(logging.ForReconcile(name, key), start, err)
+		return objs, status, err
+	}
+}
+
+// reconcileError drops ErrSkip, it only tells wrangler to keep the object as is and is not a failure
+func reconcileError(err error) error {
+	if err == generic.ErrSkip {
+		return nil
+	}
+	return err
+}
+
+func logReconcile(log *logrus.Entry, start time.Time, err error) {
+	log = log.WithField("duration", time.Since(start).String())
+	if reconcileError(err) != nil {
+		log.WithError(err).Warn("reconcile failed")
+	} else {
+		log.Debug("reconciled")
+	}
+}
+
+// phaseCollector counts the clusters in the cache by phase when metrics are scraped
+type phaseCollector struct {
+	clusterCache rocontrollers.ClusterCache
+}
+
+func (p phaseCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- clusterPhaseDesc
+}
+
+func (p phaseCollector) Collect(ch chan<- prometheus.Metric) {
+	clusters, err := p.clusterCache.List("", labels.Everything())
+	if err != nil {
+		logrus.Errorf("failed to list clusters for metrics: %v", err)
+		return
+	}
+
+	phases := map[string]int{
+		v1.ClusterPhasePending:      0,
+		v1.ClusterPhaseProvisioning: 0,
+		v1.ClusterPhaseReady:        0,
+		v1.ClusterPhaseUpdating:     0,
+		v1.ClusterPhaseDeleting:     0,
+		v1.ClusterPhaseFailed:       0,
+	}
+	for _, cluster := range clusters {
+		switch {
+		case cluster.DeletionTimestamp != nil:
+			phases[v1.ClusterPhaseDeleting]++
+		case cluster.Status.Phase == "":
+			phases[v1.ClusterPhasePending]++
+		default:
+			phases[cluster.Status.Phase]++
+		}
+	}
+
+	for phase, count := range phases {
+		ch <- prometheus.MustNewConstMetric(clusterPhaseDesc, prometheus.GaugeValue, float64(count), phase)
+	}
+}
@@ -0,0 +1,82 @@
+package cluster
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/wrangler/pkg/apply"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// legacySetIDs are the set IDs previous releases used for the objects of the generating handler. Objects
+// still owned through one of these are moved to generatingHandlerName before apply runs, otherwise apply
+// would not see them and they would be orphaned. Every release so far applied them as generatingHandlerName, so
+// an ID is only added here when the handler is renamed.
+var legacySetIDs []string
+
+// setHash is the objectset hash label apply puts on the objects of a set owned by cluster
+func setHash(setID string, cluster *v1.Cluster) string {
+	dig := sha1.New()
+	dig.Write([]byte(setID))
+	dig.Write([]byte(clusterGVK))
+	dig.Write([]byte(cluster.Name))
+	dig.Write([]byte(cluster.Namespace))
+	return hex.EncodeToString(dig.Sum(nil))
+}
+
+func (h *handler) migrateSetIDs(cluster *v1.Cluster) error {
+	for _, legacy := range legacySetIDs {
+		if err := h.migrateSetID(cluster, legacy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *handler) migrateSetID(cluster *v1.Cluster, legacy string) error {
+	sel := labels.SelectorFromSet(map[string]string{
+		apply.LabelHash: setHash(legacy, cluster),
+	})
+	hash := setHash(generatingHandlerName, cluster)
+
+	rClusters, err := h.rclusterCache.List(sel)
+	if err != nil {
+		return err
+	}
+	for _, rCluster := range rClusters {
+		rCluster = rCluster.DeepCopy()
+		migrateSetMeta(&rCluster.ObjectMeta, hash)
+		if _, err := h.rclusters.Update(rCluster); err != nil {
+			return err
+		}
+	}
+
+	secrets, err := h.secretCache.List("", sel)
+	if err != nil {
+		return err
+	}
+	for _, secret := range secrets {
+		secret = secret.DeepCopy()
+		migrateSetMeta(&secret.ObjectMeta, hash)
+		if _, err := h.secrets.Update(secret); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateSetMeta moves the object to the set of the generating handler, the annotations may be missing on objects
+// that were edited by hand
+func migrateSetMeta(meta *metav1.ObjectMeta, hash string) {
+	if meta.Labels == nil {
+		meta.Labels = map[string]string{}
+	}
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	meta.Labels[apply.LabelHash] = hash
+	meta.Annotations[apply.LabelID] = generatingHandlerName
+}
@@ -0,0 +1,148 @@
+package cluster
+
+import (
+	"testing"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	mgmtcontrollers "github.com/rancher/rancher-operator/pkg/generated/controllers/management.cattle.io/v3"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/wrangler/pkg/apply"
+	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const legacySetID = "cluster-legacy"
+
+// applyLabels are the labels and annotations apply sets on the objects of setID owned by cluster
+func applyLabels(t *testing.T, setID string, cluster *v1.Cluster) (map[string]string, map[string]string) {
+	t.Helper()
+	labels, annotations, err := apply.GetLabelsAndAnnotations(setID, cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return labels, annotations
+}
+
+func TestSetHash(t *testing.T) {
+	cluster := testCluster()
+	for _, setID := range []string{generatingHandlerName, legacySetID} {
+		labels, _ := applyLabels(t, setID, cluster)
+		if got, want := setHash(setID, cluster), labels[apply.LabelHash]; got != want {
+			t.Errorf("setHash(%q) = %s, apply uses %s", setID, got, want)
+		}
+	}
+}
+
+type fakeRClusterCache struct {
+	mgmtcontrollers.ClusterCache
+	objs []*v3.Cluster
+}
+
+func (f *fakeRClusterCache) List(selector labels.Selector) ([]*v3.Cluster, error) {
+	var result []*v3.Cluster
+	for _, obj := range f.objs {
+		if selector.Matches(labels.Set(obj.Labels)) {
+			result = append(result, obj)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeRClusterCache) Get(name string) (*v3.Cluster, error) {
+	for _, obj := range f.objs {
+		if obj.Name == name {
+			return obj, nil
+		}
+	}
+	return nil, apierror.NewNotFound(v3.Resource("clusters"), name)
+}
+
+type fakeRClusters struct {
+	mgmtcontrollers.ClusterClient
+	updated []*v3.Cluster
+}
+
+func (f *fakeRClusters) Update(obj *v3.Cluster) (*v3.Cluster, error) {
+	f.updated = append(f.updated, obj)
+	return obj, nil
+}
+
+type fakeSecretCache struct {
+	corecontrollers.SecretCache
+	objs []*corev1.Secret
+}
+
+func (f *fakeSecretCache) List(namespace string, selector labels.Selector) ([]*corev1.Secret, error) {
+	var result []*corev1.Secret
+	for _, obj := range f.objs {
+		if (namespace == "" || obj.Namespace == namespace) && selector.Matches(labels.Set(obj.Labels)) {
+			result = append(result, obj)
+		}
+	}
+	return result, nil
+}
+
+type fakeSecrets struct {
+	corecontrollers.SecretClient
+	updated []*corev1.Secret
+}
+
+func (f *fakeSecrets) Update(obj *corev1.Secret) (*corev1.Secret, error) {
+	f.updated = append(f.updated, obj)
+	return obj, nil
+}
+
+func TestMigrateSetID(t *testing.T) {
+	cluster := testCluster()
+	legacyLabels, legacyAnnotations := applyLabels(t, legacySetID, cluster)
+	wantLabels, wantAnnotations := applyLabels(t, generatingHandlerName, cluster)
+
+	rClusters := &fakeRClusters{}
+	secrets := &fakeSecrets{}
+	h := &handler{
+		rclusterCache: &fakeRClusterCache{
+			objs: []*v3.Cluster{
+				{ObjectMeta: metav1.ObjectMeta{Name: "c-legacy", Labels: legacyLabels, Annotations: legacyAnnotations}},
+				// edited by hand, apply still finds it by the hash label
+				{ObjectMeta: metav1.ObjectMeta{Name: "c-edited", Labels: legacyLabels}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "c-current", Labels: wantLabels, Annotations: wantAnnotations}},
+			},
+		},
+		rclusters: rClusters,
+		secretCache: &fakeSecretCache{
+			objs: []*corev1.Secret{
+				{ObjectMeta: metav1.ObjectMeta{Name: "test-kubeconfig", Namespace: "fleet-default", Labels: legacyLabels, Annotations: legacyAnnotations}},
+			},
+		},
+		secrets: secrets,
+	}
+
+	if err := h.migrateSetID(cluster, legacySetID); err != nil {
+		t.Fatal(err)
+	}
+
+	var migrated []metav1.ObjectMeta
+	for _, rCluster := range rClusters.updated {
+		migrated = append(migrated, rCluster.ObjectMeta)
+	}
+	for _, secret := range secrets.updated {
+		migrated = append(migrated, secret.ObjectMeta)
+	}
+	if len(migrated) != 3 {
+		t.Fatalf("migrated %d objects, want 3", len(migrated))
+	}
+	for _, meta := range migrated {
+		if meta.Name == "c-current" {
+			t.Errorf("migrated %s which already has the current set ID", meta.Name)
+		}
+		if got := meta.Labels[apply.LabelHash]; got != wantLabels[apply.LabelHash] {
+			t.Errorf("%s has hash %s, apply looks for %s", meta.Name, got, wantLabels[apply.LabelHash])
+		}
+		if got := meta.Annotations[apply.LabelID]; got != generatingHandlerName {
+			t.Errorf("%s has set ID %s, want %s", meta.Name, got, generatingHandlerName)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package cluster
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/wrangler/pkg/name"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// The annotations wrangler sets on applied objects to record the object that generated them
+const (
+	ownerGVKAnnotation       = "objectset.rio.cattle.io/owner-gvk"
+	ownerNameAnnotation      = "objectset.rio.cattle.io/owner-name"
+	ownerNamespaceAnnotation = "objectset.rio.cattle.io/owner-namespace"
+)
+
+// rancherClusterName returns the name of the v3 cluster generated for cluster. SafeConcatName truncates long names
+// so two clusters can map to the same name, when it is taken by a v3 cluster that cluster does not own a name with
+// a hash of the namespace and name is used instead. Once chosen the name is kept in status.clusterName.
+func (h *handler) rancherClusterName(cluster *v1.Cluster, status v1.ClusterStatus) (string, error) {
+	if status.ClusterName != "" {
+		return status.ClusterName, nil
+	}
+
+	hash := sha256.Sum256([]byte(cluster.Namespace + "/" + cluster.Name))
+	candidates := []string{
+		name.SafeConcatName("c", cluster.Namespace, cluster.Name),
+		name.SafeConcatName("c", hex.EncodeToString(hash[:])[:12], cluster.Namespace, cluster.Name),
+	}
+
+	for _, candidate := range candidates {
+		existing, err := h.rclusterCache.Get(candidate)
+		if apierror.IsNotFound(err) {
+			return candidate, nil
+		} else if err != nil {
+			return "", err
+		}
+		if ownedBy(existing.ObjectMeta, cluster) {
+			return candidate, nil
+		}
+	}
+
+	return "", newFailure(failureReasonAlreadyExists,
+		fmt.Errorf("management clusters %v already exist and are not owned by this cluster", candidates))
+}
+
+// rancherClusterNameOrDefault is rancherClusterName for previews, which fall back to the first candidate on error
+func (h *handler) rancherClusterNameOrDefault(cluster *v1.Cluster) string {
+	if n, err := h.rancherClusterName(cluster, cluster.Status); err == nil {
+		return n
+	}
+	return name.SafeConcatName("c", cluster.Namespace, cluster.Name)
+}
+
+func ownedBy(meta metav1.ObjectMeta, cluster *v1.Cluster) bool {
+	return meta.Annotations[ownerGVKAnnotation] == v1.SchemeGroupVersion.WithKind("Cluster").String() &&
+		meta.Annotations[ownerNameAnnotation] == cluster.Name &&
+		meta.Annotations[ownerNamespaceAnnotation] == cluster.Namespace
+}
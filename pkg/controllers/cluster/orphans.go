@@ -0,0 +1,107 @@
+package cluster
+
+import (
+	"context"
+	"time"
+
+	"github.com/rancher/rancher-operator/pkg/clients"
+	mgmtcontrollers "github.com/rancher/rancher-operator/pkg/generated/controllers/management.cattle.io/v3"
+	rocontrollers "github.com/rancher/rancher-operator/pkg/generated/controllers/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/logging"
+	"github.com/rancher/rancher-operator/pkg/options"
+	"github.com/rancher/rancher-operator/pkg/tenancy"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	orphanSweepInterval = 10 * time.Minute
+	// orphanedAnnotation is set to the time a management cluster was found without the cluster it was generated for
+	orphanedAnnotation = "rancher.cattle.io/orphaned"
+)
+
+type orphanSweeper struct {
+	policy        string
+	instance      string
+	clusters      rocontrollers.ClusterClient
+	clusterCache  rocontrollers.ClusterCache
+	rclusters     mgmtcontrollers.ClusterClient
+	rclusterCache mgmtcontrollers.ClusterCache
+}
+
+// SweepOrphans periodically looks for management clusters generated for clusters that no longer exist, which
+// happens when the finalizer of a cluster is removed by hand or its namespace is force deleted. Depending on
+// the policy they are annotated or deleted. Only the management clusters applied by this instance are swept, the
+// cluster of another instance may exist outside the namespaces or shard this one watches. It must only run on the
+// leader once the caches are started.
+func SweepOrphans(ctx context.Context, clients *clients.Clients, policy, instance string) {
+	if policy == "" {
+		return
+	}
+
+	s := &orphanSweeper{
+		policy:        policy,
+		instance:      instance,
+		clusters:      clients.Cluster(),
+		clusterCache:  clients.Cluster().Cache(),
+		rclusters:     clients.Management.Cluster(),
+		rclusterCache: clients.Management.Cluster().Cache(),
+	}
+	go wait.JitterUntil(func() {
+		if err := s.sweep(); err != nil {
+			logging.ForReconcile("cluster-orphan-sweeper", "").Errorf("failed to sweep orphaned management clusters: %v", err)
+		}
+	}, orphanSweepInterval, 0.1, true, ctx.Done())
+}
+
+func (s *orphanSweeper) sweep() error {
+	rClusters, err := s.rclusterCache.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+
+	for _, rCluster := range rClusters {
+		namespace, name, ok := tenancy.Owner(rCluster)
+		if instance, applied := rCluster.Annotations[instanceAnnotation]; !applied || instance != s.instance {
+			continue
+		}
+		if !ok || rCluster.DeletionTimestamp != nil || (rCluster.Annotations[orphanedAnnotation] != "" && s.policy != options.OrphanClusterPolicyDelete) {
+			continue
+		}
+
+		if _, err := s.clusterCache.Get(namespace, name); err == nil {
+			continue
+		} else if !apierror.IsNotFound(err) {
+			return err
+		}
+		// the cache may not have seen a cluster that was just created
+		if _, err := s.clusters.Get(namespace, name, metav1.GetOptions{}); err == nil {
+			continue
+		} else if !apierror.IsNotFound(err) {
+			return err
+		}
+
+		log := logging.ForObject(namespace, name)
+		if s.policy == options.OrphanClusterPolicyDelete {
+			log.Warnf("Deleting management cluster %s, the cluster it was generated for no longer exists", rCluster.Name)
+			if err := s.rclusters.Delete(rCluster.Name, nil); err != nil && !apierror.IsNotFound(err) {
+				return err
+			}
+			continue
+		}
+
+		log.Warnf("Management cluster %s is orphaned, the cluster it was generated for no longer exists", rCluster.Name)
+		rCluster = rCluster.DeepCopy()
+		if rCluster.Annotations == nil {
+			rCluster.Annotations = map[string]string{}
+		}
+		rCluster.Annotations[orphanedAnnotation] = time.Now().UTC().Format(time.RFC3339)
+		if _, err := s.rclusters.Update(rCluster); err != nil && !apierror.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
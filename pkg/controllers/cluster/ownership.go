@@ -0,0 +1,163 @@
+package cluster
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/wrangler/pkg/apply"
+	"github.com/rancher/wrangler/pkg/generic"
+	corev1 "k8s.io/api/core/v1"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// instanceAnnotation on the applied v3 clusters and secrets holds the --instance-name of the operator that
+	// applied them, another operator watching the same clusters never updates or prunes them
+	instanceAnnotation = "rancher.cattle.io/operator-instance"
+	byOwner            = "by-owner"
+
+	conflictingOwnerInterval = time.Minute
+)
+
+// appliedFor indexes the objects of the generating handler's apply set by the namespace/name of their cluster
+func appliedFor(meta metav1.ObjectMeta) []string {
+	if meta.Annotations[apply.LabelID] != generatingHandlerName || meta.Annotations[ownerGVKAnnotation] != clusterGVK {
+		return nil
+	}
+	return []string{meta.Annotations[ownerNamespaceAnnotation] + "/" + meta.Annotations[ownerNameAnnotation]}
+}
+
+// owns is true if the object was applied for cluster by this instance. Objects applied before the instance
+// annotation existed are adopted.
+func (h *handler) owns(meta metav1.ObjectMeta, cluster *v1.Cluster) bool {
+	instance, ok := meta.Annotations[instanceAnnotation]
+	return ownedBy(meta, cluster) && (!ok || instance == h.instance)
+}
+
+// checkOwnership refuses to apply objs while an object they would update or prune is not owned by the cluster
+// and this instance. Nothing is applied, the conflict is reported in the ConflictingOwner condition and checked
+// again every conflictingOwnerInterval.
+func (h *handler) checkOwnership(cluster *v1.Cluster, objs []runtime.Object, status v1.ClusterStatus) ([]runtime.Object, v1.ClusterStatus, error) {
+	conflicts, err := h.ownershipConflicts(cluster, objs)
+	if err != nil {
+		return nil, status, err
+	}
+
+	if len(conflicts) == 0 {
+		if v1.ClusterConditionConflictingOwner.GetStatus(&status) != "" {
+			v1.ClusterConditionConflictingOwner.False(&status)
+			v1.ClusterConditionConflictingOwner.Message(&status, "")
+		}
+		return objs, status, nil
+	}
+
+	h.clusters.EnqueueAfter(cluster.Namespace, cluster.Name, conflictingOwnerInterval)
+	message := fmt.Sprintf("not owned by operator instance %s: %s", h.instance, strings.Join(conflicts, ", "))
+	if v1.ClusterConditionConflictingOwner.IsTrue(cluster) && v1.ClusterConditionConflictingOwner.GetMessage(cluster) == message {
+		return nil, status, generic.ErrSkip
+	}
+	h.recorder.Eventf(cluster, corev1.EventTypeWarning, "ConflictingOwner", "Not applying cluster %s, %s", cluster.Name, message)
+	cluster = cluster.DeepCopy()
+	v1.ClusterConditionConflictingOwner.True(cluster)
+	v1.ClusterConditionConflictingOwner.Message(cluster, message)
+	if _, err := h.clusters.UpdateStatus(cluster); err != nil {
+		return nil, status, err
+	}
+	return nil, status, generic.ErrSkip
+}
+
+// ownershipConflicts sets the instance annotation on objs and lists the objects that would be updated or pruned
+// by applying them without being owned
+func (h *handler) ownershipConflicts(cluster *v1.Cluster, objs []runtime.Object) ([]string, error) {
+	var conflicts []string
+	desired := map[string]bool{}
+	for _, obj := range objs {
+		objMeta, err := meta.Accessor(obj)
+		if err != nil {
+			return nil, err
+		}
+		annotations := objMeta.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[instanceAnnotation] = h.instance
+		objMeta.SetAnnotations(annotations)
+
+		var existing *metav1.ObjectMeta
+		var desc string
+		switch o := obj.(type) {
+		case *corev1.Secret:
+			desc = fmt.Sprintf("v1 Secret %s/%s", o.Namespace, o.Name)
+			existing, err = h.existingSecret(o.Namespace, o.Name)
+		case *unstructured.Unstructured:
+			if o.GetKind() != "Cluster" {
+				continue
+			}
+			desc = "management.cattle.io/v3 Cluster " + o.GetName()
+			existing, err = h.existingRCluster(o.GetName())
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		desired[desc] = true
+		if existing != nil && !h.owns(*existing, cluster) {
+			conflicts = append(conflicts, desc)
+		}
+	}
+
+	// the objects applied before and no longer desired are pruned
+	key := cluster.Namespace + "/" + cluster.Name
+	secrets, err := h.secretCache.GetByIndex(byOwner, key)
+	if err != nil {
+		return nil, err
+	}
+	for _, secret := range secrets {
+		desc := fmt.Sprintf("v1 Secret %s/%s", secret.Namespace, secret.Name)
+		if !desired[desc] && !h.owns(secret.ObjectMeta, cluster) {
+			conflicts = append(conflicts, desc)
+		}
+	}
+	rClusters, err := h.rclusterCache.GetByIndex(byOwner, key)
+	if err != nil {
+		return nil, err
+	}
+	for _, rCluster := range rClusters {
+		desc := "management.cattle.io/v3 Cluster " + rCluster.Name
+		if !desired[desc] && !h.owns(rCluster.ObjectMeta, cluster) {
+			conflicts = append(conflicts, desc)
+		}
+	}
+	return conflicts, nil
+}
+
+// existingSecret only caches the secrets created through apply, one created otherwise is read from the API server
+func (h *handler) existingSecret(namespace, name string) (*metav1.ObjectMeta, error) {
+	secret, err := h.secretCache.Get(namespace, name)
+	if apierror.IsNotFound(err) {
+		secret, err = h.secrets.Get(namespace, name, metav1.GetOptions{})
+	}
+	if apierror.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &secret.ObjectMeta, nil
+}
+
+func (h *handler) existingRCluster(name string) (*metav1.ObjectMeta, error) {
+	rCluster, err := h.rclusterCache.Get(name)
+	if apierror.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &rCluster.ObjectMeta, nil
+}
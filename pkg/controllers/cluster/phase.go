@@ -0,0 +1,28 @@
+package cluster
+
+import (
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/condition/kstatus"
+)
+
+// phase summarizes the conditions of status, created is false until the v3 cluster exists. Failed from a
+// reconcile error is set by onFailure.
+func phase(cluster *v1.Cluster, status v1.ClusterStatus, created bool) string {
+	switch {
+	case cluster.DeletionTimestamp != nil:
+		return v1.ClusterPhaseDeleting
+	case kstatus.Stalled.IsTrue(&status), v1.ClusterConditionSpecInvalid.IsTrue(&status),
+		v1.ClusterConditionProvisioned.IsFalse(&status) && v1.ClusterConditionProvisioned.GetMessage(&status) != "":
+		return v1.ClusterPhaseFailed
+	case v1.ClusterConditionHibernated.IsTrue(&status):
+		return v1.ClusterPhaseHibernated
+	case status.Ready:
+		return v1.ClusterPhaseReady
+	case !created:
+		return v1.ClusterPhasePending
+	case v1.ClusterConditionProvisioned.IsTrue(&status):
+		// provisioned before, it is being upgraded or reconfigured
+		return v1.ClusterPhaseUpdating
+	}
+	return v1.ClusterPhaseProvisioning
+}
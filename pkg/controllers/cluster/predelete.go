@@ -0,0 +1,135 @@
+package cluster
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/predelete"
+	"github.com/rancher/rancher-operator/pkg/tracing"
+	"github.com/rancher/wrangler/pkg/generic"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	preDeleteHookPollInterval   = 10 * time.Second
+	defaultPreDeleteHookTimeout = 10 * time.Minute
+)
+
+// runPreDeleteHooks runs spec.preDeleteHooks in order. generic.ErrSkip is returned until every hook succeeded or
+// timed out so the finalizer is kept, each hook gets its timeout on top of the hooks before it.
+func (h *handler) runPreDeleteHooks(cluster *v1.Cluster) (*v1.Cluster, error) {
+	if v1.ClusterConditionPreDeleteHooksCompleted.IsTrue(cluster) || v1.ClusterConditionPreDeleteHooksCompleted.IsFalse(cluster) {
+		return cluster, nil
+	}
+
+	var failed []string
+	deadline := cluster.DeletionTimestamp.Add(h.deletionGracePeriod)
+	for _, hook := range cluster.Spec.PreDeleteHooks {
+		timeout := defaultPreDeleteHookTimeout
+		if hook.TimeoutSeconds > 0 {
+			timeout = time.Duration(hook.TimeoutSeconds) * time.Second
+		}
+		deadline = deadline.Add(timeout)
+
+		if completedPreDeleteHook(cluster, hook.Name) {
+			continue
+		}
+		if time.Now().After(deadline) {
+			failed = append(failed, fmt.Sprintf("%s timed out after %s", hook.Name, timeout))
+			continue
+		}
+
+		result, skipped, err := h.runPreDeleteHook(cluster, hook)
+		if err != nil {
+			return cluster, err
+		}
+		if skipped != "" {
+			failed = append(failed, fmt.Sprintf("%s skipped, %s", hook.Name, skipped))
+			continue
+		}
+
+		if result.Done {
+			cluster = cluster.DeepCopy()
+			cluster.Status.CompletedPreDeleteHooks = append(cluster.Status.CompletedPreDeleteHooks, hook.Name)
+			updated, err := h.clusters.UpdateStatus(cluster)
+			if err != nil {
+				return cluster, err
+			}
+			cluster = updated
+			continue
+		}
+
+		message := fmt.Sprintf("running %s, %s", hook.Name, result.Message)
+		if v1.ClusterConditionPreDeleteHooksCompleted.GetMessage(cluster) != message {
+			cluster = cluster.DeepCopy()
+			v1.ClusterConditionPreDeleteHooksCompleted.Unknown(cluster)
+			v1.ClusterConditionPreDeleteHooksCompleted.Message(cluster, message)
+			updated, err := h.clusters.UpdateStatus(cluster)
+			if err != nil {
+				return cluster, err
+			}
+			cluster = updated
+		}
+		h.clusters.EnqueueAfter(cluster.Namespace, cluster.Name, preDeleteHookPollInterval)
+		return cluster, generic.ErrSkip
+	}
+
+	cluster = cluster.DeepCopy()
+	if len(failed) == 0 {
+		v1.ClusterConditionPreDeleteHooksCompleted.True(cluster)
+		v1.ClusterConditionPreDeleteHooksCompleted.Message(cluster, "pre-delete hooks succeeded")
+	} else {
+		v1.ClusterConditionPreDeleteHooksCompleted.False(cluster)
+		v1.ClusterConditionPreDeleteHooksCompleted.Message(cluster, strings.Join(failed, ", "))
+	}
+	updated, err := h.clusters.UpdateStatus(cluster)
+	if err != nil {
+		return cluster, err
+	}
+	return updated, nil
+}
+
+// runPreDeleteHook runs a single hook, job hooks are skipped with the returned reason if there is no kubeconfig
+// to reach the cluster
+func (h *handler) runPreDeleteHook(cluster *v1.Cluster, hook v1.PreDeleteHook) (predelete.Result, string, error) {
+	if hook.Job == nil {
+		result, err := predelete.Webhook(cluster, hook)
+		return result, "", err
+	}
+
+	if cluster.Status.ClientSecretName == "" {
+		return predelete.Result{}, "no kubeconfig was generated for the cluster", nil
+	}
+	secret, err := h.secretCache.Get(cluster.Namespace, cluster.Status.ClientSecretName)
+	if apierror.IsNotFound(err) {
+		return predelete.Result{}, "kubeconfig secret not found", nil
+	} else if err != nil {
+		return predelete.Result{}, "", err
+	}
+
+	cfg, err := clientcmd.RESTConfigFromKubeConfig(secret.Data["value"])
+	if err != nil {
+		return predelete.Result{}, "", err
+	}
+
+	var result predelete.Result
+	err = h.limiter.Do(cluster.Status.ClusterName, func() (err error) {
+		span := tracing.Start(cluster.Namespace, cluster.Name, "predelete.job")
+		defer func() { tracing.End(span, err) }()
+		result, err = predelete.Job(cfg, hook)
+		return err
+	})
+	return result, "", err
+}
+
+func completedPreDeleteHook(cluster *v1.Cluster, name string) bool {
+	for _, completed := range cluster.Status.CompletedPreDeleteHooks {
+		if completed == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,135 @@
+package cluster
+
+import (
+	"sync"
+	"time"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	mgmtcontrollers "github.com/rancher/rancher-operator/pkg/generated/controllers/management.cattle.io/v3"
+	"github.com/rancher/wrangler/pkg/condition"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// resyncDeferInterval is how long the reconcile of a settled cluster is put off while others wait
+	resyncDeferInterval = 10 * time.Second
+	// maxResyncDefer bounds how long a settled cluster is put off, so it is still reconciled under constant load
+	maxResyncDefer = 2 * time.Minute
+)
+
+// prioritizer puts off the reconciles of settled clusters while changed, new or not ready clusters wait in the
+// queue, so a resync of every cluster doesn't delay provisioning new ones. The workqueue of the controller can't
+// be replaced, the clusters waiting are tracked from the informer events instead. Only a reconcile caused by a
+// resync is put off, an informer event with an unchanged resource version, and only while the management cluster
+// is ready, the status of the cluster may be stale.
+type prioritizer struct {
+	lock      sync.Mutex
+	rClusters mgmtcontrollers.ClusterCache
+	// waiting holds the clusters added or changed since they were last reconciled
+	waiting map[string]bool
+	// resyncs holds the settled clusters only resynced since they were last reconciled
+	resyncs  map[string]bool
+	deferred map[string]time.Time
+}
+
+func newPrioritizer(informer cache.SharedIndexInformer, rClusters mgmtcontrollers.ClusterCache) *prioritizer {
+	p := &prioritizer{
+		rClusters: rClusters,
+		waiting:   map[string]bool{},
+		resyncs:   map[string]bool{},
+		deferred:  map[string]time.Time{},
+	}
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		// the initial list is a resync of the clusters that exist
+		AddFunc: func(obj interface{}) {
+			if cluster, ok := obj.(*v1.Cluster); ok {
+				p.observe(cluster, true)
+			}
+		},
+		// a resync delivers the cached object again, a change has a new resource version
+		UpdateFunc: func(oldObj, obj interface{}) {
+			oldCluster, oldOK := oldObj.(*v1.Cluster)
+			if cluster, ok := obj.(*v1.Cluster); ok {
+				p.observe(cluster, oldOK && oldCluster.ResourceVersion == cluster.ResourceVersion)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj); err == nil {
+				p.done(key)
+			}
+		},
+	})
+	return p
+}
+
+// settled is true for a cluster whose generation was applied and whose management cluster is ready, reconciling it
+// again is only a resync
+func (p *prioritizer) settled(cluster *v1.Cluster) bool {
+	if cluster.DeletionTimestamp != nil || cluster.Status.ClusterName == "" ||
+		cluster.Status.ObservedGeneration != cluster.Generation || !v1.ClusterConditionReconciled.IsTrue(cluster) {
+		return false
+	}
+	rCluster, err := p.rClusters.Get(cluster.Status.ClusterName)
+	return err == nil && condition.Cond("Ready").IsTrue(rCluster)
+}
+
+func (p *prioritizer) observe(cluster *v1.Cluster, resync bool) {
+	key := cluster.Namespace + "/" + cluster.Name
+	settled := resync && p.settled(cluster)
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if settled {
+		// a change still waiting to be reconciled isn't put off by a later resync
+		if !p.waiting[key] {
+			p.resyncs[key] = true
+		}
+		return
+	}
+	delete(p.resyncs, key)
+	p.waiting[key] = true
+}
+
+func (p *prioritizer) done(key string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	delete(p.waiting, key)
+	delete(p.resyncs, key)
+	delete(p.deferred, key)
+}
+
+// skipped stops tracking a cluster that isn't applied, it would otherwise put off the settled clusters until it
+// changes again
+func (p *prioritizer) skipped(cluster *v1.Cluster) {
+	p.done(cluster.Namespace + "/" + cluster.Name)
+}
+
+// deferResync returns true if the reconcile of cluster is put off, the caller must requeue it
+func (p *prioritizer) deferResync(cluster *v1.Cluster) bool {
+	key := cluster.Namespace + "/" + cluster.Name
+
+	p.lock.Lock()
+	resync := p.resyncs[key]
+	p.lock.Unlock()
+	if !resync || !p.settled(cluster) {
+		p.done(key)
+		return false
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if len(p.waiting) == 0 {
+		delete(p.resyncs, key)
+		delete(p.deferred, key)
+		return false
+	}
+	since, ok := p.deferred[key]
+	if !ok {
+		p.deferred[key] = time.Now()
+	} else if time.Since(since) > maxResyncDefer {
+		delete(p.resyncs, key)
+		delete(p.deferred, key)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,59 @@
+package cluster
+
+import (
+	"testing"
+	"time"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDeferResync(t *testing.T) {
+	rCluster := &v3.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "c-settled"},
+		Status: v3.ClusterStatus{
+			Conditions: []v3.ClusterCondition{{Type: "Ready", Status: corev1.ConditionTrue}},
+		},
+	}
+	p := &prioritizer{
+		rClusters: &fakeRClusterCache{objs: []*v3.Cluster{rCluster}},
+		waiting:   map[string]bool{},
+		resyncs:   map[string]bool{},
+		deferred:  map[string]time.Time{},
+	}
+
+	settled := clusterFor("default", "settled", "c-settled")
+	settled.Generation = 1
+	settled.Status.ObservedGeneration = 1
+	v1.ClusterConditionReconciled.True(settled)
+	provisioning := clusterFor("default", "provisioning", "")
+
+	p.observe(settled, true)
+	p.observe(provisioning, true)
+	if !p.deferResync(settled) {
+		t.Error("resync of a settled cluster is not put off while another cluster waits")
+	}
+
+	p.observe(settled, false)
+	if p.deferResync(settled) {
+		t.Error("change of a settled cluster is put off")
+	}
+
+	// status.ready may be stale, the management cluster decides
+	rCluster.Status.Conditions[0].Status = corev1.ConditionFalse
+	p.observe(settled, true)
+	if p.deferResync(settled) {
+		t.Error("resync of a cluster whose management cluster is not ready is put off")
+	}
+	rCluster.Status.Conditions[0].Status = corev1.ConditionTrue
+
+	if p.deferResync(provisioning) {
+		t.Error("cluster waiting for its first apply is put off")
+	}
+	p.observe(settled, true)
+	if p.deferResync(settled) {
+		t.Error("resync of a settled cluster is put off while no other cluster waits")
+	}
+}
@@ -0,0 +1,70 @@
+package cluster
+
+import (
+	"strings"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// proxyEnvVars returns the proxy variables of spec.proxy, both upper and lower case since tools disagree on them
+func proxyEnvVars(proxy *v1.ProxyConfig) []corev1.EnvVar {
+	var envs []corev1.EnvVar
+	for _, env := range []corev1.EnvVar{
+		{Name: "HTTP_PROXY", Value: proxy.HTTPProxy},
+		{Name: "HTTPS_PROXY", Value: proxy.HTTPSProxy},
+		{Name: "NO_PROXY", Value: proxy.NoProxy},
+	} {
+		if env.Value == "" {
+			continue
+		}
+		envs = append(envs, env, corev1.EnvVar{Name: strings.ToLower(env.Name), Value: env.Value})
+	}
+	return envs
+}
+
+// withProxy adds the proxy variables to the agents, which pass them on to the fleet agent, and to the kube-apiserver,
+// kube-controller-manager and kubelet of RKE clusters, which reach cloud providers through it. Variables that are
+// already set are kept.
+func withProxy(spec v3.ClusterSpec, proxy *v1.ProxyConfig) v3.ClusterSpec {
+	if proxy == nil {
+		return spec
+	}
+
+	envs := proxyEnvVars(proxy)
+	agentEnvs := map[string]bool{}
+	for _, env := range spec.AgentEnvVars {
+		agentEnvs[env.Name] = true
+	}
+	agentEnvVars := append([]corev1.EnvVar{}, spec.AgentEnvVars...)
+	for _, env := range envs {
+		if !agentEnvs[env.Name] {
+			agentEnvVars = append(agentEnvVars, env)
+		}
+	}
+	spec.AgentEnvVars = agentEnvVars
+
+	if spec.RancherKubernetesEngineConfig != nil {
+		spec.RancherKubernetesEngineConfig = spec.RancherKubernetesEngineConfig.DeepCopy()
+		services := &spec.RancherKubernetesEngineConfig.Services
+		services.KubeAPI.ExtraEnv = withExtraEnv(services.KubeAPI.ExtraEnv, envs)
+		services.KubeController.ExtraEnv = withExtraEnv(services.KubeController.ExtraEnv, envs)
+		services.Kubelet.ExtraEnv = withExtraEnv(services.Kubelet.ExtraEnv, envs)
+	}
+	return spec
+}
+
+// withExtraEnv appends the variables not set in extraEnv, which holds NAME=value entries
+func withExtraEnv(extraEnv []string, envs []corev1.EnvVar) []string {
+	set := map[string]bool{}
+	for _, env := range extraEnv {
+		set[strings.SplitN(env, "=", 2)[0]] = true
+	}
+	for _, env := range envs {
+		if !set[env.Name] {
+			extraEnv = append(extraEnv, env.Name+"="+env.Value)
+		}
+	}
+	return extraEnv
+}
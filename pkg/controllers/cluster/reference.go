@@ -2,9 +2,15 @@ package cluster
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/tenancy"
 	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/wrangler/pkg/generic"
+	"github.com/rancher/wrangler/pkg/relatedresource"
+	corev1 "k8s.io/api/core/v1"
 	apierror "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -17,21 +23,69 @@ const (
 )
 
 func (h *handler) referenceCluster(cluster *v1.Cluster, status v1.ClusterStatus) ([]runtime.Object, v1.ClusterStatus, error) {
-	rCluster, err := h.claimCluster(cluster, status)
+	rCluster, unboundMessage, err := h.claimCluster(cluster, &status)
 	if err != nil {
 		return nil, status, err
 	}
+	if rCluster == nil {
+		return h.unbound(cluster, status, unboundMessage)
+	}
 
+	v1.ClusterConditionReferenceBound.True(&status)
+	v1.ClusterConditionReferenceBound.Message(&status, "bound to management cluster "+rCluster.Name)
 	return h.updateStatus(nil, cluster, status, rCluster)
 }
 
-func (h *handler) claimCluster(cluster *v1.Cluster, status v1.ClusterStatus) (*v3.Cluster, error) {
+// unbound reports why no management cluster is bound in the ReferenceBound condition. Waiting for a single match
+// isn't a failure, the cluster is requeued by the v3 cluster watch once one matches.
+func (h *handler) unbound(cluster *v1.Cluster, status v1.ClusterStatus, message string) ([]runtime.Object, v1.ClusterStatus, error) {
+	if v1.ClusterConditionReferenceBound.IsFalse(cluster) && v1.ClusterConditionReferenceBound.GetMessage(cluster) == message &&
+		cluster.Status.ClusterName == status.ClusterName {
+		return nil, status, generic.ErrSkip
+	}
+
+	h.recorder.Eventf(cluster, corev1.EventTypeWarning, "ReferenceUnbound", "Cluster %s is not bound to a management cluster, %s", cluster.Name, message)
+	cluster = cluster.DeepCopy()
+	if cluster.Status.ClusterName != status.ClusterName {
+		cluster.Status.ClusterName = status.ClusterName
+		cluster.Status.Ready = false
+	}
+	v1.ClusterConditionReferenceBound.False(cluster)
+	v1.ClusterConditionReferenceBound.Message(cluster, message)
+	if _, err := h.clusters.UpdateStatus(cluster); err != nil {
+		return nil, status, err
+	}
+	return nil, status, generic.ErrSkip
+}
+
+// claimCluster returns the management cluster bound to cluster, or why none is bound. status.clusterName is
+// cleared when the bound management cluster was deleted, so a replacement matching the reference is bound.
+func (h *handler) claimCluster(cluster *v1.Cluster, status *v1.ClusterStatus) (*v3.Cluster, string, error) {
 	if status.ClusterName != "" {
-		return h.rclusterCache.Get(status.ClusterName)
+		rCluster, err := h.rclusterCache.Get(status.ClusterName)
+		if apierror.IsNotFound(err) {
+			h.recorder.Eventf(cluster, corev1.EventTypeNormal, "Rebinding", "Management cluster %s was deleted, binding the one matching %s",
+				status.ClusterName, describeReference(cluster.Spec.ReferencedConfig))
+			status.ClusterName = ""
+		} else if err != nil {
+			return nil, "", err
+		} else {
+			ok, err := tenancy.CanReference(cluster.Namespace, rCluster, h.listGrants)
+			if err != nil {
+				return nil, "", err
+			}
+			if !ok {
+				return nil, "", newFailure(failureReasonClaimConflict, fmt.Errorf("management cluster %s belongs to namespace %q, which grants no access to %s",
+					rCluster.Name, tenancy.Namespace(rCluster), cluster.Namespace))
+			}
+			return rCluster, "", nil
+		}
 	}
 
-	if cluster.Spec.ReferencedConfig.Selector == nil {
-		return nil, fmt.Errorf("missing selector for referenced cluster %s/%s", cluster.Namespace, cluster.Name)
+	ref := cluster.Spec.ReferencedConfig
+	if ref.Name == "" && ref.Selector == nil {
+		return nil, "", newFailure(failureReasonInvalidConfiguration,
+			fmt.Errorf("missing name or selector for referenced cluster %s/%s", cluster.Namespace, cluster.Name))
 	}
 
 	claimed, err := h.rclusterCache.List(labels.SelectorFromSet(map[string]string{
@@ -39,61 +93,187 @@ func (h *handler) claimCluster(cluster *v1.Cluster, status v1.ClusterStatus) (*v
 		claimedLabelNamespace: cluster.Namespace,
 	}))
 	if err != nil {
-		return nil, err
+		return nil, "", err
+	}
+	claimed, _, err = h.referenceableClusters(cluster.Namespace, claimed)
+	if err != nil {
+		return nil, "", err
 	}
 
 	if len(claimed) > 1 {
-		return nil, fmt.Errorf("more than one (%d) cluster is claimed by %s/%s remove %s and %s label on the undesired clusters",
-			len(claimed), cluster.Namespace, cluster.Name, claimedLabelNamespace, claimedLabelName)
+		return nil, "", newFailure(failureReasonClaimConflict, fmt.Errorf("more than one (%d) cluster is claimed by %s/%s remove %s and %s label on the undesired clusters",
+			len(claimed), cluster.Namespace, cluster.Name, claimedLabelNamespace, claimedLabelName))
 	}
 
 	if len(claimed) == 1 {
-		return claimed[0], nil
+		return claimed[0], "", nil
 	}
 
-	sel, err := metav1.LabelSelectorAsSelector(cluster.Spec.ReferencedConfig.Selector)
+	candidates, err := h.referenceCandidates(ref)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
-
-	available, err := h.rclusterCache.List(sel)
+	// clusters of other namespaces are only claimed when a ClusterReferenceGrant of their namespace allows it
+	available, others, err := h.referenceableClusters(cluster.Namespace, candidates)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
+	var unclaimed []*v3.Cluster
 	for _, available := range available {
-		if available.Labels[claimedLabelName] != "" || available.Labels[claimedLabelNamespace] != "" {
-			continue
+		released, err := h.releaseStaleClaim(available)
+		if err != nil {
+			return nil, "", err
+		}
+		if released != nil {
+			unclaimed = append(unclaimed, released)
 		}
-		updated := available.DeepCopy()
+	}
+
+	switch {
+	case len(unclaimed) == 1:
+		updated := unclaimed[0].DeepCopy()
 		if updated.Labels == nil {
 			updated.Labels = map[string]string{}
 		}
 		updated.Labels[claimedLabelName] = cluster.Name
 		updated.Labels[claimedLabelNamespace] = cluster.Namespace
-		return h.rclusters.Update(updated)
+		rCluster, err := h.rclusters.Update(updated)
+		return rCluster, "", err
+	case len(unclaimed) > 1:
+		var names []string
+		for _, rCluster := range unclaimed {
+			names = append(names, rCluster.Name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Sprintf("%s is ambiguous, it matches the management clusters %s", describeReference(ref), strings.Join(names, ", ")), nil
+	case len(available) > 0:
+		return nil, fmt.Sprintf("the %d management cluster(s) matching %s are claimed by other clusters", len(available), describeReference(ref)), nil
+	case others > 0:
+		return nil, fmt.Sprintf("the %d management cluster(s) matching %s belong to other namespaces that grant no access", others, describeReference(ref)), nil
+	default:
+		return nil, fmt.Sprintf("no management cluster matches %s", describeReference(ref)), nil
+	}
+}
+
+// referenceCandidates returns the management clusters matching ref
+func (h *handler) referenceCandidates(ref *v1.ReferencedConfig) ([]*v3.Cluster, error) {
+	sel := labels.Everything()
+	if ref.Selector != nil {
+		var err error
+		if sel, err = metav1.LabelSelectorAsSelector(ref.Selector); err != nil {
+			return nil, newFailure(failureReasonInvalidConfiguration, err)
+		}
 	}
 
-	if len(available) == 0 {
-		return nil, fmt.Errorf("failed to find a cluster that matches %s", cluster.Spec.ReferencedConfig.Selector)
+	if ref.Name == "" {
+		return h.rclusterCache.List(sel)
+	}
+	rCluster, err := h.rclusterCache.Get(ref.Name)
+	if apierror.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if !sel.Matches(labels.Set(rCluster.Labels)) {
+		return nil, nil
 	}
+	return []*v3.Cluster{rCluster}, nil
+}
 
-	for _, available := range available {
-		if available.Labels[claimedLabelName] == "" || available.Labels[claimedLabelNamespace] == "" {
+// releaseStaleClaim returns rCluster without the claim of a cluster that no longer exists, nil if it is claimed
+func (h *handler) releaseStaleClaim(rCluster *v3.Cluster) (*v3.Cluster, error) {
+	namespace, name := rCluster.Labels[claimedLabelNamespace], rCluster.Labels[claimedLabelName]
+	if namespace == "" && name == "" {
+		return rCluster, nil
+	}
+	if _, err := h.clusters.Cache().Get(namespace, name); !apierror.IsNotFound(err) {
+		return nil, err
+	}
+
+	released := rCluster.DeepCopy()
+	delete(released.Labels, claimedLabelNamespace)
+	delete(released.Labels, claimedLabelName)
+	return h.rclusters.Update(released)
+}
+
+// unboundReferences requeues the clusters waiting for a management cluster that rCluster may match
+func (h *handler) unboundReferences(rCluster *v3.Cluster) ([]relatedresource.Key, error) {
+	clusters, err := h.clusters.Cache().List("", labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []relatedresource.Key
+	for _, cluster := range clusters {
+		ref := cluster.Spec.ReferencedConfig
+		if ref == nil || cluster.Status.ClusterName != "" || (ref.Name != "" && ref.Name != rCluster.Name) {
 			continue
 		}
-
-		_, err := h.clusters.Cache().Get(available.Labels[claimedLabelNamespace], available.Labels[claimedLabelName])
-		if apierror.IsNotFound(err) {
-			copy := available.DeepCopy()
-			delete(copy.Labels, claimedLabelNamespace)
-			delete(copy.Labels, claimedLabelName)
-			_, err := h.rclusters.Update(copy)
-			if err != nil {
-				return nil, err
+		if ref.Selector != nil {
+			sel, err := metav1.LabelSelectorAsSelector(ref.Selector)
+			if err != nil || !sel.Matches(labels.Set(rCluster.Labels)) {
+				continue
 			}
 		}
+		keys = append(keys, relatedresource.Key{
+			Namespace: cluster.Namespace,
+			Name:      cluster.Name,
+		})
+	}
+	return keys, nil
+}
+
+func describeReference(ref *v1.ReferencedConfig) string {
+	switch {
+	case ref.Name != "" && ref.Selector != nil:
+		return fmt.Sprintf("name %s and selector %s", ref.Name, metav1.FormatLabelSelector(ref.Selector))
+	case ref.Name != "":
+		return "name " + ref.Name
+	default:
+		return "selector " + metav1.FormatLabelSelector(ref.Selector)
+	}
+}
+
+// referenceableClusters returns the clusters namespace may reference and the number of the others
+func (h *handler) referenceableClusters(namespace string, clusters []*v3.Cluster) ([]*v3.Cluster, int, error) {
+	var result []*v3.Cluster
+	for _, cluster := range clusters {
+		ok, err := tenancy.CanReference(namespace, cluster, h.listGrants)
+		if err != nil {
+			return nil, 0, err
+		}
+		if ok {
+			result = append(result, cluster)
+		}
+	}
+	return result, len(clusters) - len(result), nil
+}
+
+func (h *handler) listGrants(namespace string) ([]*v1.ClusterReferenceGrant, error) {
+	return h.grantCache.List(namespace, labels.Everything())
+}
+
+// grantClusters returns every referenced cluster and every cluster replicating its kubeconfig when a grant changes,
+// the namespaces a grant named before it was narrowed or removed are not known
+func (h *handler) grantClusters(namespace, name string, obj runtime.Object) ([]relatedresource.Key, error) {
+	if _, ok := obj.(*v1.ClusterReferenceGrant); !ok && obj != nil {
+		return nil, nil
+	}
+
+	clusters, err := h.clusters.Cache().List("", labels.Everything())
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, fmt.Errorf("all clusters (%d) already claimed that match %s", len(available), cluster.Spec.ReferencedConfig.Selector)
+	var keys []relatedresource.Key
+	for _, cluster := range clusters {
+		if cluster.Spec.ReferencedConfig != nil || len(cluster.Spec.KubeConfigSecretNamespaces) > 0 {
+			keys = append(keys, relatedresource.Key{
+				Namespace: cluster.Namespace,
+				Name:      cluster.Name,
+			})
+		}
+	}
+	return keys, nil
 }
@@ -0,0 +1,103 @@
+package cluster
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/kubeconfig"
+	"github.com/rancher/wrangler/pkg/generic"
+)
+
+const (
+	holdDeletionAnnotation = "rancher.cattle.io/hold-deletion"
+)
+
+func (h *handler) onRemove(key string, cluster *v1.Cluster) (*v1.Cluster, error) {
+	held := cluster.Annotations[holdDeletionAnnotation] == "true"
+	message := "removing " + strings.Join(deletionPreview(cluster), ", ")
+	if held {
+		message = fmt.Sprintf("deletion held by %s annotation, %s", holdDeletionAnnotation, message)
+	}
+
+	if !v1.ClusterConditionRemoving.IsTrue(cluster) || v1.ClusterConditionRemoving.GetMessage(cluster) != message ||
+		cluster.Status.Phase != v1.ClusterPhaseDeleting {
+		cluster = cluster.DeepCopy()
+		cluster.Status.Phase = v1.ClusterPhaseDeleting
+		v1.ClusterConditionRemoving.True(cluster)
+		v1.ClusterConditionRemoving.Message(cluster, message)
+		updated, err := h.clusters.UpdateStatus(cluster)
+		if err != nil {
+			return cluster, err
+		}
+		cluster = updated
+	}
+
+	// Keep the finalizer while held, removing the annotation will trigger this handler again
+	if held {
+		return cluster, generic.ErrSkip
+	}
+
+	if remaining := h.deletionGracePeriod - time.Since(cluster.DeletionTimestamp.Time); remaining > 0 {
+		h.clusters.EnqueueAfter(cluster.Namespace, cluster.Name, remaining)
+		return cluster, generic.ErrSkip
+	}
+
+	if len(cluster.Spec.PreDeleteHooks) > 0 {
+		updated, err := h.runPreDeleteHooks(cluster)
+		if err != nil {
+			return updated, err
+		}
+		cluster = updated
+	}
+
+	if cluster.Spec.Decommission != nil {
+		updated, err := h.decommission(cluster)
+		if err != nil {
+			return updated, err
+		}
+		cluster = updated
+	}
+
+	if h.standalone {
+		if err := h.applyFleetSecret(cluster, "", nil); err != nil {
+			return cluster, err
+		}
+	}
+
+	return cluster, h.kubeconfigManager.DeleteUser(cluster.Namespace, cluster.Name)
+}
+
+// deletionPreview lists the objects that are removed once the cluster is gone
+func deletionPreview(cluster *v1.Cluster) []string {
+	var objs []string
+
+	if cluster.Spec.Decommission != nil {
+		objs = append(objs, "downstream resources selected by the decommission policy")
+	}
+
+	// referenced clusters are only claimed, never owned
+	if cluster.Spec.ReferencedConfig == nil && cluster.Status.ClusterName != "" {
+		objs = append(objs,
+			"management.cattle.io/v3 Cluster "+cluster.Status.ClusterName,
+			fmt.Sprintf("fleet.cattle.io/v1alpha1 Cluster %s/%s", cluster.Namespace, cluster.Status.ClusterName))
+	}
+
+	if cluster.Status.ClientSecretName != "" {
+		objs = append(objs, fmt.Sprintf("v1 Secret %s/%s", cluster.Namespace, cluster.Status.ClientSecretName))
+	}
+
+	if cluster.Status.RegistrationSecretName != "" {
+		objs = append(objs, fmt.Sprintf("v1 Secret %s/%s", cluster.Namespace, cluster.Status.RegistrationSecretName))
+	}
+
+	if cluster.Status.SSHKey != nil {
+		objs = append(objs, fmt.Sprintf("v1 Secret %s/%s", cluster.Namespace, cluster.Status.SSHKey.SecretName))
+	}
+
+	userName := kubeconfig.GetUserName(cluster.Namespace, cluster.Name)
+	return append(objs,
+		"management.cattle.io/v3 Token "+userName,
+		"management.cattle.io/v3 User "+userName)
+}
@@ -0,0 +1,87 @@
+package cluster
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Setting the reprovision annotation to <management cluster name>:<nonce> deletes the management cluster and
+// creates it again from the same spec, the name guards against copying the annotation to another cluster. Each
+// new nonce reprovisions once.
+const (
+	reprovisionAnnotation   = "rancher.cattle.io/reprovision"
+	reprovisionPollInterval = 5 * time.Second
+)
+
+// reprovisioning is true while the old management cluster is removed, nothing is applied until it is gone
+func reprovisioning(cluster *v1.Cluster) bool {
+	return v1.ClusterConditionReprovisioned.GetStatus(cluster) == "Unknown"
+}
+
+// onReprovision deletes the management cluster for a new reprovision request and revokes the kubeconfig, which
+// is generated again once the new management cluster is ready
+func (h *handler) onReprovision(key string, cluster *v1.Cluster) (*v1.Cluster, error) {
+	if cluster == nil || cluster.DeletionTimestamp != nil || cluster.Status.ClusterName == "" {
+		return cluster, nil
+	}
+
+	if reprovisioning(cluster) {
+		if _, err := h.rclusterCache.Get(cluster.Status.ClusterName); err == nil {
+			// Rancher removes the nodes first, the watch of v3 clusters doesn't requeue once it is gone
+			h.clusters.EnqueueAfter(cluster.Namespace, cluster.Name, reprovisionPollInterval)
+			return cluster, nil
+		} else if !apierror.IsNotFound(err) {
+			return cluster, err
+		}
+		h.recorder.Eventf(cluster, corev1.EventTypeNormal, "Reprovisioning", "Creating management cluster %s again", cluster.Status.ClusterName)
+		cluster = cluster.DeepCopy()
+		v1.ClusterConditionReprovisioned.True(cluster)
+		v1.ClusterConditionReprovisioned.Message(cluster, fmt.Sprintf("management cluster %s was deleted and is created again", cluster.Status.ClusterName))
+		return h.clusters.UpdateStatus(cluster)
+	}
+
+	request := cluster.Annotations[reprovisionAnnotation]
+	if request == "" || request == cluster.Status.Reprovision {
+		return cluster, nil
+	}
+
+	var reason string
+	switch {
+	case cluster.Spec.ImportedConfig != nil || cluster.Spec.ReferencedConfig != nil:
+		reason = "only clusters provisioned by Rancher can be reprovisioned"
+	case !strings.HasPrefix(request, cluster.Status.ClusterName+":") || len(request) == len(cluster.Status.ClusterName)+1:
+		reason = fmt.Sprintf("%s must be %s:<nonce>", reprovisionAnnotation, cluster.Status.ClusterName)
+	}
+	if reason != "" {
+		h.recorder.Eventf(cluster, corev1.EventTypeWarning, "ReprovisionRejected", "Not reprovisioning cluster %s, %s", cluster.Name, reason)
+		cluster = cluster.DeepCopy()
+		cluster.Status.Reprovision = request
+		v1.ClusterConditionReprovisioned.False(cluster)
+		v1.ClusterConditionReprovisioned.Message(cluster, reason)
+		return h.clusters.UpdateStatus(cluster)
+	}
+
+	// recorded first so the generating handler stops applying before the management cluster is deleted
+	cluster = cluster.DeepCopy()
+	cluster.Status.Reprovision = request
+	cluster.Status.Ready = false
+	cluster.Status.Phase = v1.ClusterPhaseProvisioning
+	v1.ClusterConditionReprovisioned.Unknown(cluster)
+	v1.ClusterConditionReprovisioned.Message(cluster, fmt.Sprintf("deleting management cluster %s", cluster.Status.ClusterName))
+	updated, err := h.clusters.UpdateStatus(cluster)
+	if err != nil {
+		return cluster, err
+	}
+	cluster = updated
+
+	h.recorder.Eventf(cluster, corev1.EventTypeWarning, "Reprovisioning", "Deleting management cluster %s to reprovision it", cluster.Status.ClusterName)
+	if err := h.rclusters.Delete(cluster.Status.ClusterName, nil); err != nil && !apierror.IsNotFound(err) {
+		return cluster, err
+	}
+	return cluster, h.kubeconfigManager.RevokeToken(cluster)
+}
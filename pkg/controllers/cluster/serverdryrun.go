@@ -0,0 +1,89 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/features"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	corev1 "k8s.io/api/core/v1"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var rClusterResource = v3.SchemeGroupVersion.WithResource("clusters")
+
+// dryRunApply sends the rendered management cluster to the API server as a server-side dry run before it is
+// applied. A spec that is rejected by validation or admission is reported in the SpecInvalid condition and the
+// last applied object is kept, so the apply doesn't fail on every reconcile until the cluster is changed.
+func (h *handler) dryRunApply(cluster *v1.Cluster, status v1.ClusterStatus, rClusterName string, data map[string]interface{}) ([]runtime.Object, v1.ClusterStatus, error) {
+	if !h.config.Get().Enabled(features.ServerDryRun) {
+		return []runtime.Object{&unstructured.Unstructured{Object: data}}, status, nil
+	}
+
+	existing, err := h.rclusterCache.Get(rClusterName)
+	if err != nil && !apierror.IsNotFound(err) {
+		return nil, status, err
+	}
+
+	clusters := h.dynamic.Resource(rClusterResource)
+	dryRun := []string{metav1.DryRunAll}
+	if existing == nil {
+		_, err = clusters.Create(context.TODO(), &unstructured.Unstructured{Object: data}, metav1.CreateOptions{DryRun: dryRun})
+	} else {
+		var patch []byte
+		if patch, err = json.Marshal(data); err != nil {
+			return nil, status, err
+		}
+		_, err = clusters.Patch(context.TODO(), rClusterName, types.MergePatchType, patch, metav1.PatchOptions{DryRun: dryRun})
+	}
+
+	if err != nil && !apierror.IsInvalid(err) && !apierror.IsBadRequest(err) {
+		return nil, status, err
+	} else if err == nil {
+		if v1.ClusterConditionSpecInvalid.GetStatus(&status) != "" {
+			v1.ClusterConditionSpecInvalid.False(&status)
+			v1.ClusterConditionSpecInvalid.Message(&status, "")
+		}
+		return []runtime.Object{&unstructured.Unstructured{Object: data}}, status, nil
+	}
+
+	message := rejection(err)
+	if v1.ClusterConditionSpecInvalid.GetMessage(&status) != message {
+		h.recorder.Eventf(cluster, corev1.EventTypeWarning, "SpecInvalid", "Management cluster %s was rejected: %s", rClusterName, message)
+	}
+	v1.ClusterConditionSpecInvalid.True(&status)
+	v1.ClusterConditionSpecInvalid.Message(&status, message)
+
+	if existing == nil {
+		return nil, status, nil
+	}
+	applied, err := appliedObject(existing)
+	if err != nil || applied == nil {
+		// without the last applied object returning none would prune the management cluster
+		return nil, status, newFailure(failureReasonInvalidConfiguration, fmt.Errorf("management cluster %s was rejected: %s", rClusterName, message))
+	}
+	return []runtime.Object{&unstructured.Unstructured{Object: applied}}, status, nil
+}
+
+// rejection describes why the API server rejected the management cluster. Only the fields and reasons of
+// validation errors are kept, their values may hold decrypted credentials.
+func rejection(err error) string {
+	var statusErr *apierror.StatusError
+	if !errors.As(err, &statusErr) || statusErr.ErrStatus.Details == nil || len(statusErr.ErrStatus.Details.Causes) == 0 {
+		return err.Error()
+	}
+
+	var causes []string
+	for _, cause := range statusErr.ErrStatus.Details.Causes {
+		causes = append(causes, fmt.Sprintf("%s %s", cause.Field, cause.Type))
+	}
+	return strings.Join(causes, ", ")
+}
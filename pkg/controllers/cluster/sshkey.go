@@ -0,0 +1,266 @@
+package cluster
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/sshkey"
+	"github.com/rancher/rancher-operator/pkg/tracing"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/wrangler/pkg/name"
+	corev1 "k8s.io/api/core/v1"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Changing this annotation to a new value, such as the current date, rotates the key of spec.sshKey once
+const rotateSSHKeyAnnotation = "rancher.cattle.io/rotate-ssh-key"
+
+const (
+	sshPublicKey          = "ssh-publickey"
+	nextSSHPrivateKey     = "next-ssh-privatekey"
+	nextSSHPublicKey      = "next-ssh-publickey"
+	sshKeyAuthorizePoll   = 10 * time.Second
+	sshKeyWaitingForReady = "waiting for the cluster to be ready to authorize the key on the nodes"
+)
+
+// onSSHKey generates the key of spec.sshKey and rotates it in two steps. The new key is added to the nodes next to
+// the current one first, once every node authorized it RKE is switched to it and the old key is removed from the
+// nodes. The nodes are never reprovisioned for it.
+func (h *handler) onSSHKey(key string, cluster *v1.Cluster) (*v1.Cluster, error) {
+	if cluster == nil || cluster.DeletionTimestamp != nil || cluster.Spec.RancherKubernetesEngineConfig == nil ||
+		(cluster.Spec.SSHKey == nil && cluster.Status.SSHKey == nil) {
+		return cluster, nil
+	}
+	if cluster.Spec.SSHKey == nil {
+		return h.removeSSHKey(cluster)
+	}
+
+	secret, err := h.sshKeySecret(cluster)
+	if err != nil {
+		return cluster, err
+	}
+
+	status := &v1.SSHKeyStatus{}
+	if cluster.Status.SSHKey != nil {
+		status = cluster.Status.SSHKey.DeepCopy()
+	}
+	if status.SecretName != secret.Name || status.RotatedAt == "" {
+		status.SecretName = secret.Name
+		status.RotatedAt = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	request := cluster.Annotations[rotateSSHKeyAnnotation]
+	if _, pending := secret.Data[nextSSHPublicKey]; !pending && ((request != "" && request != status.Rotation) || sshKeyRotationDue(cluster.Spec.SSHKey, status)) {
+		next, err := sshkey.Generate()
+		if err != nil {
+			return cluster, err
+		}
+		secret = secret.DeepCopy()
+		secret.Data[nextSSHPrivateKey] = next.PrivateKey
+		secret.Data[nextSSHPublicKey] = next.PublicKey
+		if secret, err = h.secrets.Update(secret); err != nil {
+			return cluster, err
+		}
+		status.Rotation = request
+		h.recorder.Eventf(cluster, corev1.EventTypeNormal, "RotatingSSHKey", "Authorizing SSH key %s on the nodes", next.Fingerprint)
+	}
+
+	publicKeys := []string{string(secret.Data[sshPublicKey])}
+	if next, ok := secret.Data[nextSSHPublicKey]; ok {
+		publicKeys = append(publicKeys, string(next))
+	}
+	var fingerprints []string
+	for _, publicKey := range publicKeys {
+		fingerprint, err := sshkey.Fingerprint([]byte(publicKey))
+		if err != nil {
+			return h.setSSHKeyStatus(cluster, status, "False", fmt.Sprintf("secret %s/%s: %v", secret.Namespace, secret.Name, err))
+		}
+		fingerprints = append(fingerprints, fingerprint)
+	}
+	status.Fingerprint = fingerprints[0]
+	status.PendingFingerprint = ""
+	if len(fingerprints) > 1 {
+		status.PendingFingerprint = fingerprints[1]
+	}
+
+	if !reflect.DeepEqual(status.AuthorizedFingerprints, fingerprints) {
+		if !cluster.Status.Ready || cluster.Status.ClientSecretName == "" {
+			return h.setSSHKeyStatus(cluster, status, "Unknown", sshKeyWaitingForReady)
+		}
+		done, err := h.authorizeSSHKeys(cluster, publicKeys)
+		if err != nil {
+			return cluster, err
+		}
+		if !done {
+			h.clusters.EnqueueAfter(cluster.Namespace, cluster.Name, sshKeyAuthorizePoll)
+			return h.setSSHKeyStatus(cluster, status, "Unknown", fmt.Sprintf("authorizing %v on the nodes", fingerprints))
+		}
+		status.AuthorizedFingerprints = fingerprints
+	}
+
+	// RKE is switched to the new key with the secret, the old key is removed from the nodes on the next change
+	if status.PendingFingerprint != "" {
+		secret = secret.DeepCopy()
+		secret.Data[corev1.SSHAuthPrivateKey] = secret.Data[nextSSHPrivateKey]
+		secret.Data[sshPublicKey] = secret.Data[nextSSHPublicKey]
+		delete(secret.Data, nextSSHPrivateKey)
+		delete(secret.Data, nextSSHPublicKey)
+		if _, err := h.secrets.Update(secret); err != nil {
+			return cluster, err
+		}
+		h.recorder.Eventf(cluster, corev1.EventTypeNormal, "SSHKeyRotated", "Using SSH key %s", status.PendingFingerprint)
+		status.Fingerprint = status.PendingFingerprint
+		status.PendingFingerprint = ""
+		status.RotatedAt = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	if interval := cluster.Spec.SSHKey.RotationIntervalHours; interval > 0 {
+		if rotatedAt, err := time.Parse(time.RFC3339, status.RotatedAt); err == nil {
+			h.clusters.EnqueueAfter(cluster.Namespace, cluster.Name, time.Until(rotatedAt.Add(time.Duration(interval)*time.Hour)))
+		}
+	}
+	return h.setSSHKeyStatus(cluster, status, "True", "")
+}
+
+func sshKeySecretName(cluster *v1.Cluster) string {
+	if cluster.Spec.SSHKey != nil && cluster.Spec.SSHKey.SecretName != "" {
+		return cluster.Spec.SSHKey.SecretName
+	}
+	return name.SafeConcatName(cluster.Name, "ssh-key")
+}
+
+// sshKeySecret returns the secret of the key, a missing secret is created with a new key owned by the cluster
+func (h *handler) sshKeySecret(cluster *v1.Cluster) (*corev1.Secret, error) {
+	// the secret is created without apply so it is only in the cache when applied by the user
+	secret, err := h.secretCache.Get(cluster.Namespace, sshKeySecretName(cluster))
+	if apierror.IsNotFound(err) {
+		secret, err = h.secrets.Get(cluster.Namespace, sshKeySecretName(cluster), metav1.GetOptions{})
+	}
+	if err == nil {
+		if _, ok := secret.Data[sshPublicKey]; !ok {
+			return nil, fmt.Errorf("key %s not found in SSH key secret %s/%s", sshPublicKey, secret.Namespace, secret.Name)
+		}
+		return secret, nil
+	} else if !apierror.IsNotFound(err) {
+		return nil, err
+	}
+
+	key, err := sshkey.Generate()
+	if err != nil {
+		return nil, err
+	}
+	h.recorder.Eventf(cluster, corev1.EventTypeNormal, "SSHKeyGenerated", "Generated SSH key %s", key.Fingerprint)
+	return h.secrets.Create(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      sshKeySecretName(cluster),
+			Namespace: cluster.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(cluster, v1.SchemeGroupVersion.WithKind("Cluster")),
+			},
+		},
+		Type: corev1.SecretTypeSSHAuth,
+		Data: map[string][]byte{
+			corev1.SSHAuthPrivateKey: key.PrivateKey,
+			sshPublicKey:             key.PublicKey,
+		},
+	})
+}
+
+func sshKeyRotationDue(config *v1.SSHKeyConfig, status *v1.SSHKeyStatus) bool {
+	if config.RotationIntervalHours <= 0 {
+		return false
+	}
+	rotatedAt, err := time.Parse(time.RFC3339, status.RotatedAt)
+	return err == nil && time.Since(rotatedAt) >= time.Duration(config.RotationIntervalHours)*time.Hour
+}
+
+func (h *handler) authorizeSSHKeys(cluster *v1.Cluster, publicKeys []string) (bool, error) {
+	image := cluster.Spec.AgentImageOverride
+	if image == "" {
+		var err error
+		if image, err = setting(h.settingCache, "agent-image"); err != nil {
+			return false, err
+		}
+	}
+	path := cluster.Spec.SSHKey.AuthorizedKeysPath
+	if path == "" {
+		path = sshkey.DefaultAuthorizedKeysPath
+	}
+
+	cfg, err := h.downstreamConfig(cluster)
+	if err != nil {
+		return false, err
+	}
+	var done bool
+	err = h.limiter.Do(cluster.Status.ClusterName, func() (err error) {
+		span := tracing.Start(cluster.Namespace, cluster.Name, "sshkey.authorize")
+		defer func() { tracing.End(span, err) }()
+		done, err = sshkey.Authorize(cfg, image, path, publicKeys)
+		return err
+	})
+	return done, err
+}
+
+// removeSSHKey stops syncing the authorized keys once spec.sshKey is removed, the keys and the secret are kept
+func (h *handler) removeSSHKey(cluster *v1.Cluster) (*v1.Cluster, error) {
+	if cluster.Status.Ready && cluster.Status.ClientSecretName != "" {
+		cfg, err := h.downstreamConfig(cluster)
+		if err != nil {
+			return cluster, err
+		}
+		err = h.limiter.Do(cluster.Status.ClusterName, func() (err error) {
+			span := tracing.Start(cluster.Namespace, cluster.Name, "sshkey.remove")
+			defer func() { tracing.End(span, err) }()
+			return sshkey.Remove(cfg)
+		})
+		if err != nil {
+			return cluster, err
+		}
+	}
+
+	cluster = cluster.DeepCopy()
+	cluster.Status.SSHKey = nil
+	v1.ClusterConditionSSHKeyAuthorized.SetStatus(cluster, "")
+	v1.ClusterConditionSSHKeyAuthorized.Message(cluster, "")
+	return h.clusters.UpdateStatus(cluster)
+}
+
+func (h *handler) setSSHKeyStatus(cluster *v1.Cluster, status *v1.SSHKeyStatus, conditionStatus, message string) (*v1.Cluster, error) {
+	if reflect.DeepEqual(cluster.Status.SSHKey, status) && v1.ClusterConditionSSHKeyAuthorized.GetStatus(cluster) == conditionStatus &&
+		v1.ClusterConditionSSHKeyAuthorized.GetMessage(cluster) == message {
+		return cluster, nil
+	}
+	cluster = cluster.DeepCopy()
+	cluster.Status.SSHKey = status
+	v1.ClusterConditionSSHKeyAuthorized.SetStatus(cluster, conditionStatus)
+	v1.ClusterConditionSSHKeyAuthorized.Message(cluster, message)
+	return h.clusters.UpdateStatus(cluster)
+}
+
+// sshKeyCredentials sets the private key of spec.sshKey on the nodes of the RKE config without a key of their own
+func (h *handler) sshKeyCredentials(cluster *v1.Cluster, spec v3.ClusterSpec) (v3.ClusterSpec, error) {
+	if cluster.Spec.SSHKey == nil || spec.RancherKubernetesEngineConfig == nil || len(spec.RancherKubernetesEngineConfig.Nodes) == 0 {
+		return spec, nil
+	}
+
+	secret, err := h.secretCache.Get(cluster.Namespace, sshKeySecretName(cluster))
+	if apierror.IsNotFound(err) {
+		secret, err = h.secrets.Get(cluster.Namespace, sshKeySecretName(cluster), metav1.GetOptions{})
+	}
+	if apierror.IsNotFound(err) {
+		return spec, fmt.Errorf("waiting for SSH key secret %s/%s", cluster.Namespace, sshKeySecretName(cluster))
+	} else if err != nil {
+		return spec, err
+	}
+
+	spec.RancherKubernetesEngineConfig = spec.RancherKubernetesEngineConfig.DeepCopy()
+	for i, node := range spec.RancherKubernetesEngineConfig.Nodes {
+		if node.SSHKey == "" && node.SSHKeyPath == "" {
+			spec.RancherKubernetesEngineConfig.Nodes[i].SSHKey = string(secret.Data[corev1.SSHAuthPrivateKey])
+		}
+	}
+	return spec, nil
+}
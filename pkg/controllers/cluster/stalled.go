@@ -0,0 +1,46 @@
+package cluster
+
+import (
+	"fmt"
+	"time"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/condition/kstatus"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// stalled reports whether the cluster has not been ready for longer than the provisioning timeout, otherwise it
+// checks again when the timeout would expire
+func (h *handler) stalled(cluster *v1.Cluster, status v1.ClusterStatus) (string, bool) {
+	timeout := h.provisioningTimeout
+	if cluster.Spec.ProvisioningTimeoutSeconds > 0 {
+		timeout = time.Duration(cluster.Spec.ProvisioningTimeoutSeconds) * time.Second
+	}
+	if timeout <= 0 {
+		return "", false
+	}
+
+	notReadySince := cluster.CreationTimestamp.Time
+	for _, cond := range status.Conditions {
+		if cond.Type != string(v1.ClusterConditionReady) {
+			continue
+		}
+		if cond.Status == "True" {
+			// it is becoming not ready now
+			notReadySince = time.Now()
+		} else if cond.LastTransitionTime.After(notReadySince) {
+			notReadySince = cond.LastTransitionTime.Time
+		}
+	}
+
+	if remaining := timeout - time.Since(notReadySince); remaining > 0 {
+		h.clusters.EnqueueAfter(cluster.Namespace, cluster.Name, remaining)
+		return "", false
+	}
+
+	message := fmt.Sprintf("cluster did not become ready within the provisioning timeout of %s", timeout)
+	if !kstatus.Stalled.IsTrue(cluster) {
+		h.recorder.Event(cluster, corev1.EventTypeWarning, "ProvisioningTimeout", message)
+	}
+	return message, true
+}
@@ -0,0 +1,26 @@
+package cluster
+
+import (
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// fleetSecretSetID is the apply set of the kubeconfig copied to the fleet workspace when the operator runs
+// standalone, fleet reads it from the cluster Rancher runs in
+const fleetSecretSetID = "cluster-fleet-kubeconfig"
+
+// applyFleetSecret copies secret to namespace in the cluster Rancher runs in, a nil secret prunes the copy
+func (h *handler) applyFleetSecret(cluster *v1.Cluster, namespace string, secret *corev1.Secret) error {
+	var objs []runtime.Object
+	if secret != nil && namespace != "" {
+		replica := secret.DeepCopy()
+		replica.Namespace = namespace
+		objs = append(objs, replica)
+	}
+	return h.managementApply.
+		WithOwner(cluster).
+		WithSetID(fleetSecretSetID).
+		WithGVK(corev1.SchemeGroupVersion.WithKind("Secret")).
+		ApplyObjects(objs...)
+}
@@ -0,0 +1,138 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/supportbundle"
+	"github.com/rancher/wrangler/pkg/name"
+	"github.com/rancher/wrangler/pkg/yaml"
+	corev1 "k8s.io/api/core/v1"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// Setting this annotation to any value gathers a support bundle into the <cluster>-support-bundle secret
+	supportBundleAnnotation = "rancher.cattle.io/support-bundle"
+	appliedAnnotation       = "objectset.rio.cattle.io/applied"
+)
+
+func (h *handler) onSupportBundle(key string, cluster *v1.Cluster) (*v1.Cluster, error) {
+	if cluster == nil || cluster.Annotations[supportBundleAnnotation] == "" {
+		return cluster, nil
+	}
+
+	files, err := h.supportBundleFiles(cluster)
+	if err != nil {
+		return cluster, err
+	}
+
+	data, err := supportbundle.Archive(cluster.Name, files)
+	if err != nil {
+		return cluster, err
+	}
+
+	// The bundle holds the live v3 cluster which can contain credentials, so it is stored in a secret
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name.SafeConcatName(cluster.Name, "support-bundle"),
+			Namespace: cluster.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(cluster, v1.SchemeGroupVersion.WithKind("Cluster")),
+			},
+		},
+		Data: map[string][]byte{
+			supportbundle.FileName: data,
+		},
+	}
+
+	existing, err := h.secrets.Get(secret.Namespace, secret.Name, metav1.GetOptions{})
+	if apierror.IsNotFound(err) {
+		_, err = h.secrets.Create(secret)
+	} else if err == nil {
+		existing = existing.DeepCopy()
+		existing.Data = secret.Data
+		_, err = h.secrets.Update(existing)
+	}
+	if err != nil {
+		return cluster, err
+	}
+
+	cluster = cluster.DeepCopy()
+	delete(cluster.Annotations, supportBundleAnnotation)
+	return h.clusters.Update(cluster)
+}
+
+func (h *handler) supportBundleFiles(cluster *v1.Cluster) (map[string][]byte, error) {
+	files := map[string][]byte{}
+
+	clusterYAML, err := yaml.ToBytes([]runtime.Object{cluster})
+	if err != nil {
+		return nil, err
+	}
+	files["cluster.yaml"] = clusterYAML
+
+	// Rendered without decrypting so ENC[] values stay encrypted in the bundle
+	merged, err := h.withClusterGroups(cluster)
+	if err != nil {
+		return nil, err
+	}
+	if spec, ok := rancherClusterSpec(merged); ok {
+		spec.FleetWorkspaceName = h.fleetWorkspaceName(merged)
+		_, data, err := renderCluster(merged, h.rancherClusterNameOrDefault(merged), spec)
+		if err != nil {
+			return nil, err
+		}
+		rendered, err := yaml.ToBytes([]runtime.Object{&unstructured.Unstructured{Object: data}})
+		if err != nil {
+			return nil, err
+		}
+		files["rendered-v3-cluster.yaml"] = rendered
+	}
+
+	if cluster.Status.ClusterName != "" {
+		live, err := h.rclusterCache.Get(cluster.Status.ClusterName)
+		if err == nil {
+			live = live.DeepCopy()
+			live.ManagedFields = nil
+			delete(live.Annotations, appliedAnnotation)
+			liveYAML, err := yaml.ToBytes([]runtime.Object{live})
+			if err != nil {
+				return nil, err
+			}
+			files["live-v3-cluster.yaml"] = liveYAML
+		} else if !apierror.IsNotFound(err) {
+			return nil, err
+		}
+	}
+
+	events, err := h.events.Events(cluster.Namespace).List(context.TODO(), metav1.ListOptions{
+		FieldSelector: fields.Set{
+			"involvedObject.kind": "Cluster",
+			"involvedObject.name": cluster.Name,
+		}.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var eventObjs []runtime.Object
+	for i := range events.Items {
+		eventObjs = append(eventObjs, &events.Items[i])
+	}
+	eventsYAML, err := yaml.ToBytes(eventObjs)
+	if err != nil {
+		return nil, err
+	}
+	files["events.yaml"] = eventsYAML
+
+	files["operator.log"] = supportbundle.Logs.Filter(
+		fmt.Sprintf("%s/%s", cluster.Namespace, cluster.Name),
+		cluster.Status.ClusterName)
+
+	return files, nil
+}
@@ -0,0 +1,169 @@
+package cluster
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	rketypes "github.com/rancher/rke/types"
+	corev1 "k8s.io/api/core/v1"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+// withUpgradeStrategy maps spec.upgradeStrategy to the upgrade strategy of the provider, fields it leaves empty
+// keep the value of the provider config
+func withUpgradeStrategy(spec v3.ClusterSpec, strategy *v1.UpgradeStrategy) v3.ClusterSpec {
+	if strategy == nil {
+		return spec
+	}
+
+	switch {
+	case spec.RancherKubernetesEngineConfig != nil:
+		spec.RancherKubernetesEngineConfig = spec.RancherKubernetesEngineConfig.DeepCopy()
+		rke := spec.RancherKubernetesEngineConfig
+		if rke.UpgradeStrategy == nil {
+			rke.UpgradeStrategy = &rketypes.NodeUpgradeStrategy{}
+		}
+		if strategy.MaxUnavailableControlPlane != "" {
+			rke.UpgradeStrategy.MaxUnavailableControlplane = strategy.MaxUnavailableControlPlane
+		}
+		if strategy.MaxUnavailableWorker != "" {
+			rke.UpgradeStrategy.MaxUnavailableWorker = strategy.MaxUnavailableWorker
+		}
+		if strategy.Drain != nil {
+			drain := true
+			rke.UpgradeStrategy.Drain = &drain
+			rke.UpgradeStrategy.DrainInput = drainInput(strategy.Drain)
+		}
+	case spec.K3sConfig != nil:
+		spec.K3sConfig = spec.K3sConfig.DeepCopy()
+		spec.K3sConfig.ClusterUpgradeStrategy = clusterUpgradeStrategy(spec.K3sConfig.ClusterUpgradeStrategy, strategy)
+	case spec.Rke2Config != nil:
+		spec.Rke2Config = spec.Rke2Config.DeepCopy()
+		spec.Rke2Config.ClusterUpgradeStrategy = clusterUpgradeStrategy(spec.Rke2Config.ClusterUpgradeStrategy, strategy)
+	}
+
+	return spec
+}
+
+// clusterUpgradeStrategy maps strategy for k3s and rke2, the node counts are validated by the webhook
+func clusterUpgradeStrategy(existing v3.ClusterUpgradeStrategy, strategy *v1.UpgradeStrategy) v3.ClusterUpgradeStrategy {
+	if n, err := strconv.Atoi(strategy.MaxUnavailableControlPlane); err == nil {
+		existing.ServerConcurrency = n
+	}
+	if n, err := strconv.Atoi(strategy.MaxUnavailableWorker); err == nil {
+		existing.WorkerConcurrency = n
+	}
+	if strategy.Drain != nil {
+		existing.DrainServerNodes = true
+		existing.DrainWorkerNodes = true
+	}
+	return existing
+}
+
+func specVersion(spec v3.ClusterSpec) string {
+	switch {
+	case spec.RancherKubernetesEngineConfig != nil:
+		return spec.RancherKubernetesEngineConfig.Version
+	case spec.EKSConfig != nil && spec.EKSConfig.KubernetesVersion != nil:
+		return *spec.EKSConfig.KubernetesVersion
+	case spec.K3sConfig != nil:
+		return spec.K3sConfig.Version
+	case spec.Rke2Config != nil:
+		return spec.Rke2Config.Version
+	}
+	return ""
+}
+
+func withVersion(spec v3.ClusterSpec, kubernetesVersion string) v3.ClusterSpec {
+	switch {
+	case spec.RancherKubernetesEngineConfig != nil:
+		spec.RancherKubernetesEngineConfig = spec.RancherKubernetesEngineConfig.DeepCopy()
+		spec.RancherKubernetesEngineConfig.Version = kubernetesVersion
+	case spec.EKSConfig != nil:
+		spec.EKSConfig = spec.EKSConfig.DeepCopy()
+		spec.EKSConfig.KubernetesVersion = &kubernetesVersion
+	case spec.K3sConfig != nil:
+		spec.K3sConfig = spec.K3sConfig.DeepCopy()
+		spec.K3sConfig.Version = kubernetesVersion
+	case spec.Rke2Config != nil:
+		spec.Rke2Config = spec.Rke2Config.DeepCopy()
+		spec.Rke2Config.Version = kubernetesVersion
+	}
+	return spec
+}
+
+// sameVersion compares the requested version, such as v1.20.4-rancher1-1, to the version reported by the cluster
+func sameVersion(requested, running string) bool {
+	r, err := version.ParseGeneric(requested)
+	if err != nil {
+		return requested == running
+	}
+	v, err := version.ParseGeneric(running)
+	if err != nil {
+		return false
+	}
+	return r.Major() == v.Major() && r.Minor() == v.Minor() && r.Patch() == v.Patch()
+}
+
+// upgrade holds a new kubernetes version back until a maintenance window opens and tracks the rollout in the
+// Upgrading and Upgraded conditions. New clusters are always created with the requested version.
+func (h *handler) upgrade(cluster *v1.Cluster, status v1.ClusterStatus, rClusterName string, spec v3.ClusterSpec) (v3.ClusterSpec, v1.ClusterStatus, error) {
+	requested := specVersion(spec)
+	if requested == "" {
+		return spec, status, nil
+	}
+
+	existing, err := h.rclusterCache.Get(rClusterName)
+	if apierror.IsNotFound(err) {
+		return spec, status, nil
+	} else if err != nil {
+		return spec, status, err
+	}
+
+	applied := specVersion(existing.Spec)
+	if applied != "" && applied != requested {
+		next, open, err := nextMaintenanceWindow(cluster.Spec.MaintenanceWindow, time.Now())
+		if err != nil {
+			return spec, status, err
+		}
+		if !open {
+			v1.ClusterConditionUpgrading.False(&status)
+			v1.ClusterConditionUpgrading.Message(&status, fmt.Sprintf("waiting for the maintenance window at %s to upgrade to %s",
+				next.Format(time.RFC3339), requested))
+			h.clusters.EnqueueAfter(cluster.Namespace, cluster.Name, time.Until(next)+time.Second)
+			return withVersion(spec, applied), status, nil
+		}
+	}
+
+	running := ""
+	if existing.Status.Version != nil {
+		running = existing.Status.Version.GitVersion
+	}
+	if running == "" {
+		return spec, status, nil
+	}
+
+	if sameVersion(requested, running) {
+		if v1.ClusterConditionUpgrading.IsTrue(&status) {
+			h.recorder.Eventf(cluster, corev1.EventTypeNormal, "Upgraded", "Cluster %s is upgraded to %s", rClusterName, running)
+		}
+		v1.ClusterConditionUpgrading.False(&status)
+		v1.ClusterConditionUpgrading.Message(&status, "")
+		v1.ClusterConditionUpgraded.True(&status)
+		v1.ClusterConditionUpgraded.Message(&status, "")
+		return spec, status, nil
+	}
+
+	if !v1.ClusterConditionUpgrading.IsTrue(&status) {
+		h.recorder.Eventf(cluster, corev1.EventTypeNormal, "Upgrading", "Upgrading cluster %s from %s to %s", rClusterName, running, requested)
+	}
+	v1.ClusterConditionUpgrading.True(&status)
+	v1.ClusterConditionUpgrading.Message(&status, fmt.Sprintf("upgrading from %s to %s", running, requested))
+	v1.ClusterConditionUpgraded.False(&status)
+	v1.ClusterConditionUpgraded.Message(&status, "")
+	return spec, status, nil
+}
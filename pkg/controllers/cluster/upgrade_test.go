@@ -0,0 +1,30 @@
+package cluster
+
+import (
+	"testing"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	rketypes "github.com/rancher/rke/types"
+)
+
+func TestWithUpgradeStrategyKeepsDrain(t *testing.T) {
+	drain := true
+	spec := v3.ClusterSpec{}
+	spec.RancherKubernetesEngineConfig = &rketypes.RancherKubernetesEngineConfig{
+		UpgradeStrategy: &rketypes.NodeUpgradeStrategy{Drain: &drain},
+	}
+
+	spec = withUpgradeStrategy(spec, &v1.UpgradeStrategy{MaxUnavailableWorker: "2"})
+	if got := spec.RancherKubernetesEngineConfig.UpgradeStrategy; got.Drain == nil || !*got.Drain || got.MaxUnavailableWorker != "2" {
+		t.Errorf("got drain %v and max unavailable workers %q, want the drain of the provider config kept", got.Drain, got.MaxUnavailableWorker)
+	}
+
+	k3s := v3.ClusterSpec{}
+	k3s.K3sConfig = &v3.K3sConfig{}
+	k3s.K3sConfig.ClusterUpgradeStrategy.DrainWorkerNodes = true
+	k3s = withUpgradeStrategy(k3s, &v1.UpgradeStrategy{MaxUnavailableWorker: "2"})
+	if got := k3s.K3sConfig.ClusterUpgradeStrategy; !got.DrainWorkerNodes || got.WorkerConcurrency != 2 {
+		t.Errorf("got drain %v and worker concurrency %d, want the drain of the provider config kept", got.DrainWorkerNodes, got.WorkerConcurrency)
+	}
+}
@@ -0,0 +1,50 @@
+package cluster
+
+import (
+	"time"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/smoketest"
+	"github.com/rancher/rancher-operator/pkg/tracing"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	smokeTestPollInterval  = 5 * time.Second
+	smokeTestRetryInterval = time.Minute
+)
+
+// verify runs the smoke test against the cluster using the generated kubeconfig, the cluster is only
+// considered Verified once the test passed
+func (h *handler) verify(cluster *v1.Cluster, status v1.ClusterStatus, secret *corev1.Secret) (v1.ClusterStatus, error) {
+	cfg, err := clientcmd.RESTConfigFromKubeConfig(secret.Data["value"])
+	if err != nil {
+		return status, err
+	}
+
+	var result smoketest.Result
+	err = h.limiter.Do(status.ClusterName, func() (err error) {
+		span := tracing.Start(cluster.Namespace, cluster.Name, "smoketest.run")
+		defer func() { tracing.End(span, err) }()
+		result, err = smoketest.Run(cfg, cluster.Spec.SmokeTest.Image)
+		return err
+	})
+	if err != nil {
+		return status, err
+	}
+
+	switch {
+	case result.Passed:
+		v1.ClusterConditionVerified.True(&status)
+	case result.Done:
+		v1.ClusterConditionVerified.False(&status)
+		h.clusters.EnqueueAfter(cluster.Namespace, cluster.Name, smokeTestRetryInterval)
+	default:
+		v1.ClusterConditionVerified.Unknown(&status)
+		h.clusters.EnqueueAfter(cluster.Namespace, cluster.Name, smokeTestPollInterval)
+	}
+	v1.ClusterConditionVerified.Message(&status, result.Message)
+
+	return status, nil
+}
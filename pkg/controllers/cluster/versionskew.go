@@ -0,0 +1,76 @@
+package cluster
+
+import (
+	"fmt"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/logging"
+	"github.com/rancher/rancher-operator/pkg/options"
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+// supportedMinorSkew is how many minor versions a downstream cluster may be ahead of the management cluster
+const supportedMinorSkew = 1
+
+func requestedVersion(cluster *v1.Cluster) string {
+	switch {
+	case cluster.Spec.RancherKubernetesEngineConfig != nil:
+		return cluster.Spec.RancherKubernetesEngineConfig.Version
+	case cluster.Spec.EKSConfig != nil && cluster.Spec.EKSConfig.KubernetesVersion != nil:
+		return *cluster.Spec.EKSConfig.KubernetesVersion
+	case cluster.Spec.K3SConfig != nil:
+		return cluster.Spec.K3SConfig.Version
+	case cluster.Spec.RKE2Config != nil:
+		return cluster.Spec.RKE2Config.Version
+	}
+	return ""
+}
+
+// checkVersionSkew compares the requested downstream version to the management cluster. With the warn policy the
+// result is only reported in the VersionSupported condition, with the enforce policy an error is returned so the
+// cluster is not applied.
+func (h *handler) checkVersionSkew(cluster *v1.Cluster, status v1.ClusterStatus) (v1.ClusterStatus, error) {
+	if h.versionSkewPolicy == "" {
+		return status, nil
+	}
+
+	requested := requestedVersion(cluster)
+	if requested == "" {
+		// rancher picks the default version which is always supported
+		v1.ClusterConditionVersionSupported.True(&status)
+		v1.ClusterConditionVersionSupported.Message(&status, "")
+		return status, nil
+	}
+
+	downstream, err := version.ParseGeneric(requested)
+	if err != nil {
+		return status, newFailure(failureReasonInvalidConfiguration,
+			fmt.Errorf("invalid kubernetes version %q: %w", requested, err))
+	}
+
+	info, err := h.discovery.ServerVersion()
+	if err != nil {
+		return status, err
+	}
+	management, err := version.ParseGeneric(info.GitVersion)
+	if err != nil {
+		return status, err
+	}
+
+	if downstream.Major() == management.Major() && downstream.Minor() <= management.Minor()+supportedMinorSkew {
+		v1.ClusterConditionVersionSupported.True(&status)
+		v1.ClusterConditionVersionSupported.Message(&status, "")
+		return status, nil
+	}
+
+	msg := fmt.Sprintf("kubernetes version %s exceeds the supported skew of %d minor version(s) from the management cluster version %s",
+		requested, supportedMinorSkew, info.GitVersion)
+	if h.versionSkewPolicy == options.VersionSkewPolicyEnforce {
+		return status, newFailure(failureReasonUnsupportedVersion, fmt.Errorf("%s", msg))
+	}
+
+	logging.ForObject(cluster.Namespace, cluster.Name).Warn(msg)
+	v1.ClusterConditionVersionSupported.False(&status)
+	v1.ClusterConditionVersionSupported.Message(&status, msg)
+	return status, nil
+}
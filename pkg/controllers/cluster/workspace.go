@@ -0,0 +1,37 @@
+package cluster
+
+import (
+	"fmt"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/tenancy"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// fleetWorkspaceName returns the fleet workspace of cluster, spec.fleetWorkspaceName or the workspace the operator
+// maps its namespace to, the namespace name otherwise
+func (h *handler) fleetWorkspaceName(cluster *v1.Cluster) string {
+	if cluster.Spec.FleetWorkspaceName != "" {
+		return cluster.Spec.FleetWorkspaceName
+	}
+	return tenancy.MappedFleetWorkspace(cluster.Namespace, h.fleetWorkspaces)
+}
+
+// validFleetWorkspaceName is fleetWorkspaceName, failing if a workspace that was chosen explicitly is not allowed for
+// the namespace of cluster or does not exist. Changing it moves the fleet cluster, it is recreated in the new
+// workspace and pruned from the old one.
+func (h *handler) validFleetWorkspaceName(cluster *v1.Cluster) (string, error) {
+	workspace := h.fleetWorkspaceName(cluster)
+	if workspace == cluster.Namespace {
+		return workspace, nil
+	}
+	if !tenancy.CanUseFleetWorkspace(cluster.Namespace, workspace, h.fleetWorkspaces, h.allowedWorkspaces) {
+		return "", newFailure(failureReasonInvalidConfiguration, fmt.Errorf("fleet workspace %s is not allowed for clusters of namespace %s", workspace, cluster.Namespace))
+	}
+
+	_, err := h.workspaceCache.Get(workspace)
+	if apierror.IsNotFound(err) {
+		return "", newFailure(failureReasonInvalidConfiguration, fmt.Errorf("fleet workspace %s does not exist", workspace))
+	}
+	return workspace, err
+}
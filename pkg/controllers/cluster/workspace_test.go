@@ -0,0 +1,54 @@
+package cluster
+
+import (
+	"errors"
+	"testing"
+
+	mgmtcontrollers "github.com/rancher/rancher-operator/pkg/generated/controllers/management.cattle.io/v3"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// fakeWorkspaceCache has every workspace
+type fakeWorkspaceCache struct {
+	mgmtcontrollers.FleetWorkspaceCache
+}
+
+func (f *fakeWorkspaceCache) Get(name string) (*v3.FleetWorkspace, error) {
+	return &v3.FleetWorkspace{ObjectMeta: metav1.ObjectMeta{Name: name}}, nil
+}
+
+func TestValidFleetWorkspaceName(t *testing.T) {
+	h := &handler{
+		workspaceCache:    &fakeWorkspaceCache{},
+		fleetWorkspaces:   map[string]string{"fleet-default": "mapped"},
+		allowedWorkspaces: []string{"shared"},
+	}
+
+	tests := []struct {
+		workspace string
+		want      string
+		allowed   bool
+	}{
+		{"", "mapped", true},
+		{"fleet-default", "fleet-default", true},
+		{"mapped", "mapped", true},
+		{"shared", "shared", true},
+		{"fleet-local", "", false},
+	}
+	for _, tt := range tests {
+		cluster := testCluster()
+		cluster.Spec.FleetWorkspaceName = tt.workspace
+
+		got, err := h.validFleetWorkspaceName(cluster)
+		var f *failure
+		switch {
+		case tt.allowed && err != nil:
+			t.Errorf("workspace %q: %v", tt.workspace, err)
+		case !tt.allowed && (!errors.As(err, &f) || f.reason != failureReasonInvalidConfiguration):
+			t.Errorf("workspace %q: got error %v, want InvalidConfiguration", tt.workspace, err)
+		case got != tt.want:
+			t.Errorf("workspace %q: got %q, want %q", tt.workspace, got, tt.want)
+		}
+	}
+}
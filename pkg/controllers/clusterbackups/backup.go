@@ -0,0 +1,284 @@
+package clusterbackups
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/controllers/cluster"
+	"github.com/rancher/rancher-operator/pkg/supportbundle"
+	"github.com/rancher/wrangler/pkg/yaml"
+	corev1 "k8s.io/api/core/v1"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type object interface {
+	runtime.Object
+	metav1.Object
+}
+
+// archive collects the backed up objects as <kind>/<name>.yaml
+type archive struct {
+	files   map[string][]byte
+	objects []string
+}
+
+// add writes obj without its status and the metadata that only applies to this environment, so the objects can
+// be created in any namespace
+func (a *archive) add(gvk schema.GroupVersionKind, obj object) error {
+	key := gvk.Kind + "/" + obj.GetName()
+	if _, ok := a.files[key+".yaml"]; ok {
+		return nil
+	}
+
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+	obj.SetNamespace("")
+	obj.SetUID("")
+	obj.SetResourceVersion("")
+	obj.SetGeneration(0)
+	obj.SetCreationTimestamp(metav1.Time{})
+	obj.SetSelfLink("")
+	obj.SetManagedFields(nil)
+	obj.SetOwnerReferences(nil)
+	obj.SetFinalizers(nil)
+
+	data, err := yaml.ToBytes([]runtime.Object{obj})
+	if err != nil {
+		return err
+	}
+	a.files[key+".yaml"] = data
+	a.objects = append(a.objects, key)
+	return nil
+}
+
+// backup archives the cluster with the projects and role template bindings that select it, its node pools and their
+// node templates, and the config maps and secrets the cluster references. Encrypted values stay encrypted, and the
+// cloud credentials of node templates are kept in Rancher.
+func (h *handler) backup(backup *v1.ClusterBackup) ([]string, string, error) {
+	target, err := h.clusters.Get(backup.Namespace, backup.Spec.ClusterName)
+	if apierror.IsNotFound(err) {
+		return nil, fmt.Sprintf("waiting for cluster %s", backup.Spec.ClusterName), nil
+	} else if err != nil {
+		return nil, "", err
+	}
+
+	a := &archive{
+		files: map[string][]byte{},
+	}
+	obj := target.DeepCopy()
+	obj.Status = v1.ClusterStatus{}
+	if err := a.add(v1.SchemeGroupVersion.WithKind("Cluster"), obj); err != nil {
+		return nil, "", err
+	}
+
+	projects, err := h.projects.List(backup.Namespace, labels.Everything())
+	if err != nil {
+		return nil, "", err
+	}
+	var selected []*v1.Project
+	for _, project := range projects {
+		if project.Spec.ClusterName != target.Name && !selects(project.Spec.ClusterSelector, target) {
+			continue
+		}
+		selected = append(selected, project)
+		project = project.DeepCopy()
+		project.Status = v1.ProjectStatus{}
+		if err := a.add(v1.SchemeGroupVersion.WithKind("Project"), project); err != nil {
+			return nil, "", err
+		}
+	}
+
+	bindings, err := h.bindings.List(backup.Namespace, labels.Everything())
+	if err != nil {
+		return nil, "", err
+	}
+	for _, binding := range bindings {
+		if !bindsTo(binding.BindingScope, target, selected) {
+			continue
+		}
+		binding = binding.DeepCopy()
+		binding.Status = v1.RoleTemplateBindingStatus{}
+		if err := a.add(v1.SchemeGroupVersion.WithKind("RoleTemplateBinding"), binding); err != nil {
+			return nil, "", err
+		}
+	}
+
+	pools, err := h.pools.List(backup.Namespace, labels.Everything())
+	if err != nil {
+		return nil, "", err
+	}
+	for _, pool := range pools {
+		if pool.Spec.ClusterName != target.Name {
+			continue
+		}
+		if !strings.Contains(pool.Spec.NodeTemplateName, ":") {
+			template, err := h.templates.Get(backup.Namespace, pool.Spec.NodeTemplateName)
+			if err != nil && !apierror.IsNotFound(err) {
+				return nil, "", err
+			}
+			if err == nil {
+				template = template.DeepCopy()
+				template.Status = v1.NodeTemplateStatus{}
+				if err := a.add(v1.SchemeGroupVersion.WithKind("NodeTemplate"), template); err != nil {
+					return nil, "", err
+				}
+			}
+		}
+		pool = pool.DeepCopy()
+		pool.Status = v1.NodePoolStatus{}
+		if err := a.add(v1.SchemeGroupVersion.WithKind("NodePool"), pool); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if err := h.addReferences(a, target); err != nil {
+		return nil, "", err
+	}
+
+	data, err := supportbundle.Archive(backup.Name, a.files)
+	if err != nil {
+		return nil, "", err
+	}
+	if backup.Spec.UploadURLSecretName != "" {
+		location, message, err := h.secretURL(backup.Namespace, backup.Spec.UploadURLSecretName)
+		if err != nil || message != "" {
+			return nil, message, err
+		}
+		if _, err := transfer(http.MethodPut, location, data); err != nil {
+			return nil, "", err
+		}
+		return a.objects, "", nil
+	}
+	message, err := h.writeSecret(backup, data)
+	return a.objects, message, err
+}
+
+// addReferences adds the config maps and secrets of the cluster that exist
+func (h *handler) addReferences(a *archive, target *v1.Cluster) error {
+	refs := cluster.References(target)
+	addSecret := func(name string) {
+		refs = append(refs, fmt.Sprintf("%s/Secret/%s", target.Namespace, name))
+	}
+	if target.Spec.ImportedConfig != nil && target.Spec.ImportedConfig.KubeConfigSecret != "" {
+		addSecret(target.Spec.ImportedConfig.KubeConfigSecret)
+	}
+	if schedule := target.Spec.EtcdSnapshotSchedule; schedule != nil && schedule.S3 != nil && schedule.S3.CredentialsSecretName != "" {
+		addSecret(schedule.S3.CredentialsSecretName)
+	}
+	if bundle := target.Spec.KubeConfigCABundle; bundle != nil {
+		if bundle.SecretKeyRef != nil {
+			addSecret(bundle.SecretKeyRef.Name)
+		}
+		if bundle.ConfigMapKeyRef != nil {
+			refs = append(refs, fmt.Sprintf("%s/ConfigMap/%s", target.Namespace, bundle.ConfigMapKeyRef.Name))
+		}
+	}
+
+	for _, ref := range refs {
+		parts := strings.SplitN(ref, "/", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		kind, name := parts[1], parts[2]
+
+		switch kind {
+		case "ConfigMap":
+			configMap, err := h.configMapCache.Get(target.Namespace, name)
+			if apierror.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return err
+			}
+			if err := a.add(corev1.SchemeGroupVersion.WithKind(kind), &corev1.ConfigMap{
+				ObjectMeta: *configMap.ObjectMeta.DeepCopy(),
+				Data:       configMap.Data,
+				BinaryData: configMap.BinaryData,
+			}); err != nil {
+				return err
+			}
+		case "Secret":
+			// referenced secrets aren't cached
+			secret, err := h.secrets.Get(target.Namespace, name, metav1.GetOptions{})
+			if apierror.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return err
+			}
+			if err := a.add(corev1.SchemeGroupVersion.WithKind(kind), &corev1.Secret{
+				ObjectMeta: secret.ObjectMeta,
+				Type:       secret.Type,
+				Data:       secret.Data,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeSecret writes the tarball to the secret of the backup, which is owned by the backup
+func (h *handler) writeSecret(backup *v1.ClusterBackup, data []byte) (string, error) {
+	_, err := h.secrets.Create(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      backup.Name,
+			Namespace: backup.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(backup, v1.SchemeGroupVersion.WithKind("ClusterBackup")),
+			},
+		},
+		Data: map[string][]byte{
+			v1.ClusterBackupKey: data,
+		},
+	})
+	if !apierror.IsAlreadyExists(err) {
+		return "", err
+	}
+
+	// an existing secret of this backup was written by an earlier attempt whose status update failed
+	existing, err := h.secrets.Get(backup.Namespace, backup.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	if !metav1.IsControlledBy(existing, backup) {
+		return fmt.Sprintf("secret %s already exists", backup.Name), nil
+	}
+	existing = existing.DeepCopy()
+	existing.Data = map[string][]byte{
+		v1.ClusterBackupKey: data,
+	}
+	_, err = h.secrets.Update(existing)
+	return "", err
+}
+
+// selects is false for invalid selectors, the controllers of the selecting objects report them
+func selects(selector *metav1.LabelSelector, obj metav1.Object) bool {
+	if selector == nil {
+		return false
+	}
+	sel, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false
+	}
+	return sel.Matches(labels.Set(obj.GetLabels()))
+}
+
+// bindsTo is true for bindings of the cluster and of the selected projects. A binding that selects more clusters
+// or projects is backed up as is.
+func bindsTo(scope v1.RoleTemplateBindingScope, target *v1.Cluster, projects []*v1.Project) bool {
+	switch scope.Kind {
+	case "Cluster":
+		return scope.Name == target.Name || selects(scope.Selector, target)
+	case "Project":
+		for _, project := range projects {
+			if scope.Name == project.Name || selects(scope.Selector, project) {
+				return true
+			}
+		}
+	}
+	return false
+}
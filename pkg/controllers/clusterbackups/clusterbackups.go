@@ -0,0 +1,216 @@
+package clusterbackups
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/clients"
+	rocontrollers "github.com/rancher/rancher-operator/pkg/generated/controllers/rancher.cattle.io/v1"
+	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
+	"github.com/rancher/wrangler/pkg/relatedresource"
+	corev1 "k8s.io/api/core/v1"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	byCluster = "by-cluster"
+	bySecret  = "by-secret"
+
+	transferTimeout = 5 * time.Minute
+)
+
+type handler struct {
+	backups        rocontrollers.ClusterBackupCache
+	clusters       rocontrollers.ClusterCache
+	projects       rocontrollers.ProjectCache
+	bindings       rocontrollers.RoleTemplateBindingCache
+	pools          rocontrollers.NodePoolCache
+	templates      rocontrollers.NodeTemplateCache
+	configMapCache corecontrollers.ConfigMapCache
+	secrets        corecontrollers.SecretClient
+	dynamic        dynamic.Interface
+}
+
+// Register the controller that backs up the definition of a cluster into a tarball and restores the objects of a
+// tarball, so a cluster can be moved to the operator of another management environment
+func Register(ctx context.Context, clients *clients.Clients) {
+	h := handler{
+		backups:        clients.ClusterBackup().Cache(),
+		clusters:       clients.Cluster().Cache(),
+		projects:       clients.Project().Cache(),
+		bindings:       clients.RoleTemplateBinding().Cache(),
+		pools:          clients.NodePool().Cache(),
+		templates:      clients.NodeTemplate().Cache(),
+		configMapCache: clients.Core.ConfigMap().Cache(),
+		secrets:        clients.Core.Secret(),
+		dynamic:        dynamic.NewForConfigOrDie(clients.RESTConfig),
+	}
+
+	h.backups.AddIndexer(byCluster, func(obj *v1.ClusterBackup) ([]string, error) {
+		if obj.Spec.ClusterName == "" {
+			return nil, nil
+		}
+		return []string{obj.Namespace + "/" + obj.Spec.ClusterName}, nil
+	})
+	h.backups.AddIndexer(bySecret, func(obj *v1.ClusterBackup) ([]string, error) {
+		var keys []string
+		for _, name := range secretNames(obj.Spec) {
+			keys = append(keys, obj.Namespace+"/"+name)
+		}
+		return keys, nil
+	})
+
+	rocontrollers.RegisterClusterBackupStatusHandler(ctx,
+		clients.ClusterBackup(),
+		"",
+		"cluster-backup",
+		h.onBackup)
+
+	relatedresource.Watch(ctx, "cluster-backup-watch", h.pendingBackups, clients.ClusterBackup(),
+		clients.Cluster(), clients.Core.Secret())
+}
+
+// secretNames are the secrets the backup reads its URL or tarball from
+func secretNames(spec v1.ClusterBackupSpec) []string {
+	var names []string
+	if spec.UploadURLSecretName != "" {
+		names = append(names, spec.UploadURLSecretName)
+	}
+	if spec.Restore != nil {
+		for _, name := range []string{spec.Restore.SecretName, spec.Restore.DownloadURLSecretName} {
+			if name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// pendingBackups requeues the backups that didn't complete once their cluster or secrets exist
+func (h *handler) pendingBackups(namespace, name string, obj runtime.Object) ([]relatedresource.Key, error) {
+	var (
+		backups []*v1.ClusterBackup
+		err     error
+	)
+	switch obj.(type) {
+	case *v1.Cluster:
+		backups, err = h.backups.GetByIndex(byCluster, namespace+"/"+name)
+	case *corev1.Secret:
+		backups, err = h.backups.GetByIndex(bySecret, namespace+"/"+name)
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []relatedresource.Key
+	for _, backup := range backups {
+		if backup.Status.CompletedAt != "" {
+			continue
+		}
+		keys = append(keys, relatedresource.Key{
+			Namespace: backup.Namespace,
+			Name:      backup.Name,
+		})
+	}
+	return keys, nil
+}
+
+func (h *handler) onBackup(backup *v1.ClusterBackup, status v1.ClusterBackupStatus) (v1.ClusterBackupStatus, error) {
+	status.ObservedGeneration = backup.Generation
+	if status.CompletedAt != "" {
+		return status, nil
+	}
+
+	var (
+		objects []string
+		message string
+		err     error
+	)
+	switch {
+	case backup.Spec.Restore != nil && backup.Spec.ClusterName != "":
+		return notCompleted(status, "only one of spec.clusterName and spec.restore can be set"), nil
+	case backup.Spec.Restore != nil:
+		objects, message, err = h.restore(backup)
+	case backup.Spec.ClusterName != "":
+		objects, message, err = h.backup(backup)
+	default:
+		return notCompleted(status, "one of spec.clusterName and spec.restore is required"), nil
+	}
+	if err != nil {
+		return status, err
+	}
+	if message != "" {
+		return notCompleted(status, message), nil
+	}
+
+	status.Objects = objects
+	status.CompletedAt = time.Now().UTC().Format(time.RFC3339)
+	v1.ClusterBackupConditionCompleted.True(&status)
+	v1.ClusterBackupConditionCompleted.Message(&status, "")
+	return status, nil
+}
+
+func notCompleted(status v1.ClusterBackupStatus, message string) v1.ClusterBackupStatus {
+	v1.ClusterBackupConditionCompleted.False(&status)
+	v1.ClusterBackupConditionCompleted.Message(&status, message)
+	return status
+}
+
+// secretURL returns the url key of the secret, or a message while it is missing
+func (h *handler) secretURL(namespace, name string) (string, string, error) {
+	secret, err := h.secrets.Get(namespace, name, metav1.GetOptions{})
+	if apierror.IsNotFound(err) {
+		return "", fmt.Sprintf("waiting for secret %s", name), nil
+	} else if err != nil {
+		return "", "", err
+	}
+	location := string(secret.Data["url"])
+	if location == "" {
+		return "", fmt.Sprintf("secret %s has no url key", name), nil
+	}
+	return location, "", nil
+}
+
+// transfer puts body to location, or gets location without a body. Request failures are returned as errors so the
+// backup is retried, they leave out the location since presigned URLs hold credentials.
+func transfer(method, location string, body []byte) ([]byte, error) {
+	req, err := http.NewRequest(method, location, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("invalid url: %w", withoutURL(err))
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/gzip")
+	}
+
+	client := http.Client{
+		Timeout: transferTimeout,
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s of the backup failed: %w", method, withoutURL(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("%s of the backup returned %s", method, resp.Status)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+func withoutURL(err error) error {
+	if urlErr, ok := err.(*url.Error); ok {
+		return urlErr.Err
+	}
+	return err
+}
@@ -0,0 +1,183 @@
+package clusterbackups
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/wrangler/pkg/yaml"
+	corev1 "k8s.io/api/core/v1"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// restoreOrder creates the referenced objects before the objects that reference them
+var restoreOrder = []struct {
+	gvk      schema.GroupVersionKind
+	resource schema.GroupVersionResource
+}{
+	{corev1.SchemeGroupVersion.WithKind("Secret"), corev1.SchemeGroupVersion.WithResource("secrets")},
+	{corev1.SchemeGroupVersion.WithKind("ConfigMap"), corev1.SchemeGroupVersion.WithResource("configmaps")},
+	{v1.SchemeGroupVersion.WithKind("NodeTemplate"), v1.SchemeGroupVersion.WithResource(v1.NodeTemplateResourceName)},
+	{v1.SchemeGroupVersion.WithKind("Cluster"), v1.SchemeGroupVersion.WithResource(v1.ClusterResourceName)},
+	{v1.SchemeGroupVersion.WithKind("Project"), v1.SchemeGroupVersion.WithResource(v1.ProjectResourceName)},
+	{v1.SchemeGroupVersion.WithKind("NodePool"), v1.SchemeGroupVersion.WithResource(v1.NodePoolResourceName)},
+	{v1.SchemeGroupVersion.WithKind("RoleTemplateBinding"), v1.SchemeGroupVersion.WithResource(v1.RoleTemplateBindingResourceName)},
+}
+
+// restore creates the objects of the tarball in the namespace of the backup. Objects that exist are only updated
+// with spec.restore.overwrite or when an earlier attempt of this restore created them. The clusters are provisioned
+// or imported again by the operator and Rancher of this environment.
+func (h *handler) restore(backup *v1.ClusterBackup) ([]string, string, error) {
+	data, message, err := h.restoreData(backup)
+	if err != nil || message != "" {
+		return nil, message, err
+	}
+
+	files, err := extract(data)
+	if err != nil {
+		return nil, fmt.Sprintf("reading the backup: %v", err), nil
+	}
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var objs []*unstructured.Unstructured
+	order := map[*unstructured.Unstructured]int{}
+	for _, name := range names {
+		parsed, err := yaml.ToObjects(bytes.NewReader(files[name]))
+		if err != nil {
+			return nil, fmt.Sprintf("reading %s of the backup: %v", name, err), nil
+		}
+		for _, decoded := range parsed {
+			obj, ok := decoded.(*unstructured.Unstructured)
+			if !ok {
+				continue
+			}
+			i := kindOrder(obj.GroupVersionKind())
+			if i < 0 {
+				return nil, fmt.Sprintf("%s of the backup has the unsupported kind %s", name, obj.GroupVersionKind()), nil
+			}
+			objs = append(objs, obj)
+			order[obj] = i
+		}
+	}
+	sort.SliceStable(objs, func(i, j int) bool {
+		return order[objs[i]] < order[objs[j]]
+	})
+
+	var objects []string
+	for _, obj := range objs {
+		key := obj.GetKind() + "/" + obj.GetName()
+		if message, err := h.restoreObject(backup, restoreOrder[order[obj]].resource, obj); err != nil || message != "" {
+			return nil, message, err
+		}
+		objects = append(objects, key)
+	}
+	return objects, "", nil
+}
+
+// restoreData returns the tarball from the secret or URL of spec.restore
+func (h *handler) restoreData(backup *v1.ClusterBackup) ([]byte, string, error) {
+	restore := backup.Spec.Restore
+	switch {
+	case restore.SecretName != "" && restore.DownloadURLSecretName != "":
+		return nil, "only one of spec.restore.secretName and spec.restore.downloadURLSecretName can be set", nil
+	case restore.SecretName != "":
+		secret, err := h.secrets.Get(backup.Namespace, restore.SecretName, metav1.GetOptions{})
+		if apierror.IsNotFound(err) {
+			return nil, fmt.Sprintf("waiting for secret %s", restore.SecretName), nil
+		} else if err != nil {
+			return nil, "", err
+		}
+		data, ok := secret.Data[v1.ClusterBackupKey]
+		if !ok {
+			return nil, fmt.Sprintf("secret %s has no %s key", restore.SecretName, v1.ClusterBackupKey), nil
+		}
+		return data, "", nil
+	case restore.DownloadURLSecretName != "":
+		location, message, err := h.secretURL(backup.Namespace, restore.DownloadURLSecretName)
+		if err != nil || message != "" {
+			return nil, message, err
+		}
+		data, err := transfer(http.MethodGet, location, nil)
+		return data, "", err
+	}
+	return nil, "one of spec.restore.secretName and spec.restore.downloadURLSecretName is required", nil
+}
+
+func (h *handler) restoreObject(backup *v1.ClusterBackup, resource schema.GroupVersionResource, obj *unstructured.Unstructured) (string, error) {
+	obj.SetNamespace(backup.Namespace)
+	obj.SetUID("")
+	obj.SetResourceVersion("")
+	obj.SetOwnerReferences(nil)
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[v1.ClusterBackupAnnotation] = string(backup.UID)
+	obj.SetAnnotations(annotations)
+
+	client := h.dynamic.Resource(resource).Namespace(backup.Namespace)
+	_, err := client.Create(context.TODO(), obj, metav1.CreateOptions{})
+	if !apierror.IsAlreadyExists(err) {
+		return "", err
+	}
+
+	existing, err := client.Get(context.TODO(), obj.GetName(), metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	if !backup.Spec.Restore.Overwrite && existing.GetAnnotations()[v1.ClusterBackupAnnotation] != string(backup.UID) {
+		return fmt.Sprintf("%s %s already exists, set spec.restore.overwrite to replace it", obj.GetKind(), obj.GetName()), nil
+	}
+	obj.SetResourceVersion(existing.GetResourceVersion())
+	_, err = client.Update(context.TODO(), obj, metav1.UpdateOptions{})
+	return "", err
+}
+
+func kindOrder(gvk schema.GroupVersionKind) int {
+	for i, kind := range restoreOrder {
+		if kind.gvk == gvk {
+			return i
+		}
+	}
+	return -1
+}
+
+// extract reads the files of a gzipped tarball by their names
+func extract(data []byte) (map[string][]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	files := map[string][]byte{}
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return files, nil
+		} else if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if files[header.Name], err = ioutil.ReadAll(tr); err != nil {
+			return nil, err
+		}
+	}
+}
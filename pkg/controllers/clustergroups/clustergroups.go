@@ -0,0 +1,102 @@
+package clustergroups
+
+import (
+	"context"
+	"strconv"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/clients"
+	"github.com/rancher/rancher-operator/pkg/condition/kstatus"
+	rocontrollers "github.com/rancher/rancher-operator/pkg/generated/controllers/rancher.cattle.io/v1"
+	"github.com/rancher/wrangler/pkg/name"
+	"github.com/rancher/wrangler/pkg/relatedresource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type handler struct {
+	clusters rocontrollers.ClusterCache
+	groups   rocontrollers.ClusterGroupCache
+}
+
+// Register the controller of the group wide role bindings, the other settings are merged by the cluster
+// controller when it renders a member
+func Register(ctx context.Context, clients *clients.Clients) {
+	h := handler{
+		clusters: clients.Cluster().Cache(),
+		groups:   clients.ClusterGroup().Cache(),
+	}
+
+	rocontrollers.RegisterClusterGroupGeneratingHandler(ctx,
+		clients.ClusterGroup(),
+		clients.Apply.
+			WithCacheTypes(clients.RoleTemplateBinding()),
+		"",
+		"cluster-group",
+		h.onClusterGroup,
+		nil)
+
+	relatedresource.Watch(ctx, "cluster-group-members", h.namespaceGroups, clients.ClusterGroup(), clients.Cluster())
+}
+
+func (h *handler) onClusterGroup(group *v1.ClusterGroup, status v1.ClusterGroupStatus) ([]runtime.Object, v1.ClusterGroupStatus, error) {
+	kstatus.SetObservedGeneration(&status.ObservedGeneration, group)
+
+	if group.Spec.Selector == nil {
+		status.Clusters = 0
+		return nil, status, nil
+	}
+
+	sel, err := metav1.LabelSelectorAsSelector(group.Spec.Selector)
+	if err != nil {
+		return nil, status, err
+	}
+
+	clusters, err := h.clusters.List(group.Namespace, sel)
+	if err != nil {
+		return nil, status, err
+	}
+	status.Clusters = len(clusters)
+
+	// the bindings select the members themselves, so they follow the group as clusters are labeled
+	var objs []runtime.Object
+	for i, binding := range group.Spec.RoleTemplateBindings {
+		objs = append(objs, &v1.RoleTemplateBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name.SafeConcatName(group.Name, strconv.Itoa(i)),
+				Namespace: group.Namespace,
+			},
+			RoleTemplateName: binding.RoleTemplateName,
+			BindingScope: v1.RoleTemplateBindingScope{
+				Selector: group.Spec.Selector,
+				Kind:     "Cluster",
+				APIGroup: "rancher.cattle.io",
+			},
+			Subjects: binding.Subjects,
+		})
+	}
+
+	return objs, status, nil
+}
+
+// namespaceGroups returns the groups in the namespace of a changed cluster to update their member count
+func (h *handler) namespaceGroups(namespace, name string, obj runtime.Object) ([]relatedresource.Key, error) {
+	if _, ok := obj.(*v1.Cluster); !ok && obj != nil {
+		return nil, nil
+	}
+
+	groups, err := h.groups.List(namespace, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []relatedresource.Key
+	for _, group := range groups {
+		keys = append(keys, relatedresource.Key{
+			Namespace: group.Namespace,
+			Name:      group.Name,
+		})
+	}
+	return keys, nil
+}
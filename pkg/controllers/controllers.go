@@ -3,39 +3,215 @@ package controllers
 import (
 	"context"
 
+	fleet "github.com/rancher/fleet/pkg/apis/fleet.cattle.io/v1alpha1"
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
 	"github.com/rancher/rancher-operator/pkg/clients"
 	"github.com/rancher/rancher-operator/pkg/controllers/auth"
 	"github.com/rancher/rancher-operator/pkg/controllers/cluster"
+	"github.com/rancher/rancher-operator/pkg/controllers/clusterbackups"
+	"github.com/rancher/rancher-operator/pkg/controllers/clustergroups"
+	"github.com/rancher/rancher-operator/pkg/controllers/discovery"
+	"github.com/rancher/rancher-operator/pkg/controllers/encryptionkeys"
+	"github.com/rancher/rancher-operator/pkg/controllers/etcdrestore"
+	"github.com/rancher/rancher-operator/pkg/controllers/fleetbundle"
 	"github.com/rancher/rancher-operator/pkg/controllers/fleetcluster"
+	"github.com/rancher/rancher-operator/pkg/controllers/nodepools"
+	"github.com/rancher/rancher-operator/pkg/controllers/nodetemplates"
 	"github.com/rancher/rancher-operator/pkg/controllers/projects"
+	"github.com/rancher/rancher-operator/pkg/controllers/serviceusers"
 	"github.com/rancher/rancher-operator/pkg/controllers/workspace"
+	"github.com/rancher/rancher-operator/pkg/debug"
+	"github.com/rancher/rancher-operator/pkg/downstream"
+	"github.com/rancher/rancher-operator/pkg/encryption"
+	"github.com/rancher/rancher-operator/pkg/health"
+	"github.com/rancher/rancher-operator/pkg/kubeconfig"
+	"github.com/rancher/rancher-operator/pkg/leader"
+	"github.com/rancher/rancher-operator/pkg/metrics"
+	"github.com/rancher/rancher-operator/pkg/operatorconfig"
+	"github.com/rancher/rancher-operator/pkg/options"
 	"github.com/rancher/rancher-operator/pkg/principals"
-	"github.com/rancher/wrangler/pkg/leader"
+	"github.com/rancher/rancher-operator/pkg/supportbundle"
+	"github.com/rancher/rancher-operator/pkg/tracing"
+	"github.com/rancher/rancher-operator/pkg/webhook"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/wrangler/pkg/name"
 	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
-func Register(ctx context.Context, systemNamespace string, clientConfig clientcmd.ClientConfig) error {
-	clients, err := clients.New(clientConfig)
+func Register(ctx context.Context, systemNamespace string, clientConfig clientcmd.ClientConfig, opts options.Options) error {
+	shard, err := options.ShardSelector(opts.ShardLabel, opts.ShardValue)
+	if err != nil {
+		return err
+	}
+	clients, err := clients.New(clientConfig, clients.Options{
+		Workers:         workers(opts),
+		Namespaces:      opts.WatchNamespaces,
+		ClusterSelector: shard,
+		RateLimits: clients.RateLimits{
+			QPS:                   float32(opts.KubeAPIQPS),
+			Burst:                 opts.KubeAPIBurst,
+			ManagementQPS:         float32(opts.ManagementAPIQPS),
+			ManagementBurst:       opts.ManagementAPIBurst,
+			ClusterReconcileQPS:   float32(opts.ClusterReconcileQPS),
+			ClusterReconcileBurst: opts.ClusterReconcileBurst,
+		},
+		ResyncPeriod:     opts.ResyncPeriod,
+		ResyncJitter:     opts.ResyncJitter,
+		CacheSyncTimeout: opts.CacheSyncTimeout,
+		Management:       clients.ManagementClientConfig(opts.ManagementKubeConfig, opts.ManagementURL, opts.ManagementToken, opts.ManagementCAFile),
+	})
+	if err != nil {
+		return err
+	}
+
+	logrus.AddHook(supportbundle.Logs)
+
+	if opts.MetricsAddress != "" {
+		metrics.Serve(ctx, opts.MetricsAddress)
+	}
+	if opts.DebugAddress != "" {
+		debug.Serve(ctx, opts.DebugAddress, map[string]cache.SharedIndexInformer{
+			"clusters":                      clients.Cluster().Informer(),
+			"projects":                      clients.Project().Informer(),
+			"ranchertokens":                 clients.RancherToken().Informer(),
+			"secrets":                       clients.Core.Secret().Informer(),
+			"management.cattle.io/clusters": clients.Management.Cluster().Informer(),
+			"management.cattle.io/nodes":    clients.Management.Node().Informer(),
+			"management.cattle.io/tokens":   clients.Management.Token().Informer(),
+			"management.cattle.io/users":    clients.Management.User().Informer(),
+			"fleet.cattle.io/bundles":       clients.Fleet.Bundle().Informer(),
+			"fleet.cattle.io/clusters":      clients.Fleet.Cluster().Informer(),
+		})
+	}
+
+	// watched by every replica, the webhook is served before this one is elected leader
+	config := operatorconfig.Watch(ctx, clients.K8s, opts.ConfigNamespace)
+
+	if opts.WebhookAddress != "" {
+		webhook.Serve(ctx, opts.WebhookAddress, opts.WebhookCertDir, config,
+			webhook.NewQuota(opts.MaxClustersPerNamespace, clients.Cluster(), clients.Core.Namespace()),
+			webhook.NewIsolation(clients.Management.Cluster(), clients.ClusterReferenceGrant(), opts.FleetWorkspaces, opts.AllowedFleetWorkspaces))
+		conversion, err := webhook.Conversion(opts.WebhookCertDir, opts.WebhookNamespace, opts.WebhookService)
+		if err != nil {
+			return err
+		}
+		clients.Conversion = conversion
+	}
+
+	if err := tracing.Setup(ctx, opts.TracingEndpoint, opts.TracingInsecure); err != nil {
+		return err
+	}
+
+	checker := health.NewChecker(func() error {
+		_, err := clients.ManagementK8s.Discovery().ServerResourcesForGroupVersion(v3.SchemeGroupVersion.String())
+		return err
+	})
+	if opts.HealthAddress != "" {
+		checker.Serve(ctx, opts.HealthAddress)
+	}
+
+	decrypters, err := encryption.New(opts.EncryptionKeyFile, opts.AgeIdentityFile)
 	if err != nil {
 		return err
 	}
 
 	lookup := principals.NewLookup(systemNamespace, "rancher-apikey", clients)
 
-	cluster.Register(ctx, clients)
-	projects.Register(ctx, clients)
-	auth.Register(ctx, clients, lookup)
-	auth.RegisterRoleTemplate(ctx, clients)
-	workspace.Register(ctx, clients)
-	fleetcluster.Register(ctx, clients)
+	// shared so the concurrency limits cover every controller calling downstream clusters
+	limiter := downstream.NewLimiter(opts.DownstreamConcurrency, opts.DownstreamClusterConcurrency)
+
+	// the names match the rules of the controllers in the rbac package
+	registers := []struct {
+		name     string
+		register func()
+	}{
+		{"cluster", func() { cluster.Register(ctx, clients, opts, decrypters, limiter, config) }},
+		{"clusterbackups", func() { clusterbackups.Register(ctx, clients) }},
+		{"clustergroups", func() { clustergroups.Register(ctx, clients) }},
+		{"discovery", func() { discovery.Register(ctx, clients) }},
+		{"encryptionkeys", func() { encryptionkeys.Register(ctx, clients) }},
+		{"etcdrestore", func() { etcdrestore.Register(ctx, clients) }},
+		{"nodepools", func() { nodepools.Register(ctx, clients) }},
+		{"nodetemplates", func() { nodetemplates.Register(ctx, clients) }},
+		{"projects", func() { projects.Register(ctx, clients, limiter) }},
+		{"serviceusers", func() {
+			serviceusers.Register(ctx, clients, kubeconfig.New(clients, opts.KubeConfigCABundleFile, limiter, config))
+		}},
+		{"auth", func() {
+			auth.Register(ctx, clients, lookup)
+			auth.RegisterRoleTemplate(ctx, clients)
+		}},
+		{"workspace", func() { workspace.Register(ctx, clients) }},
+		{"fleetcluster", func() { fleetcluster.Register(ctx, clients, config) }},
+		{"fleetbundle", func() { fleetbundle.Register(ctx, clients) }},
+	}
+	for _, c := range registers {
+		if opts.Enabled(c.name) {
+			c.register()
+		}
+	}
+
+	namespace := opts.LeaderElectionNamespace
+	if namespace == "" {
+		namespace = systemNamespace
+	}
+	// each shard elects its own leader
+	lockName := opts.LeaderElectionName
+	if opts.ShardValue != "" && (lockName == "" || lockName == leader.DefaultName) {
+		lockName = name.SafeConcatName(leader.DefaultName, opts.ShardValue)
+	}
+	// The lease outlives ctx until the running reconciles returned, so the next leader doesn't reconcile the
+	// same objects at the same time
+	leaderCtx, release := context.WithCancel(context.Background())
+	go func() {
+		<-ctx.Done()
+		logrus.Info("Shutting down, waiting for running reconciles")
+		if !clients.Drain(opts.ShutdownTimeout) {
+			logrus.Warnf("Reconciles still running after %s, releasing the leader lock anyway", opts.ShutdownTimeout)
+		}
+		release()
+	}()
 
-	leader.RunOrDie(ctx, systemNamespace, "rancher-controller-lock", clients.K8s, func(ctx context.Context) {
+	// every replica serves health, metrics and the webhook, only the leader runs the controllers
+	leader.RunOrDie(leaderCtx, clients.K8s, leader.Options{
+		Namespace:     namespace,
+		Name:          lockName,
+		LeaseDuration: opts.LeaderElectionLeaseDuration,
+		RenewDeadline: opts.LeaderElectionRenewDeadline,
+		RetryPeriod:   opts.LeaderElectionRetryPeriod,
+	}, func(ctx context.Context) {
+		checker.Leading()
 		if err := clients.Start(ctx); err != nil {
 			logrus.Fatal(err)
 		}
+		checker.Synced()
 		logrus.Info("All controllers are started")
+		if opts.Enabled("cluster") {
+			cluster.SweepOrphans(ctx, clients, opts.OrphanClusterPolicy, opts.InstanceName)
+		}
 	})
 
 	return nil
 }
+
+// workers maps the per controller worker options to the kinds the controllers reconcile
+func workers(opts options.Options) clients.Workers {
+	kinds := map[schema.GroupVersionKind]int{}
+	for kind, n := range map[schema.GroupVersionKind]int{
+		v1.SchemeGroupVersion.WithKind("Cluster"):      opts.ClusterWorkers,
+		v1.SchemeGroupVersion.WithKind("RancherToken"): opts.KubeconfigWorkers,
+		v1.SchemeGroupVersion.WithKind("Project"):      opts.ProjectWorkers,
+		fleet.SchemeGroupVersion.WithKind("Bundle"):    opts.FleetBundleWorkers,
+	} {
+		if n > 0 {
+			kinds[kind] = n
+		}
+	}
+	return clients.Workers{
+		Default: opts.Workers,
+		Kinds:   kinds,
+	}
+}
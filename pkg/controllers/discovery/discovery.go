@@ -0,0 +1,217 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/clients"
+	"github.com/rancher/rancher-operator/pkg/condition/kstatus"
+	rocontrollers "github.com/rancher/rancher-operator/pkg/generated/controllers/rancher.cattle.io/v1"
+	"github.com/rancher/wrangler/pkg/apply"
+	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
+	"github.com/rancher/wrangler/pkg/relatedresource"
+	corev1 "k8s.io/api/core/v1"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// discoveryLabel is set to the name of the discovery on the clusters it imports
+const discoveryLabel = "rancher.cattle.io/cluster-discovery"
+
+type handler struct {
+	clusters    rocontrollers.ClusterCache
+	discoveries rocontrollers.ClusterDiscoveryCache
+	secrets     corecontrollers.SecretClient
+}
+
+// Register the controller that imports a cluster for every kubeconfig secret found by a ClusterDiscovery
+func Register(ctx context.Context, clients *clients.Clients) {
+	h := handler{
+		clusters:    clients.Cluster().Cache(),
+		discoveries: clients.ClusterDiscovery().Cache(),
+		secrets:     clients.Core.Secret(),
+	}
+
+	rocontrollers.RegisterClusterDiscoveryGeneratingHandler(ctx,
+		clients.ClusterDiscovery(),
+		clients.Apply.
+			WithCacheTypes(clients.Cluster()),
+		v1.ClusterDiscoveryConditionDiscovered,
+		"cluster-discovery",
+		h.onDiscovery,
+		nil)
+
+	// the discovered secrets aren't cached so they can't be watched, new ones are found when the discoveries are
+	// resynced
+	relatedresource.Watch(ctx, "cluster-discovery-sources", h.namespaceDiscoveries, clients.ClusterDiscovery(),
+		clients.Cluster())
+}
+
+func (h *handler) onDiscovery(discovery *v1.ClusterDiscovery, status v1.ClusterDiscoveryStatus) ([]runtime.Object, v1.ClusterDiscoveryStatus, error) {
+	kstatus.SetObservedGeneration(&status.ObservedGeneration, discovery)
+
+	secrets, skipped, err := h.discoveredSecrets(discovery)
+	if err != nil {
+		return nil, status, err
+	}
+
+	existing, err := h.clusters.List(discovery.Namespace, labels.Everything())
+	if err != nil {
+		return nil, status, err
+	}
+	// clusters imported by hand or by another discovery keep their secret, and their name
+	importedBy := map[string]string{}
+	taken := map[string]bool{}
+	for _, cluster := range existing {
+		if cluster.Labels[discoveryLabel] == discovery.Name {
+			continue
+		}
+		taken[cluster.Name] = true
+		if cluster.Spec.ImportedConfig != nil {
+			importedBy[cluster.Spec.ImportedConfig.KubeConfigSecret] = cluster.Name
+		}
+	}
+
+	var (
+		objs     []runtime.Object
+		clusters []string
+		servers  = map[string]string{}
+	)
+	for _, secret := range secrets {
+		server, err := serverURL(secret)
+		if err != nil {
+			skipped = append(skipped, fmt.Sprintf("%s: %v", secret.Name, err))
+			continue
+		}
+		// named after the secret, the kubeconfig of the cluster is written to <cluster>-kubeconfig
+		clusterName := secret.Name
+		switch {
+		case importedBy[secret.Name] != "":
+			skipped = append(skipped, fmt.Sprintf("%s: already imported by cluster %s", secret.Name, importedBy[secret.Name]))
+			continue
+		case servers[server] != "":
+			skipped = append(skipped, fmt.Sprintf("%s: same server %s as secret %s", secret.Name, server, servers[server]))
+			continue
+		case taken[clusterName]:
+			skipped = append(skipped, fmt.Sprintf("%s: cluster %s already exists", secret.Name, clusterName))
+			continue
+		}
+		servers[server] = secret.Name
+
+		clusterLabels := map[string]string{}
+		for k, v := range discovery.Spec.ClusterLabels {
+			clusterLabels[k] = v
+		}
+		clusterLabels[discoveryLabel] = discovery.Name
+		objs = append(objs, &v1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      clusterName,
+				Namespace: discovery.Namespace,
+				Labels:    clusterLabels,
+			},
+			Spec: v1.ClusterSpec{
+				ImportedConfig: &v1.ImportedConfig{
+					KubeConfigSecret: secret.Name,
+				},
+			},
+		})
+		clusters = append(clusters, clusterName)
+	}
+
+	status.Clusters = clusters
+	status.Skipped = skipped
+	return objs, status, nil
+}
+
+// discoveredSecrets returns the secrets named by the discovery or matching its selector sorted by name, and the
+// named secrets that don't exist. They are read from the API server, the secret cache only holds applied secrets.
+func (h *handler) discoveredSecrets(discovery *v1.ClusterDiscovery) ([]*corev1.Secret, []string, error) {
+	found := map[string]*corev1.Secret{}
+	var skipped []string
+	for _, name := range discovery.Spec.SecretNames {
+		secret, err := h.secrets.Get(discovery.Namespace, name, metav1.GetOptions{})
+		if apierror.IsNotFound(err) {
+			skipped = append(skipped, name+": not found")
+			continue
+		} else if err != nil {
+			return nil, nil, err
+		}
+		found[name] = secret
+	}
+
+	if discovery.Spec.Selector != nil {
+		sel, err := metav1.LabelSelectorAsSelector(discovery.Spec.Selector)
+		if err != nil {
+			return nil, nil, err
+		}
+		secrets, err := h.secrets.List(discovery.Namespace, metav1.ListOptions{LabelSelector: sel.String()})
+		if err != nil {
+			return nil, nil, err
+		}
+		for i := range secrets.Items {
+			secret := &secrets.Items[i]
+			// kubeconfigs the operator wrote for its own clusters are never imported again
+			if secret.Annotations[apply.LabelID] != "" {
+				continue
+			}
+			found[secret.Name] = secret
+		}
+	}
+
+	secrets := make([]*corev1.Secret, 0, len(found))
+	for _, secret := range found {
+		secrets = append(secrets, secret)
+	}
+	sort.Slice(secrets, func(i, j int) bool {
+		return secrets[i].Name < secrets[j].Name
+	})
+	return secrets, skipped, nil
+}
+
+// serverURL returns the API server of the current context of the kubeconfig in the value key, like the agent
+// deployment reads it
+func serverURL(secret *corev1.Secret) (string, error) {
+	data := secret.Data["value"]
+	if len(data) == 0 {
+		return "", fmt.Errorf("no kubeconfig in the value key")
+	}
+	config, err := clientcmd.Load(data)
+	if err != nil {
+		return "", fmt.Errorf("invalid kubeconfig: %w", err)
+	}
+	current, ok := config.Contexts[config.CurrentContext]
+	if !ok {
+		return "", fmt.Errorf("kubeconfig has no current context")
+	}
+	cluster, ok := config.Clusters[current.Cluster]
+	if !ok || cluster.Server == "" {
+		return "", fmt.Errorf("kubeconfig has no server for context %s", config.CurrentContext)
+	}
+	return cluster.Server, nil
+}
+
+// namespaceDiscoveries requeues the discoveries of the namespace of a cluster that may free a secret imported by
+// hand
+func (h *handler) namespaceDiscoveries(namespace, name string, obj runtime.Object) ([]relatedresource.Key, error) {
+	if cluster, ok := obj.(*v1.Cluster); ok && (cluster.Spec.ImportedConfig == nil || cluster.Labels[discoveryLabel] != "") {
+		return nil, nil
+	}
+
+	discoveries, err := h.discoveries.List(namespace, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	var keys []relatedresource.Key
+	for _, discovery := range discoveries {
+		keys = append(keys, relatedresource.Key{
+			Namespace: discovery.Namespace,
+			Name:      discovery.Name,
+		})
+	}
+	return keys, nil
+}
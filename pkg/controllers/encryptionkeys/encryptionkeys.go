@@ -0,0 +1,158 @@
+package encryptionkeys
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/clients"
+	mgmtcontrollers "github.com/rancher/rancher-operator/pkg/generated/controllers/management.cattle.io/v3"
+	rocontrollers "github.com/rancher/rancher-operator/pkg/generated/controllers/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/tenancy"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/wrangler/pkg/condition"
+	"github.com/rancher/wrangler/pkg/relatedresource"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const byCluster = "by-cluster"
+
+type handler struct {
+	clusters      rocontrollers.ClusterCache
+	rotations     rocontrollers.EncryptionKeyRotationCache
+	rclusterCache mgmtcontrollers.ClusterCache
+	rclusters     mgmtcontrollers.ClusterClient
+}
+
+// Register the controller that rotates the secrets encryption key of RKE clusters by setting rotateEncryptionKey
+// on the management cluster, Rancher resets it once the new key is in use. The k3s and rke2 configs of this
+// Rancher version have no secrets encryption settings, so rotations of those clusters fail.
+func Register(ctx context.Context, clients *clients.Clients) {
+	h := handler{
+		clusters:      clients.Cluster().Cache(),
+		rotations:     clients.EncryptionKeyRotation().Cache(),
+		rclusterCache: clients.Management.Cluster().Cache(),
+		rclusters:     clients.Management.Cluster(),
+	}
+
+	h.rotations.AddIndexer(byCluster, func(obj *v1.EncryptionKeyRotation) ([]string, error) {
+		return []string{obj.Namespace + "/" + obj.Spec.ClusterName}, nil
+	})
+
+	rocontrollers.RegisterEncryptionKeyRotationStatusHandler(ctx,
+		clients.EncryptionKeyRotation(),
+		"",
+		"encryption-key-rotation",
+		h.onRotation)
+
+	relatedresource.Watch(ctx, "encryption-key-rotation-cluster", h.clusterRotations,
+		clients.EncryptionKeyRotation(), clients.Cluster(), clients.Management.Cluster())
+}
+
+func (h *handler) clusterRotations(namespace, name string, obj runtime.Object) ([]relatedresource.Key, error) {
+	if rCluster, ok := obj.(*v3.Cluster); ok {
+		if namespace, name, ok = tenancy.Owner(rCluster); !ok {
+			return nil, nil
+		}
+	} else if _, ok := obj.(*v1.Cluster); !ok {
+		return nil, nil
+	}
+
+	rotations, err := h.rotations.GetByIndex(byCluster, namespace+"/"+name)
+	if err != nil {
+		return nil, err
+	}
+	var keys []relatedresource.Key
+	for _, rotation := range rotations {
+		keys = append(keys, relatedresource.Key{
+			Namespace: rotation.Namespace,
+			Name:      rotation.Name,
+		})
+	}
+	return keys, nil
+}
+
+func (h *handler) onRotation(rotation *v1.EncryptionKeyRotation, status v1.EncryptionKeyRotationStatus) (v1.EncryptionKeyRotationStatus, error) {
+	status.ObservedGeneration = rotation.Generation
+
+	switch status.Phase {
+	case v1.EncryptionKeyRotationPhaseCompleted, v1.EncryptionKeyRotationPhaseFailed:
+		return status, nil
+	}
+
+	cluster, err := h.clusters.Get(rotation.Namespace, rotation.Spec.ClusterName)
+	if apierror.IsNotFound(err) && status.Phase == v1.EncryptionKeyRotationPhaseRotating {
+		return failed(status, fmt.Sprintf("cluster %s was removed", rotation.Spec.ClusterName)), nil
+	} else if apierror.IsNotFound(err) {
+		return pending(status, fmt.Sprintf("waiting for cluster %s", rotation.Spec.ClusterName)), nil
+	} else if err != nil {
+		return status, err
+	}
+
+	rke := cluster.Spec.RancherKubernetesEngineConfig
+	if rke == nil {
+		return failed(status, "only the secrets encryption key of RKE clusters is rotated by Rancher"), nil
+	}
+	if encryption := rke.Services.KubeAPI.SecretsEncryptionConfig; encryption == nil || !encryption.Enabled {
+		return failed(status, "secrets encryption is not enabled in spec.rancherKubernetesEngineConfig.services.kubeApi"), nil
+	}
+
+	rCluster, err := h.rclusterCache.Get(cluster.Status.ClusterName)
+	if apierror.IsNotFound(err) || (err == nil && rCluster.Spec.RancherKubernetesEngineConfig == nil) {
+		return pending(status, fmt.Sprintf("waiting for the management cluster of %s", cluster.Name)), nil
+	} else if err != nil {
+		return status, err
+	}
+
+	if status.Phase == v1.EncryptionKeyRotationPhaseRotating {
+		return rotating(rCluster, status), nil
+	}
+
+	if rCluster.Spec.RancherKubernetesEngineConfig.RotateEncryptionKey {
+		return pending(status, fmt.Sprintf("waiting for the running rotation of %s", rCluster.Name)), nil
+	}
+
+	rCluster = rCluster.DeepCopy()
+	rCluster.Spec.RancherKubernetesEngineConfig.RotateEncryptionKey = true
+	if _, err := h.rclusters.Update(rCluster); err != nil {
+		return status, err
+	}
+
+	status.Phase = v1.EncryptionKeyRotationPhaseRotating
+	status.StartedAt = time.Now().UTC().Format(time.RFC3339)
+	v1.EncryptionKeyRotationConditionRotated.False(&status)
+	v1.EncryptionKeyRotationConditionRotated.Message(&status, "rotating the secrets encryption key")
+	return status, nil
+}
+
+// rotating completes the rotation once Rancher reset rotateEncryptionKey and the cluster is ready again
+func rotating(rCluster *v3.Cluster, status v1.EncryptionKeyRotationStatus) v1.EncryptionKeyRotationStatus {
+	if rCluster.Spec.RancherKubernetesEngineConfig.RotateEncryptionKey || !condition.Cond("Ready").IsTrue(rCluster) {
+		if message := condition.Cond("Provisioned").GetMessage(rCluster); message != "" {
+			v1.EncryptionKeyRotationConditionRotated.Message(&status, message)
+		}
+		return status
+	}
+
+	status.Phase = v1.EncryptionKeyRotationPhaseCompleted
+	status.LastRotationTime = time.Now().UTC().Format(time.RFC3339)
+	v1.EncryptionKeyRotationConditionRotated.True(&status)
+	v1.EncryptionKeyRotationConditionRotated.Message(&status, "")
+	return status
+}
+
+func pending(status v1.EncryptionKeyRotationStatus, message string) v1.EncryptionKeyRotationStatus {
+	status.Phase = v1.EncryptionKeyRotationPhasePending
+	v1.EncryptionKeyRotationConditionRotated.False(&status)
+	v1.EncryptionKeyRotationConditionRotated.Message(&status, message)
+	return status
+}
+
+func failed(status v1.EncryptionKeyRotationStatus, message string) v1.EncryptionKeyRotationStatus {
+	status.Phase = v1.EncryptionKeyRotationPhaseFailed
+	v1.EncryptionKeyRotationConditionRotated.False(&status)
+	v1.EncryptionKeyRotationConditionRotated.Message(&status, message)
+	return status
+}
@@ -0,0 +1,168 @@
+package etcdrestore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/clients"
+	mgmtcontrollers "github.com/rancher/rancher-operator/pkg/generated/controllers/management.cattle.io/v3"
+	rocontrollers "github.com/rancher/rancher-operator/pkg/generated/controllers/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/tenancy"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	rketypes "github.com/rancher/rke/types"
+	"github.com/rancher/wrangler/pkg/condition"
+	"github.com/rancher/wrangler/pkg/relatedresource"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const byCluster = "by-cluster"
+
+type handler struct {
+	clusters      rocontrollers.ClusterCache
+	restores      rocontrollers.EtcdSnapshotRestoreCache
+	rclusterCache mgmtcontrollers.ClusterCache
+	rclusters     mgmtcontrollers.ClusterClient
+	etcdBackups   mgmtcontrollers.EtcdBackupCache
+}
+
+// Register the controller that restores RKE clusters from etcd snapshots by setting the restore config of the
+// management cluster, Rancher clears it again once the snapshot is restored
+func Register(ctx context.Context, clients *clients.Clients) {
+	h := handler{
+		clusters:      clients.Cluster().Cache(),
+		restores:      clients.EtcdSnapshotRestore().Cache(),
+		rclusterCache: clients.Management.Cluster().Cache(),
+		rclusters:     clients.Management.Cluster(),
+		etcdBackups:   clients.Management.EtcdBackup().Cache(),
+	}
+
+	h.restores.AddIndexer(byCluster, func(obj *v1.EtcdSnapshotRestore) ([]string, error) {
+		return []string{obj.Namespace + "/" + obj.Spec.ClusterName}, nil
+	})
+
+	rocontrollers.RegisterEtcdSnapshotRestoreStatusHandler(ctx,
+		clients.EtcdSnapshotRestore(),
+		"",
+		"etcd-snapshot-restore",
+		h.onRestore)
+
+	relatedresource.Watch(ctx, "etcd-snapshot-restore-cluster", h.clusterRestores,
+		clients.EtcdSnapshotRestore(), clients.Cluster(), clients.Management.Cluster())
+}
+
+func (h *handler) clusterRestores(namespace, name string, obj runtime.Object) ([]relatedresource.Key, error) {
+	if rCluster, ok := obj.(*v3.Cluster); ok {
+		if namespace, name, ok = tenancy.Owner(rCluster); !ok {
+			return nil, nil
+		}
+	} else if _, ok := obj.(*v1.Cluster); !ok {
+		return nil, nil
+	}
+
+	restores, err := h.restores.GetByIndex(byCluster, namespace+"/"+name)
+	if err != nil {
+		return nil, err
+	}
+	var keys []relatedresource.Key
+	for _, restore := range restores {
+		keys = append(keys, relatedresource.Key{
+			Namespace: restore.Namespace,
+			Name:      restore.Name,
+		})
+	}
+	return keys, nil
+}
+
+func (h *handler) onRestore(restore *v1.EtcdSnapshotRestore, status v1.EtcdSnapshotRestoreStatus) (v1.EtcdSnapshotRestoreStatus, error) {
+	status.ObservedGeneration = restore.Generation
+
+	switch status.Phase {
+	case v1.EtcdSnapshotRestorePhaseCompleted, v1.EtcdSnapshotRestorePhaseFailed:
+		return status, nil
+	}
+
+	cluster, err := h.clusters.Get(restore.Namespace, restore.Spec.ClusterName)
+	if apierror.IsNotFound(err) && status.Phase == v1.EtcdSnapshotRestorePhaseRestoring {
+		return failed(status, fmt.Sprintf("cluster %s was removed", restore.Spec.ClusterName)), nil
+	} else if apierror.IsNotFound(err) {
+		return pending(status, fmt.Sprintf("waiting for cluster %s", restore.Spec.ClusterName)), nil
+	} else if err != nil {
+		return status, err
+	}
+
+	if cluster.Spec.RancherKubernetesEngineConfig == nil {
+		return failed(status, "only RKE clusters are restored from etcd snapshots by Rancher"), nil
+	}
+
+	rCluster, err := h.rclusterCache.Get(cluster.Status.ClusterName)
+	if apierror.IsNotFound(err) || (err == nil && rCluster.Spec.RancherKubernetesEngineConfig == nil) {
+		return pending(status, fmt.Sprintf("waiting for the management cluster of %s", cluster.Name)), nil
+	} else if err != nil {
+		return status, err
+	}
+
+	if status.Phase == v1.EtcdSnapshotRestorePhaseRestoring {
+		return restoring(rCluster, status), nil
+	}
+	return h.start(restore, rCluster, status)
+}
+
+func (h *handler) start(restore *v1.EtcdSnapshotRestore, rCluster *v3.Cluster, status v1.EtcdSnapshotRestoreStatus) (v1.EtcdSnapshotRestoreStatus, error) {
+	if rCluster.Spec.RancherKubernetesEngineConfig.Restore.Restore {
+		return pending(status, fmt.Sprintf("waiting for the running restore of %s", rCluster.Name)), nil
+	}
+
+	if _, err := h.etcdBackups.Get(rCluster.Name, restore.Spec.SnapshotName); apierror.IsNotFound(err) {
+		return failed(status, fmt.Sprintf("etcd snapshot %s of management cluster %s not found", restore.Spec.SnapshotName, rCluster.Name)), nil
+	} else if err != nil {
+		return status, err
+	}
+
+	rCluster = rCluster.DeepCopy()
+	rCluster.Spec.RancherKubernetesEngineConfig.Restore = rketypes.RestoreConfig{
+		Restore:      true,
+		SnapshotName: rCluster.Name + ":" + restore.Spec.SnapshotName,
+	}
+	if _, err := h.rclusters.Update(rCluster); err != nil {
+		return status, err
+	}
+
+	status.Phase = v1.EtcdSnapshotRestorePhaseRestoring
+	status.StartedAt = time.Now().UTC().Format(time.RFC3339)
+	v1.EtcdSnapshotRestoreConditionRestored.False(&status)
+	v1.EtcdSnapshotRestoreConditionRestored.Message(&status, fmt.Sprintf("restoring etcd snapshot %s", restore.Spec.SnapshotName))
+	return status, nil
+}
+
+// restoring completes the restore once Rancher cleared the restore config and the cluster is ready again
+func restoring(rCluster *v3.Cluster, status v1.EtcdSnapshotRestoreStatus) v1.EtcdSnapshotRestoreStatus {
+	if rCluster.Spec.RancherKubernetesEngineConfig.Restore.Restore || !condition.Cond("Ready").IsTrue(rCluster) {
+		if message := condition.Cond("Provisioned").GetMessage(rCluster); message != "" {
+			v1.EtcdSnapshotRestoreConditionRestored.Message(&status, message)
+		}
+		return status
+	}
+
+	status.Phase = v1.EtcdSnapshotRestorePhaseCompleted
+	status.CompletedAt = time.Now().UTC().Format(time.RFC3339)
+	v1.EtcdSnapshotRestoreConditionRestored.True(&status)
+	v1.EtcdSnapshotRestoreConditionRestored.Message(&status, "")
+	return status
+}
+
+func pending(status v1.EtcdSnapshotRestoreStatus, message string) v1.EtcdSnapshotRestoreStatus {
+	status.Phase = v1.EtcdSnapshotRestorePhasePending
+	v1.EtcdSnapshotRestoreConditionRestored.False(&status)
+	v1.EtcdSnapshotRestoreConditionRestored.Message(&status, message)
+	return status
+}
+
+func failed(status v1.EtcdSnapshotRestoreStatus, message string) v1.EtcdSnapshotRestoreStatus {
+	status.Phase = v1.EtcdSnapshotRestorePhaseFailed
+	v1.EtcdSnapshotRestoreConditionRestored.False(&status)
+	v1.EtcdSnapshotRestoreConditionRestored.Message(&status, message)
+	return status
+}
@@ -0,0 +1,160 @@
+package fleetbundle
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	fleet "github.com/rancher/fleet/pkg/apis/fleet.cattle.io/v1alpha1"
+	"github.com/rancher/rancher-operator/pkg/clients"
+	"github.com/rancher/rancher-operator/pkg/fleetstatus"
+	fleetcontrollers "github.com/rancher/rancher-operator/pkg/generated/controllers/fleet.cattle.io/v1alpha1"
+	rocontrollers "github.com/rancher/rancher-operator/pkg/generated/controllers/rancher.cattle.io/v1"
+	"github.com/rancher/wrangler/pkg/relatedresource"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	byCluster     = "by-rancher-cluster"
+	repoNameLabel = "fleet.cattle.io/repo-name"
+)
+
+type handler struct {
+	clusters    rocontrollers.ClusterCache
+	bundleCache fleetcontrollers.BundleCache
+	bundles     fleetcontrollers.BundleClient
+	gitRepos    fleetcontrollers.GitRepoController
+}
+
+// Register reports the provisioning of the clusters deployed by fleet bundles in the ClustersReady condition of
+// the bundle and its git repo. Bundles are expected to target the cluster the operator runs in, as the bundles of
+// fleet-local do.
+func Register(ctx context.Context, clients *clients.Clients) {
+	h := handler{
+		clusters:    clients.Cluster().Cache(),
+		bundleCache: clients.Fleet.Bundle().Cache(),
+		bundles:     clients.Fleet.Bundle(),
+		gitRepos:    clients.Fleet.GitRepo(),
+	}
+
+	h.bundleCache.AddIndexer(byCluster, func(bundle *fleet.Bundle) ([]string, error) {
+		refs, err := fleetstatus.Clusters(bundle)
+		if err != nil {
+			// reported by the handler
+			return nil, nil
+		}
+		var keys []string
+		for _, ref := range refs {
+			keys = append(keys, ref.String())
+		}
+		return keys, nil
+	})
+
+	clients.Fleet.Bundle().OnChange(ctx, "fleet-bundle-clusters", h.onBundle)
+	relatedresource.Watch(ctx, "fleet-bundle-cluster-watch", h.clusterBundles, clients.Fleet.Bundle(), clients.Cluster())
+}
+
+func (h *handler) clusterBundles(namespace, name string, obj runtime.Object) ([]relatedresource.Key, error) {
+	bundles, err := h.bundleCache.GetByIndex(byCluster, namespace+"/"+name)
+	if err != nil {
+		return nil, err
+	}
+	var keys []relatedresource.Key
+	for _, bundle := range bundles {
+		keys = append(keys, relatedresource.Key{
+			Namespace: bundle.Namespace,
+			Name:      bundle.Name,
+		})
+	}
+	return keys, nil
+}
+
+func (h *handler) onBundle(key string, bundle *fleet.Bundle) (*fleet.Bundle, error) {
+	if bundle == nil || bundle.DeletionTimestamp != nil {
+		return bundle, nil
+	}
+
+	refs, err := fleetstatus.Clusters(bundle)
+	if err != nil {
+		return bundle, err
+	}
+	if len(refs) == 0 {
+		return bundle, nil
+	}
+
+	ready, message, err := fleetstatus.Summarize(refs, h.clusters.Get)
+	if err != nil {
+		return bundle, err
+	}
+
+	if fleetstatus.ConditionClustersReady.IsTrue(bundle) != ready ||
+		fleetstatus.ConditionClustersReady.GetMessage(bundle) != message {
+		bundle = bundle.DeepCopy()
+		setReady(bundle, ready, message)
+		bundle, err = h.bundles.UpdateStatus(bundle)
+		if err != nil {
+			return bundle, err
+		}
+	}
+
+	if repo := bundle.Labels[repoNameLabel]; repo != "" {
+		return bundle, h.updateGitRepo(bundle.Namespace, repo)
+	}
+	return bundle, nil
+}
+
+// updateGitRepo sets the condition of the git repo from those of all its bundles
+func (h *handler) updateGitRepo(namespace, name string) error {
+	bundles, err := h.bundleCache.List(namespace, labels.SelectorFromSet(labels.Set{
+		repoNameLabel: name,
+	}))
+	if err != nil {
+		return err
+	}
+
+	var (
+		found    bool
+		ready    = true
+		messages []string
+	)
+	for _, bundle := range bundles {
+		switch fleetstatus.ConditionClustersReady.GetStatus(bundle) {
+		case "":
+			// deploys no clusters
+		case "True":
+			found = true
+		default:
+			found = true
+			ready = false
+			messages = append(messages, fleetstatus.ConditionClustersReady.GetMessage(bundle))
+		}
+	}
+	if !found {
+		return nil
+	}
+	sort.Strings(messages)
+	message := strings.Join(messages, ", ")
+
+	repo, err := h.gitRepos.Cache().Get(namespace, name)
+	if apierror.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if fleetstatus.ConditionClustersReady.IsTrue(repo) == ready &&
+		fleetstatus.ConditionClustersReady.GetMessage(repo) == message {
+		return nil
+	}
+
+	repo = repo.DeepCopy()
+	setReady(repo, ready, message)
+	_, err = h.gitRepos.UpdateStatus(repo)
+	return err
+}
+
+func setReady(obj interface{}, ready bool, message string) {
+	fleetstatus.ConditionClustersReady.SetStatusBool(obj, ready)
+	fleetstatus.ConditionClustersReady.Message(obj, message)
+}
@@ -2,11 +2,14 @@ package fleetcluster
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 
 	fleet "github.com/rancher/fleet/pkg/apis/fleet.cattle.io/v1alpha1"
 	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
 	"github.com/rancher/rancher-operator/pkg/clients"
 	mgmtcontrollers "github.com/rancher/rancher-operator/pkg/generated/controllers/management.cattle.io/v3"
+	"github.com/rancher/rancher-operator/pkg/operatorconfig"
 	"github.com/rancher/rancher-operator/pkg/settings"
 	mgmt "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
 	"github.com/rancher/wrangler/pkg/apply"
@@ -26,13 +29,15 @@ type handler struct {
 	settings mgmtcontrollers.SettingCache
 	clusters mgmtcontrollers.ClusterClient
 	apply    apply.Apply
+	config   *operatorconfig.Config
 }
 
-func Register(ctx context.Context, clients *clients.Clients) {
+func Register(ctx context.Context, clients *clients.Clients, config *operatorconfig.Config) {
 	h := &handler{
 		settings: clients.Management.Setting().Cache(),
 		clusters: clients.Management.Cluster(),
 		apply:    clients.Apply.WithCacheTypes(clients.Cluster()),
+		config:   config,
 	}
 
 	clients.Management.Cluster().OnChange(ctx, "fleet-cluster-label", h.addLabel)
@@ -111,9 +116,11 @@ func (h *handler) addLabel(key string, cluster *mgmt.Cluster) (*mgmt.Cluster, er
 }
 
 func (h *handler) createCluster(cluster *mgmt.Cluster, status mgmt.ClusterStatus) ([]runtime.Object, mgmt.ClusterStatus, error) {
+	// opting out prunes the fleet cluster, fleet then removes its agent
 	if cluster.Spec.FleetWorkspaceName == "" ||
 		cluster.Labels[clusterName] == "" ||
-		cluster.Spec.Internal {
+		cluster.Spec.Internal ||
+		cluster.Annotations[v1.FleetAgentAnnotation] == "false" {
 		return nil, status, nil
 	}
 
@@ -121,12 +128,27 @@ func (h *handler) createCluster(cluster *mgmt.Cluster, status mgmt.ClusterStatus
 		return nil, status, generic.ErrSkip
 	}
 
-	labels := yaml.CleanAnnotationsForExport(cluster.Labels)
+	// with the None label sync policy only the labels below are set
+	labels := map[string]string{}
+	if h.config.Get().LabelSyncPolicy != operatorconfig.LabelSyncPolicyNone {
+		labels = yaml.CleanAnnotationsForExport(cluster.Labels)
+	}
 	labels["management.cattle.io/cluster-name"] = cluster.Name
 	if errs := validation.IsValidLabelValue(cluster.Spec.DisplayName); len(errs) == 0 {
 		labels["management.cattle.io/cluster-display-name"] = cluster.Spec.DisplayName
 	}
 
+	// the labels of the fleet cluster only, the other labels are on the v1 cluster created for it too
+	fleetLabels := map[string]string{}
+	for k, v := range labels {
+		fleetLabels[k] = v
+	}
+	if value := cluster.Annotations[v1.FleetLabelsAnnotation]; value != "" {
+		if err := json.Unmarshal([]byte(value), &fleetLabels); err != nil {
+			return nil, status, fmt.Errorf("invalid %s annotation on cluster %s: %w", v1.FleetLabelsAnnotation, cluster.Name, err)
+		}
+	}
+
 	var (
 		secretName    = cluster.Name + "-kubeconfig"
 		createCluster = true
@@ -167,7 +189,7 @@ func (h *handler) createCluster(cluster *mgmt.Cluster, status mgmt.ClusterStatus
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      cluster.Name,
 			Namespace: cluster.Spec.FleetWorkspaceName,
-			Labels:    labels,
+			Labels:    fleetLabels,
 		},
 		Spec: fleet.ClusterSpec{
 			KubeConfigSecret: secretName,
@@ -0,0 +1,256 @@
+package nodepools
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/clients"
+	"github.com/rancher/rancher-operator/pkg/condition/kstatus"
+	mgmtcontrollers "github.com/rancher/rancher-operator/pkg/generated/controllers/management.cattle.io/v3"
+	rocontrollers "github.com/rancher/rancher-operator/pkg/generated/controllers/rancher.cattle.io/v1"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/wrangler/pkg/condition"
+	"github.com/rancher/wrangler/pkg/relatedresource"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	byCluster  = "by-cluster"
+	byTemplate = "by-template"
+	byPoolName = "by-pool-name"
+	byNodePool = "by-node-pool"
+)
+
+type handler struct {
+	clusters  rocontrollers.ClusterCache
+	pools     rocontrollers.NodePoolCache
+	templates rocontrollers.NodeTemplateCache
+	nodeCache mgmtcontrollers.NodeCache
+}
+
+// Register the controller that provisions the nodes of a NodePool with a node pool of the management cluster,
+// Rancher creates and deletes the nodes to match its quantity
+func Register(ctx context.Context, clients *clients.Clients) {
+	h := handler{
+		clusters:  clients.Cluster().Cache(),
+		pools:     clients.NodePool().Cache(),
+		templates: clients.NodeTemplate().Cache(),
+		nodeCache: clients.Management.Node().Cache(),
+	}
+
+	h.pools.AddIndexer(byCluster, func(obj *v1.NodePool) ([]string, error) {
+		return []string{obj.Namespace + "/" + obj.Spec.ClusterName}, nil
+	})
+	h.pools.AddIndexer(byTemplate, func(obj *v1.NodePool) ([]string, error) {
+		if strings.Contains(obj.Spec.NodeTemplateName, ":") {
+			return nil, nil
+		}
+		return []string{obj.Namespace + "/" + obj.Spec.NodeTemplateName}, nil
+	})
+	h.pools.AddIndexer(byPoolName, func(obj *v1.NodePool) ([]string, error) {
+		if obj.Status.PoolName == "" {
+			return nil, nil
+		}
+		return []string{obj.Status.PoolName}, nil
+	})
+	h.nodeCache.AddIndexer(byNodePool, func(obj *v3.Node) ([]string, error) {
+		if obj.Spec.NodePoolName == "" {
+			return nil, nil
+		}
+		return []string{obj.Spec.NodePoolName}, nil
+	})
+
+	rocontrollers.RegisterNodePoolGeneratingHandler(ctx,
+		clients.NodePool(),
+		clients.Apply.
+			WithCacheTypes(clients.Management.NodePool()),
+		v1.NodePoolConditionReconciled,
+		"node-pool",
+		h.onNodePool,
+		nil)
+
+	relatedresource.Watch(ctx, "node-pool-sources", h.relatedPools, clients.NodePool(),
+		clients.Cluster(), clients.NodeTemplate(), clients.Management.Node())
+}
+
+// relatedPools requeues the pools of a cluster or node template and the pool of a node, so the counts in the status
+// follow the nodes
+func (h *handler) relatedPools(namespace, name string, obj runtime.Object) ([]relatedresource.Key, error) {
+	var (
+		pools []*v1.NodePool
+		err   error
+	)
+	switch obj := obj.(type) {
+	case *v1.Cluster:
+		pools, err = h.pools.GetByIndex(byCluster, namespace+"/"+name)
+	case *v1.NodeTemplate:
+		pools, err = h.pools.GetByIndex(byTemplate, namespace+"/"+name)
+	case *v3.Node:
+		if obj.Spec.NodePoolName == "" {
+			return nil, nil
+		}
+		pools, err = h.pools.GetByIndex(byPoolName, obj.Spec.NodePoolName)
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []relatedresource.Key
+	for _, pool := range pools {
+		keys = append(keys, relatedresource.Key{
+			Namespace: pool.Namespace,
+			Name:      pool.Name,
+		})
+	}
+	return keys, nil
+}
+
+func (h *handler) onNodePool(pool *v1.NodePool, status v1.NodePoolStatus) ([]runtime.Object, v1.NodePoolStatus, error) {
+	kstatus.SetObservedGeneration(&status.ObservedGeneration, pool)
+
+	if err := validate(pool.Spec); err != nil {
+		return nil, status, err
+	}
+
+	cluster, err := h.clusters.Get(pool.Namespace, pool.Spec.ClusterName)
+	if apierror.IsNotFound(err) {
+		return nil, status, fmt.Errorf("waiting for cluster %s", pool.Spec.ClusterName)
+	} else if err != nil {
+		return nil, status, err
+	}
+	if cluster.Spec.RancherKubernetesEngineConfig == nil {
+		return nil, status, fmt.Errorf("cluster %s is not an RKE cluster, only RKE clusters have node pools", cluster.Name)
+	}
+	// the management cluster namespace does not exist until the cluster is created
+	if cluster.Status.ClusterName == "" {
+		return nil, status, fmt.Errorf("waiting for the management cluster of %s", cluster.Name)
+	}
+
+	templateName, err := h.templateName(pool)
+	if err != nil {
+		return nil, status, err
+	}
+
+	rPool := managementPool(pool, cluster, templateName)
+	status.PoolName = rPool.Namespace + ":" + rPool.Name
+	status.Quantity = rPool.Spec.Quantity
+
+	nodes, err := h.nodeCache.GetByIndex(byNodePool, status.PoolName)
+	if err != nil {
+		return nil, status, err
+	}
+	status.Nodes = len(nodes)
+	status.ReadyNodes = 0
+	for _, node := range nodes {
+		if condition.Cond("Ready").IsTrue(node) {
+			status.ReadyNodes++
+		}
+	}
+
+	obj, err := withDrainBeforeDelete(rPool, pool.Spec.DrainBeforeDelete || cluster.Spec.DrainBeforeDelete != nil)
+	if err != nil {
+		return nil, status, err
+	}
+	return []runtime.Object{obj}, status, nil
+}
+
+// managementPool returns the node pool of the management cluster. The hibernation and drain settings of the cluster
+// are set the way the cluster controller sets them on the pools, so both agree on the pool.
+func managementPool(pool *v1.NodePool, cluster *v1.Cluster, templateName string) *v3.NodePool {
+	hostnamePrefix := pool.Spec.HostnamePrefix
+	if hostnamePrefix == "" {
+		hostnamePrefix = pool.Name + "-"
+	}
+
+	rPool := &v3.NodePool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        pool.Name,
+			Namespace:   cluster.Status.ClusterName,
+			Annotations: map[string]string{},
+		},
+		Spec: v3.NodePoolSpec{
+			DisplayName:      pool.Name,
+			ClusterName:      cluster.Status.ClusterName,
+			NodeTemplateName: templateName,
+			HostnamePrefix:   hostnamePrefix,
+			Quantity:         quantity(pool.Spec),
+			Etcd:             pool.Spec.Etcd,
+			ControlPlane:     pool.Spec.ControlPlane,
+			Worker:           pool.Spec.Worker,
+			NodeLabels:       pool.Spec.Labels,
+			NodeTaints:       pool.Spec.Taints,
+		},
+	}
+
+	if cluster.Spec.Hibernate && pool.Spec.Worker && !pool.Spec.ControlPlane && !pool.Spec.Etcd {
+		rPool.Annotations[v1.HibernateQuantityAnnotation] = strconv.Itoa(rPool.Spec.Quantity)
+		rPool.Spec.Quantity = 0
+	}
+	if cluster.Spec.DrainBeforeDelete != nil {
+		rPool.Annotations[v1.DrainManagedAnnotation] = "true"
+	}
+	return rPool
+}
+
+// withDrainBeforeDelete returns the management node pool with drainBeforeDelete set. The field is newer than the
+// management.cattle.io types the operator is built with, so the pool is applied as unstructured.
+func withDrainBeforeDelete(rPool *v3.NodePool, drain bool) (runtime.Object, error) {
+	data, err := runtime.DefaultUnstructuredConverter.ToUnstructured(rPool)
+	if err != nil {
+		return nil, err
+	}
+	data["apiVersion"] = "management.cattle.io/v3"
+	data["kind"] = "NodePool"
+	if err := unstructured.SetNestedField(data, drain, "spec", "drainBeforeDelete"); err != nil {
+		return nil, err
+	}
+	return &unstructured.Unstructured{Object: data}, nil
+}
+
+// templateName returns the node template of Rancher, a NodeTemplate is used once it is reconciled
+func (h *handler) templateName(pool *v1.NodePool) (string, error) {
+	if strings.Contains(pool.Spec.NodeTemplateName, ":") {
+		return pool.Spec.NodeTemplateName, nil
+	}
+	template, err := h.templates.Get(pool.Namespace, pool.Spec.NodeTemplateName)
+	if apierror.IsNotFound(err) || (err == nil && template.Status.TemplateName == "") {
+		return "", fmt.Errorf("waiting for node template %s", pool.Spec.NodeTemplateName)
+	} else if err != nil {
+		return "", err
+	}
+	return template.Status.TemplateName, nil
+}
+
+func validate(spec v1.NodePoolSpec) error {
+	switch {
+	case spec.NodeTemplateName == "":
+		return fmt.Errorf("spec.nodeTemplateName is required")
+	case !spec.Etcd && !spec.ControlPlane && !spec.Worker:
+		return fmt.Errorf("at least one of spec.etcd, spec.controlPlane and spec.worker must be set")
+	case spec.Desired < 0 || spec.Min < 0 || spec.Max < 0:
+		return fmt.Errorf("spec.desired, spec.min and spec.max must not be negative")
+	case spec.Max > 0 && spec.Min > spec.Max:
+		return fmt.Errorf("spec.min %d must not be greater than spec.max %d", spec.Min, spec.Max)
+	}
+	return nil
+}
+
+// quantity is spec.desired within spec.min and spec.max
+func quantity(spec v1.NodePoolSpec) int {
+	quantity := spec.Desired
+	if quantity < spec.Min {
+		quantity = spec.Min
+	}
+	if spec.Max > 0 && quantity > spec.Max {
+		quantity = spec.Max
+	}
+	return quantity
+}
@@ -0,0 +1,103 @@
+package nodetemplates
+
+import (
+	"context"
+	"fmt"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/clients"
+	"github.com/rancher/rancher-operator/pkg/condition/kstatus"
+	rocontrollers "github.com/rancher/rancher-operator/pkg/generated/controllers/rancher.cattle.io/v1"
+	"github.com/rancher/wrangler/pkg/name"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// templateNamespace holds the node templates of Rancher
+const templateNamespace = "cattle-global-nt"
+
+// Register the controller that materializes a NodeTemplate as a node template of Rancher. The driver configs are
+// dynamic fields of the Rancher node templates, so they are applied as unstructured objects.
+func Register(ctx context.Context, clients *clients.Clients) {
+	rocontrollers.RegisterNodeTemplateGeneratingHandler(ctx,
+		clients.NodeTemplate(),
+		clients.Apply,
+		v1.NodeTemplateConditionReconciled,
+		"node-template",
+		onNodeTemplate,
+		nil)
+}
+
+func onNodeTemplate(template *v1.NodeTemplate, status v1.NodeTemplateStatus) ([]runtime.Object, v1.NodeTemplateStatus, error) {
+	kstatus.SetObservedGeneration(&status.ObservedGeneration, template)
+
+	driver, config, err := driverConfig(template.Spec)
+	if err != nil {
+		return nil, status, err
+	}
+	values, err := runtime.DefaultUnstructuredConverter.ToUnstructured(config)
+	if err != nil {
+		return nil, status, err
+	}
+
+	spec := map[string]interface{}{
+		"displayName": template.Name,
+		"driver":      driver,
+	}
+	if template.Spec.CloudCredentialName != "" {
+		spec["cloudCredentialName"] = template.Spec.CloudCredentialName
+	}
+	if template.Spec.EngineInstallURL != "" {
+		spec["engineInstallURL"] = template.Spec.EngineInstallURL
+	}
+	if template.Spec.UseInternalIPAddress {
+		spec["useInternalIpAddress"] = true
+	}
+
+	rTemplateName := templateName(template)
+	status.TemplateName = templateNamespace + ":" + rTemplateName
+	return []runtime.Object{
+		&unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "management.cattle.io/v3",
+				"kind":       "NodeTemplate",
+				"metadata": map[string]interface{}{
+					"name":      rTemplateName,
+					"namespace": templateNamespace,
+				},
+				"spec":            spec,
+				driver + "Config": values,
+			},
+		},
+	}, status, nil
+}
+
+// templateName is the name of the node template of Rancher, which are all in one namespace
+func templateName(template *v1.NodeTemplate) string {
+	return name.SafeConcatName("nt", template.Namespace, template.Name)
+}
+
+// driverConfig returns the node driver and its config, exactly one has to be set
+func driverConfig(spec v1.NodeTemplateSpec) (string, interface{}, error) {
+	var (
+		drivers []string
+		config  interface{}
+	)
+	if spec.AmazonEC2Config != nil {
+		drivers, config = append(drivers, "amazonec2"), spec.AmazonEC2Config
+	}
+	if spec.AzureConfig != nil {
+		drivers, config = append(drivers, "azure"), spec.AzureConfig
+	}
+	if spec.HarvesterConfig != nil {
+		drivers, config = append(drivers, "harvester"), spec.HarvesterConfig
+	}
+	if spec.VSphereConfig != nil {
+		drivers, config = append(drivers, "vmwarevsphere"), spec.VSphereConfig
+	}
+	if len(drivers) != 1 {
+		return "", nil, fmt.Errorf("exactly one of spec.amazonec2Config, spec.azureConfig, spec.harvesterConfig and spec.vmwarevsphereConfig must be set, found %d",
+			len(drivers))
+	}
+	return drivers[0], config, nil
+}
@@ -0,0 +1,87 @@
+package projects
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/namespaces"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// onProjectNamespaces assigns spec.namespaces to the project in each of its downstream clusters through the
+// generated kubeconfig, clusters without one are retried once their kubeconfig is written
+func (h *handler) onProjectNamespaces(key string, prj *v1.Project) (*v1.Project, error) {
+	if prj == nil || prj.DeletionTimestamp != nil {
+		return prj, nil
+	}
+	// a condition is kept once namespaces were assigned so emptying the list removes them again
+	if len(prj.Spec.Namespaces) == 0 && v1.ProjectConditionNamespacesAssigned.GetStatus(prj) == "" {
+		return prj, nil
+	}
+
+	clusters, err := projectClusters(prj, h.clusterCache)
+	if err != nil {
+		return prj, err
+	}
+
+	var waiting, failed []string
+	for _, cluster := range clusters {
+		if cluster.Status.ClusterName == "" || cluster.Status.ClientSecretName == "" {
+			waiting = append(waiting, cluster.Name)
+			continue
+		}
+		if err := h.assignNamespaces(cluster, prj); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", cluster.Name, err))
+		}
+	}
+	sort.Strings(waiting)
+	sort.Strings(failed)
+
+	status := "True"
+	message := ""
+	switch {
+	case len(failed) > 0:
+		status, message = "False", strings.Join(failed, "; ")
+	case len(waiting) > 0:
+		status, message = "Unknown", "waiting for the kubeconfig of "+strings.Join(waiting, ", ")
+	}
+
+	if v1.ProjectConditionNamespacesAssigned.GetStatus(prj) != status ||
+		v1.ProjectConditionNamespacesAssigned.GetMessage(prj) != message {
+		prj = prj.DeepCopy()
+		v1.ProjectConditionNamespacesAssigned.SetStatus(prj, status)
+		v1.ProjectConditionNamespacesAssigned.Message(prj, message)
+		updated, err := h.projectController.UpdateStatus(prj)
+		if err != nil {
+			return prj, err
+		}
+		prj = updated
+	}
+
+	if len(failed) > 0 {
+		return prj, fmt.Errorf("failed to assign namespaces: %s", message)
+	}
+	return prj, nil
+}
+
+func (h *handler) assignNamespaces(cluster *v1.Cluster, prj *v1.Project) error {
+	secret, err := h.secretCache.Get(cluster.Namespace, cluster.Status.ClientSecretName)
+	if apierror.IsNotFound(err) {
+		return fmt.Errorf("kubeconfig secret %s not found", cluster.Status.ClientSecretName)
+	} else if err != nil {
+		return err
+	}
+
+	cfg, err := clientcmd.RESTConfigFromKubeConfig(secret.Data["value"])
+	if err != nil {
+		return err
+	}
+
+	projectID := cluster.Status.ClusterName + ":" + projectName(cluster, prj)
+	return h.limiter.Do(cluster.Status.ClusterName, func() error {
+		return namespaces.Assign(cfg, projectID, prj.Spec.Namespaces)
+	})
+}
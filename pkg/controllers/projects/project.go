@@ -5,9 +5,12 @@ import (
 
 	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
 	"github.com/rancher/rancher-operator/pkg/clients"
+	"github.com/rancher/rancher-operator/pkg/downstream"
 	rocontrollers "github.com/rancher/rancher-operator/pkg/generated/controllers/rancher.cattle.io/v1"
 	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
 	"github.com/rancher/wrangler/pkg/name"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -17,13 +20,17 @@ type handler struct {
 	clusterCache      rocontrollers.ClusterCache
 	projectCache      rocontrollers.ProjectCache
 	projectController rocontrollers.ProjectController
+	secretCache       corecontrollers.SecretCache
+	limiter           *downstream.Limiter
 }
 
-func Register(ctx context.Context, clients *clients.Clients) {
+func Register(ctx context.Context, clients *clients.Clients, limiter *downstream.Limiter) {
 	h := handler{
 		clusterCache:      clients.Cluster().Cache(),
 		projectCache:      clients.Project().Cache(),
 		projectController: clients.Project(),
+		secretCache:       clients.Core.Secret().Cache(),
+		limiter:           limiter,
 	}
 
 	rocontrollers.RegisterProjectGeneratingHandler(ctx,
@@ -35,40 +42,83 @@ func Register(ctx context.Context, clients *clients.Clients) {
 		h.onProject,
 		nil)
 
+	clients.Project().OnChange(ctx, "project-namespaces", h.onProjectNamespaces)
 	clients.Cluster().OnChange(ctx, "project-cluster-trigger", h.onCluster)
 }
 
 func Projects(prj *v1.Project, clusterCache rocontrollers.ClusterCache) ([]*v3.Project, error) {
-	if prj.Spec.ClusterSelector == nil {
-		return nil, nil
-	}
-
-	sel, err := metav1.LabelSelectorAsSelector(prj.Spec.ClusterSelector)
+	clusters, err := projectClusters(prj, clusterCache)
 	if err != nil {
 		return nil, err
 	}
 
-	clusters, err := clusterCache.List(prj.Namespace, sel)
-	if err != nil {
-		return nil, err
+	displayName := prj.Spec.DisplayName
+	if displayName == "" {
+		displayName = prj.Name
 	}
 
 	var objs []*v3.Project
 	for _, cluster := range clusters {
+		// the management cluster namespace does not exist until the cluster is created
+		if cluster.Status.ClusterName == "" {
+			continue
+		}
 		objs = append(objs, &v3.Project{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      name.SafeConcatName("p", cluster.Name, prj.Name),
+				Name:      projectName(cluster, prj),
 				Namespace: cluster.Status.ClusterName,
 			},
 			Spec: v3.ProjectSpec{
-				DisplayName: prj.Name,
-				Description: prj.Annotations["field.cattle.io/description"],
-				ClusterName: cluster.Status.ClusterName,
+				DisplayName:                   displayName,
+				Description:                   prj.Annotations["field.cattle.io/description"],
+				ClusterName:                   cluster.Status.ClusterName,
+				ResourceQuota:                 prj.Spec.ResourceQuota,
+				NamespaceDefaultResourceQuota: prj.Spec.NamespaceDefaultResourceQuota,
+				ContainerDefaultResourceLimit: prj.Spec.ContainerDefaultResourceLimit,
 			},
 		})
 	}
 
-	return objs, err
+	return objs, nil
+}
+
+// projectName is the name of the management project generated for prj in cluster
+func projectName(cluster *v1.Cluster, prj *v1.Project) string {
+	return name.SafeConcatName("p", cluster.Name, prj.Name)
+}
+
+// projectClusters returns the clusters matched by the selector of prj followed by the cluster it names
+func projectClusters(prj *v1.Project, clusterCache rocontrollers.ClusterCache) ([]*v1.Cluster, error) {
+	var clusters []*v1.Cluster
+	if prj.Spec.ClusterSelector != nil {
+		sel, err := metav1.LabelSelectorAsSelector(prj.Spec.ClusterSelector)
+		if err != nil {
+			return nil, err
+		}
+
+		clusters, err = clusterCache.List(prj.Namespace, sel)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if prj.Spec.ClusterName == "" {
+		return clusters, nil
+	}
+	for _, cluster := range clusters {
+		if cluster.Name == prj.Spec.ClusterName {
+			return clusters, nil
+		}
+	}
+
+	cluster, err := clusterCache.Get(prj.Namespace, prj.Spec.ClusterName)
+	if apierror.IsNotFound(err) {
+		// the project is enqueued again by onCluster once the cluster is created
+		return clusters, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return append(clusters, cluster), nil
 }
 
 func (h *handler) onCluster(key string, cluster *v1.Cluster) (*v1.Cluster, error) {
@@ -83,12 +133,17 @@ func (h *handler) onCluster(key string, cluster *v1.Cluster) (*v1.Cluster, error
 
 	var errs []error
 	for _, prj := range prjs {
+		if prj.Spec.ClusterName == cluster.Name {
+			h.projectController.Enqueue(prj.Namespace, prj.Name)
+			continue
+		}
 		if prj.Spec.ClusterSelector == nil {
 			continue
 		}
 		sel, err := metav1.LabelSelectorAsSelector(prj.Spec.ClusterSelector)
 		if err != nil {
 			errs = append(errs, err)
+			continue
 		}
 		if sel.Matches(labels.Set(cluster.Labels)) {
 			h.projectController.Enqueue(prj.Namespace, prj.Name)
@@ -99,14 +154,26 @@ func (h *handler) onCluster(key string, cluster *v1.Cluster) (*v1.Cluster, error
 }
 
 func (h *handler) onProject(prj *v1.Project, status v1.ProjectStatus) ([]runtime.Object, v1.ProjectStatus, error) {
+	status.ObservedGeneration = prj.Generation
 	prjs, err := Projects(prj, h.clusterCache)
 	if err != nil {
 		return nil, status, err
 	}
 
 	var result []runtime.Object
-	for _, prj := range prjs {
-		result = append(result, prj)
+	for _, rPrj := range prjs {
+		result = append(result, rPrj)
+		// bindings live in the namespace Rancher creates for the management project
+		if prj.Spec.PodSecurityPolicyTemplateName != "" {
+			result = append(result, &v3.PodSecurityPolicyTemplateProjectBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name.SafeConcatName(rPrj.Name, "pspt"),
+					Namespace: rPrj.Name,
+				},
+				PodSecurityPolicyTemplateName: prj.Spec.PodSecurityPolicyTemplateName,
+				TargetProjectName:             rPrj.Spec.ClusterName + ":" + rPrj.Name,
+			})
+		}
 	}
 	return result, status, nil
 }
@@ -0,0 +1,131 @@
+package serviceusers
+
+import (
+	"context"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/clients"
+	"github.com/rancher/rancher-operator/pkg/condition/kstatus"
+	rocontrollers "github.com/rancher/rancher-operator/pkg/generated/controllers/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/kubeconfig"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
+	"github.com/rancher/wrangler/pkg/name"
+	"github.com/rancher/wrangler/pkg/relatedresource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const principalPrefix = "system://rancher-operator/"
+
+type handler struct {
+	userCache         rocontrollers.RancherUserCache
+	tokenCache        rocontrollers.RancherTokenCache
+	tokens            rocontrollers.RancherTokenController
+	secretCache       corecontrollers.SecretCache
+	secrets           corecontrollers.SecretClient
+	kubeconfigManager *kubeconfig.Manager
+}
+
+func Register(ctx context.Context, clients *clients.Clients, kubeconfigManager *kubeconfig.Manager) {
+	h := handler{
+		userCache:         clients.RancherUser().Cache(),
+		tokenCache:        clients.RancherToken().Cache(),
+		tokens:            clients.RancherToken(),
+		secretCache:       clients.Core.Secret().Cache(),
+		secrets:           clients.Core.Secret(),
+		kubeconfigManager: kubeconfigManager,
+	}
+
+	rocontrollers.RegisterRancherUserGeneratingHandler(ctx,
+		clients.RancherUser(),
+		clients.Apply.
+			WithCacheTypes(clients.Management.User(),
+				clients.Management.GlobalRoleBinding()),
+		"",
+		"rancher-user",
+		h.onUser,
+		nil)
+
+	rocontrollers.RegisterRancherTokenGeneratingHandler(ctx,
+		clients.RancherToken(),
+		clients.Apply.
+			WithCacheTypes(clients.Core.Secret()),
+		v1.RancherTokenConditionIssued,
+		"rancher-token",
+		h.onToken,
+		nil)
+	clients.RancherToken().OnRemove(ctx, "rancher-token-remove", h.onTokenRemove)
+
+	// tokens are issued once their user exists
+	relatedresource.Watch(ctx, "rancher-token-user", h.userTokens, clients.RancherToken(), clients.RancherUser())
+}
+
+// principalID is the principal of the management user created for user, it is never used to log in
+func principalID(user *v1.RancherUser) string {
+	return principalPrefix + user.Namespace + "/" + user.Name
+}
+
+func (h *handler) onUser(user *v1.RancherUser, status v1.RancherUserStatus) ([]runtime.Object, v1.RancherUserStatus, error) {
+	kstatus.SetObservedGeneration(&status.ObservedGeneration, user)
+
+	principal := principalID(user)
+	userName := kubeconfig.UserNameForPrincipal(principal)
+	displayName := user.Spec.DisplayName
+	if displayName == "" {
+		displayName = user.Name
+	}
+	enabled := !user.Spec.Disabled
+
+	objs := []runtime.Object{
+		&v3.User{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   userName,
+				Labels: kubeconfig.LabelsForUser(principal),
+			},
+			DisplayName: displayName,
+			Description: user.Annotations["field.cattle.io/description"],
+			PrincipalIDs: []string{
+				principal,
+				"local://" + userName,
+			},
+			Enabled: &enabled,
+		},
+	}
+	for _, role := range user.Spec.GlobalRoles {
+		objs = append(objs, &v3.GlobalRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name.SafeConcatName(userName, role),
+			},
+			GlobalRoleName: role,
+			UserName:       userName,
+		})
+	}
+
+	status.UserName = userName
+	status.PrincipalID = "local://" + userName
+	return objs, status, nil
+}
+
+func (h *handler) userTokens(namespace, name string, obj runtime.Object) ([]relatedresource.Key, error) {
+	if _, ok := obj.(*v1.RancherUser); !ok {
+		return nil, nil
+	}
+
+	tokens, err := h.tokenCache.List(namespace, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []relatedresource.Key
+	for _, token := range tokens {
+		if token.Spec.UserName == name {
+			keys = append(keys, relatedresource.Key{
+				Namespace: token.Namespace,
+				Name:      token.Name,
+			})
+		}
+	}
+	return keys, nil
+}
@@ -0,0 +1,118 @@
+package serviceusers
+
+import (
+	"crypto/sha256"
+	"encoding/base32"
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// tokenName is the name of the management token issued for tok, tokens are cluster scoped so it is hashed from
+// the namespace and name
+func tokenName(tok *v1.RancherToken) string {
+	hasher := sha256.New()
+	hasher.Write([]byte(tok.Namespace + "/" + tok.Name))
+	sha := base32.StdEncoding.WithPadding(-1).EncodeToString(hasher.Sum(nil))[:10]
+	return "t-" + strings.ToLower(sha)
+}
+
+func secretName(tok *v1.RancherToken) string {
+	if tok.Spec.SecretName != "" {
+		return tok.Spec.SecretName
+	}
+	return tok.Name + "-token"
+}
+
+func (h *handler) onToken(tok *v1.RancherToken, status v1.RancherTokenStatus) ([]runtime.Object, v1.RancherTokenStatus, error) {
+	user, err := h.userCache.Get(tok.Namespace, tok.Spec.UserName)
+	if apierror.IsNotFound(err) {
+		return nil, status, fmt.Errorf("waiting for user %s/%s", tok.Namespace, tok.Spec.UserName)
+	} else if err != nil {
+		return nil, status, err
+	}
+	if user.Status.UserName == "" {
+		return nil, status, fmt.Errorf("waiting for user %s/%s to be created in Rancher", tok.Namespace, tok.Spec.UserName)
+	}
+
+	secretName := secretName(tok)
+	ttl := time.Duration(tok.Spec.TTLSeconds) * time.Second
+
+	tokenValue, err := h.savedToken(tok.Namespace, secretName)
+	if err != nil {
+		return nil, status, err
+	}
+
+	token, valid, err := h.kubeconfigManager.ValidToken(tokenName(tok))
+	if err != nil {
+		return nil, status, err
+	}
+
+	issuedAt, _ := time.Parse(time.RFC3339, status.IssuedAt)
+	rotateAt := time.Time{}
+	if tok.Spec.RotateAfterSeconds > 0 && !issuedAt.IsZero() {
+		rotateAt = issuedAt.Add(time.Duration(tok.Spec.RotateAfterSeconds) * time.Second)
+	}
+
+	if !valid || tokenValue == "" || token.UserID != user.Status.UserName || token.TTLMillis != ttl.Milliseconds() ||
+		(!rotateAt.IsZero() && time.Now().After(rotateAt)) {
+		tokenValue, err = h.kubeconfigManager.CreateToken(tokenName(tok), user.Status.UserName, "", ttl)
+		if err != nil {
+			return nil, status, err
+		}
+		issuedAt = time.Now()
+		if tok.Spec.RotateAfterSeconds > 0 {
+			rotateAt = issuedAt.Add(time.Duration(tok.Spec.RotateAfterSeconds) * time.Second)
+		}
+	}
+
+	serverURL, _, err := h.kubeconfigManager.GetServerURLAndCA()
+	if err != nil {
+		return nil, status, err
+	}
+
+	if !rotateAt.IsZero() {
+		h.tokens.EnqueueAfter(tok.Namespace, tok.Name, time.Until(rotateAt)+time.Second)
+	}
+
+	status.ObservedGeneration = tok.Generation
+	status.TokenName = tokenName(tok)
+	status.SecretName = secretName
+	status.IssuedAt = issuedAt.UTC().Format(time.RFC3339)
+	return []runtime.Object{
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      secretName,
+				Namespace: tok.Namespace,
+			},
+			Data: map[string][]byte{
+				"token": []byte(tokenValue),
+				"url":   []byte(serverURL),
+			},
+		},
+	}, status, nil
+}
+
+// savedToken returns the token written to the secret, the cache may not have seen a secret that was just created
+func (h *handler) savedToken(namespace, name string) (string, error) {
+	secret, err := h.secretCache.Get(namespace, name)
+	if apierror.IsNotFound(err) {
+		secret, err = h.secrets.Get(namespace, name, metav1.GetOptions{})
+	}
+	if apierror.IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	return string(secret.Data["token"]), nil
+}
+
+func (h *handler) onTokenRemove(key string, tok *v1.RancherToken) (*v1.RancherToken, error) {
+	return tok, h.kubeconfigManager.DeleteToken(tokenName(tok))
+}
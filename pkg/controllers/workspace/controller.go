@@ -31,15 +31,15 @@ func Register(ctx context.Context, clients *clients.Clients) {
 	h := &handle{
 		workspaceCache: clients.Management.FleetWorkspace().Cache(),
 		workspaces:     clients.Management.FleetWorkspace(),
-		namespaceCache: clients.Core.Namespace().Cache(),
+		namespaceCache: clients.ManagementCore.Namespace().Cache(),
 	}
 
 	clients.Management.Setting().OnChange(ctx, "default-workspace", h.OnSetting)
 
 	mgmtcontrollers.RegisterFleetWorkspaceGeneratingHandler(ctx,
 		clients.Management.FleetWorkspace(),
-		clients.Apply.
-			WithCacheTypes(clients.Core.Namespace()),
+		clients.ManagementApply.
+			WithCacheTypes(clients.ManagementCore.Namespace()),
 		"",
 		"workspace",
 		h.OnChange,
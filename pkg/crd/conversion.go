@@ -0,0 +1,109 @@
+package crd
+
+import (
+	"context"
+
+	"github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1beta2"
+	"github.com/rancher/wrangler/pkg/crd"
+	apiextv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	clientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/retry"
+)
+
+const clusterCRDName = "clusters.rancher.cattle.io"
+
+// Conversion is the webhook service converting clusters between v1 and v1beta2
+type Conversion struct {
+	ServiceNamespace string
+	ServiceName      string
+	Path             string
+	CABundle         []byte
+}
+
+func toCRD(c crd.CRD) (*apiextv1beta1.CustomResourceDefinition, error) {
+	obj, err := c.ToCustomResourceDefinition()
+	if err != nil {
+		return nil, err
+	}
+	return &obj, nil
+}
+
+// serveV1Beta2 adds the v1beta2 version to the cluster CRD, v1 stays the storage version. Versions only differ
+// in their schema, so validation, subresources and columns move from the CRD to each version.
+func serveV1Beta2(ctx context.Context, cfg *rest.Config, conversion *Conversion) error {
+	v1beta2CRD, err := toCRD(newCRD(&v1beta2.Cluster{}, func(c crd.CRD) crd.CRD {
+		c.GVK.Version = v1beta2.SchemeGroupVersion.Version
+		return clusterColumns(c)
+	}))
+	if err != nil {
+		return err
+	}
+
+	client, err := clientset.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	crds := client.ApiextensionsV1beta1().CustomResourceDefinitions()
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		existing, err := crds.Get(ctx, clusterCRDName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		preserveUnknownFields := false
+		updated := existing.DeepCopy()
+		updated.Spec.PreserveUnknownFields = &preserveUnknownFields
+		updated.Spec.Versions = []apiextv1beta1.CustomResourceDefinitionVersion{
+			version(existing, "v1", true),
+			version(v1beta2CRD, v1beta2.SchemeGroupVersion.Version, false),
+		}
+		updated.Spec.Validation = nil
+		updated.Spec.Subresources = nil
+		updated.Spec.AdditionalPrinterColumns = nil
+		updated.Spec.Conversion = &apiextv1beta1.CustomResourceConversion{
+			Strategy: apiextv1beta1.WebhookConverter,
+			WebhookClientConfig: &apiextv1beta1.WebhookClientConfig{
+				Service: &apiextv1beta1.ServiceReference{
+					Namespace: conversion.ServiceNamespace,
+					Name:      conversion.ServiceName,
+					Path:      &conversion.Path,
+				},
+				CABundle: conversion.CABundle,
+			},
+			ConversionReviewVersions: []string{"v1beta1"},
+		}
+
+		_, err = crds.Update(ctx, updated, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+func version(c *apiextv1beta1.CustomResourceDefinition, name string, storage bool) apiextv1beta1.CustomResourceDefinitionVersion {
+	v := apiextv1beta1.CustomResourceDefinitionVersion{
+		Name:                     name,
+		Served:                   true,
+		Storage:                  storage,
+		Schema:                   c.Spec.Validation,
+		Subresources:             c.Spec.Subresources,
+		AdditionalPrinterColumns: c.Spec.AdditionalPrinterColumns,
+	}
+	for _, existing := range c.Spec.Versions {
+		if existing.Name != name {
+			continue
+		}
+		// already moved to the version by an earlier start
+		if v.Schema == nil {
+			v.Schema = existing.Schema
+		}
+		if v.Subresources == nil {
+			v.Subresources = existing.Subresources
+		}
+		if v.AdditionalPrinterColumns == nil {
+			v.AdditionalPrinterColumns = existing.AdditionalPrinterColumns
+		}
+	}
+	return v
+}
@@ -5,24 +5,100 @@ import (
 
 	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
 	"github.com/rancher/wrangler/pkg/crd"
+	apiextv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	clientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/rest"
 )
 
 func List() []crd.CRD {
 	return []crd.CRD{
-		newCRD(&v1.Cluster{}, func(c crd.CRD) crd.CRD {
+		newCRD(&v1.Cluster{}, clusterColumns),
+		newCRD(&v1.ClusterBackup{}, func(c crd.CRD) crd.CRD {
+			return withAge(c.
+				WithColumn("Cluster", ".spec.clusterName").
+				WithColumn("Completed", ".status.completedAt"))
+		}),
+		newCRD(&v1.ClusterDiscovery{}, func(c crd.CRD) crd.CRD {
+			return withAge(c.
+				WithColumn("Clusters", ".status.clusters").
+				WithColumn("Skipped", ".status.skipped"))
+		}),
+		newCRD(&v1.ClusterExport{}, func(c crd.CRD) crd.CRD {
+			return withAge(c.
+				WithColumn("Cluster", ".spec.clusterName").
+				WithColumn("Target", ".spec.target").
+				WithColumn("Exported", ".status.exportedAt"))
+		}),
+		newCRD(&v1.ClusterGroup{}, func(c crd.CRD) crd.CRD {
+			return c.
+				WithColumn("Clusters", ".status.clusters")
+		}),
+		newCRD(&v1.ClusterReferenceGrant{}, func(c crd.CRD) crd.CRD {
+			c.Status = false
+			return c
+		}),
+		newCRD(&v1.EncryptionKeyRotation{}, func(c crd.CRD) crd.CRD {
+			return withAge(c.
+				WithColumn("Cluster", ".spec.clusterName").
+				WithColumn("Phase", ".status.phase").
+				WithColumn("Rotated", ".status.lastRotationTime"))
+		}),
+		newCRD(&v1.EtcdSnapshotRestore{}, func(c crd.CRD) crd.CRD {
+			return withAge(c.
+				WithColumn("Cluster", ".spec.clusterName").
+				WithColumn("Snapshot", ".spec.snapshotName").
+				WithColumn("Phase", ".status.phase"))
+		}),
+		newCRD(&v1.GlobalRole{}, func(c crd.CRD) crd.CRD {
+			c.NonNamespace = true
 			return c.
-				WithColumn("Ready", ".status.ready").
-				WithColumn("Kubeconfig", ".status.clientSecretName")
+				WithColumn("Display Name", ".displayName").
+				WithColumn("Default", ".newUserDefault")
+		}),
+		newCRD(&v1.GlobalRoleBinding{}, func(c crd.CRD) crd.CRD {
+			c.NonNamespace = true
+			return c.
+				WithColumn("Role", ".globalRoleName")
+		}),
+		newCRD(&v1.GlobalRoleTemplateBinding{}, func(c crd.CRD) crd.CRD {
+			c.NonNamespace = true
+			return c.
+				WithColumn("Role", ".roleTemplateName").
+				WithColumn("Selector", ".clusterSelector")
+		}),
+		newCRD(&v1.NodePool{}, func(c crd.CRD) crd.CRD {
+			c = c.WithColumn("Cluster", ".spec.clusterName")
+			c = withIntegerColumn(c, "Desired", ".spec.desired")
+			c = withIntegerColumn(c, "Nodes", ".status.nodes")
+			c = withIntegerColumn(c, "Ready", ".status.readyNodes")
+			return withAge(c)
+		}),
+		newCRD(&v1.NodeTemplate{}, func(c crd.CRD) crd.CRD {
+			return withAge(c.
+				WithColumn("Template", ".status.templateName"))
 		}),
 		newCRD(&v1.Project{}, func(c crd.CRD) crd.CRD {
 			return c.
+				WithColumn("Cluster", ".spec.clusterName").
 				WithColumn("Selector", ".spec.clusterSelector")
 		}),
+		newCRD(&v1.RancherToken{}, func(c crd.CRD) crd.CRD {
+			return c.
+				WithColumn("User", ".spec.userName").
+				WithColumn("Secret", ".status.secretName").
+				WithColumn("Issued", ".status.issuedAt")
+		}),
+		newCRD(&v1.RancherUser{}, func(c crd.CRD) crd.CRD {
+			return c.
+				WithColumn("User", ".status.userName").
+				WithColumn("Principal", ".status.principalID")
+		}),
 		newCRD(&v1.RoleTemplate{}, func(c crd.CRD) crd.CRD {
 			c.NonNamespace = true
-			return c
+			return c.
+				WithColumn("Context", ".context").
+				WithColumn("Locked", ".locked")
 		}),
 		newCRD(&v1.RoleTemplateBinding{}, func(c crd.CRD) crd.CRD {
 			return c.
@@ -31,6 +107,35 @@ func List() []crd.CRD {
 	}
 }
 
+func clusterColumns(c crd.CRD) crd.CRD {
+	return withAge(c.
+		WithColumn("Phase", ".status.phase").
+		WithColumn("Ready", ".status.ready").
+		WithColumn("Provider", ".status.provider").
+		WithColumn("Version", ".status.kubernetesVersion").
+		WithColumn("Kubeconfig", ".status.clientSecretName"))
+}
+
+// withAge adds the AGE column kubectl shows by default, it is dropped once custom columns are defined
+func withAge(c crd.CRD) crd.CRD {
+	c.Columns = append(c.Columns, apiextv1beta1.CustomResourceColumnDefinition{
+		Name:     "Age",
+		Type:     "date",
+		JSONPath: ".metadata.creationTimestamp",
+	})
+	return c
+}
+
+// withIntegerColumn adds a column of a numeric field, WithColumn always declares the type string
+func withIntegerColumn(c crd.CRD, name, path string) crd.CRD {
+	c.Columns = append(c.Columns, apiextv1beta1.CustomResourceColumnDefinition{
+		Name:     name,
+		Type:     "integer",
+		JSONPath: path,
+	})
+	return c
+}
+
 func newCRD(obj interface{}, customize func(crd.CRD) crd.CRD) crd.CRD {
 	crd := crd.CRD{
 		GVK: schema.GroupVersionKind{
@@ -50,6 +155,30 @@ func WriteFile(filename string) error {
 	return crd.WriteFile(filename, List())
 }
 
-func Create(ctx context.Context, cfg *rest.Config) error {
-	return crd.Create(ctx, cfg, List())
+// Create creates or updates the CRDs, with a conversion webhook the clusters are also served as v1beta2. It fails
+// without changing them when they were installed by a newer operator. Objects stored in an earlier version are
+// migrated to the storage version.
+func Create(ctx context.Context, cfg *rest.Config, conversion *Conversion) error {
+	client, err := clientset.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	crds := List()
+	if err := checkRevision(ctx, client, crds); err != nil {
+		return err
+	}
+
+	if err := crd.Create(ctx, cfg, crds); err != nil {
+		return err
+	}
+	if conversion != nil {
+		if err := serveV1Beta2(ctx, cfg, conversion); err != nil {
+			return err
+		}
+	}
+
+	if err := recordRevision(ctx, client, crds); err != nil {
+		return err
+	}
+	return migrateStorage(ctx, cfg, client, crds)
 }
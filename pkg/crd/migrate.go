@@ -0,0 +1,152 @@
+package crd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/rancher/wrangler/pkg/crd"
+	"github.com/sirupsen/logrus"
+	apiextv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	clientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	// Revision is the revision of the CRDs of this binary. Bump it when the CRDs change in a way earlier
+	// binaries can't handle, they then refuse to start instead of reverting the CRDs.
+	Revision = 1
+
+	revisionAnnotation = "rancher.cattle.io/crd-revision"
+)
+
+// crdName returns the name of the CRD of c
+func crdName(c crd.CRD) (string, error) {
+	obj, err := toCRD(c)
+	if err != nil {
+		return "", err
+	}
+	return obj.Name, nil
+}
+
+// checkRevision fails if a CRD was installed by a binary with a newer Revision
+func checkRevision(ctx context.Context, client clientset.Interface, crds []crd.CRD) error {
+	for _, c := range crds {
+		name, err := crdName(c)
+		if err != nil {
+			return err
+		}
+		existing, err := client.ApiextensionsV1beta1().CustomResourceDefinitions().Get(ctx, name, metav1.GetOptions{})
+		if apierror.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		value, ok := existing.Annotations[revisionAnnotation]
+		if !ok {
+			continue
+		}
+		revision, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("CRD %s has an invalid %s annotation %q", name, revisionAnnotation, value)
+		}
+		if revision > Revision {
+			return fmt.Errorf("CRD %s is at revision %d, this operator only understands revision %d and older, upgrade the operator",
+				name, revision, Revision)
+		}
+	}
+	return nil
+}
+
+// recordRevision sets the Revision on the installed CRDs
+func recordRevision(ctx context.Context, client clientset.Interface, crds []crd.CRD) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				revisionAnnotation: strconv.Itoa(Revision),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	for _, c := range crds {
+		name, err := crdName(c)
+		if err != nil {
+			return err
+		}
+		if _, err := client.ApiextensionsV1beta1().CustomResourceDefinitions().Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateStorage rewrites the objects of the CRDs that still have objects stored in an earlier version, so the
+// earlier version can be dropped from the CRD. Writing an object back unchanged stores it in the current storage
+// version. Once every object was written the earlier versions are removed from status.storedVersions.
+func migrateStorage(ctx context.Context, cfg *rest.Config, client clientset.Interface, crds []crd.CRD) error {
+	dynamicClient, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range crds {
+		name, err := crdName(c)
+		if err != nil {
+			return err
+		}
+		existing, err := client.ApiextensionsV1beta1().CustomResourceDefinitions().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		storage := storageVersion(existing)
+		if storage == "" || (len(existing.Status.StoredVersions) == 1 && existing.Status.StoredVersions[0] == storage) {
+			continue
+		}
+
+		logrus.Infof("Migrating %s from versions %v to %s", name, existing.Status.StoredVersions, storage)
+		resource := dynamicClient.Resource(c.GVK.GroupVersion().WithResource(existing.Spec.Names.Plural))
+		objs, err := resource.List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return err
+		}
+		for i := range objs.Items {
+			obj := &objs.Items[i]
+			_, err := resource.Namespace(obj.GetNamespace()).Update(ctx, obj, metav1.UpdateOptions{})
+			// deleted or changed since the list, either way it was written in the storage version
+			if err != nil && !apierror.IsNotFound(err) && !apierror.IsConflict(err) {
+				return fmt.Errorf("migrating %s %s/%s: %w", name, obj.GetNamespace(), obj.GetName(), err)
+			}
+		}
+
+		err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			current, err := client.ApiextensionsV1beta1().CustomResourceDefinitions().Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			current.Status.StoredVersions = []string{storage}
+			_, err = client.ApiextensionsV1beta1().CustomResourceDefinitions().UpdateStatus(ctx, current, metav1.UpdateOptions{})
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func storageVersion(c *apiextv1beta1.CustomResourceDefinition) string {
+	for _, v := range c.Spec.Versions {
+		if v.Storage {
+			return v.Name
+		}
+	}
+	return c.Spec.Version
+}
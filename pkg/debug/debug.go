@@ -0,0 +1,113 @@
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/pprof"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rancher/rancher-operator/pkg/metrics"
+	"github.com/sirupsen/logrus"
+	"k8s.io/client-go/tools/cache"
+)
+
+var (
+	inFlightLock sync.Mutex
+	inFlight     = map[inFlightKey]time.Time{}
+)
+
+type inFlightKey struct {
+	handler string
+	key     string
+}
+
+// Reconcile is a reconcile that has not returned yet
+type Reconcile struct {
+	Handler        string  `json:"handler"`
+	Key            string  `json:"key"`
+	RunningSeconds float64 `json:"runningSeconds"`
+}
+
+// Controllers is the state of the controllers served at /debug/controllers
+type Controllers struct {
+	QueueDepths map[string]int64 `json:"queueDepths"`
+	InFlight    []Reconcile      `json:"inFlight"`
+	CacheSizes  map[string]int   `json:"cacheSizes"`
+}
+
+// Start records that handler is reconciling key until the returned func is called
+func Start(handler, key string) func() {
+	k := inFlightKey{
+		handler: handler,
+		key:     key,
+	}
+	inFlightLock.Lock()
+	inFlight[k] = time.Now()
+	inFlightLock.Unlock()
+	return func() {
+		inFlightLock.Lock()
+		delete(inFlight, k)
+		inFlightLock.Unlock()
+	}
+}
+
+func reconciles() []Reconcile {
+	inFlightLock.Lock()
+	defer inFlightLock.Unlock()
+	result := make([]Reconcile, 0, len(inFlight))
+	for k, start := range inFlight {
+		result = append(result, Reconcile{
+			Handler:        k.handler,
+			Key:            k.key,
+			RunningSeconds: time.Since(start).Seconds(),
+		})
+	}
+	// longest running first, those are the ones that are stuck
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].RunningSeconds > result[j].RunningSeconds
+	})
+	return result
+}
+
+// Serve serves the pprof profiles under /debug/pprof/ and the state of the controllers at /debug/controllers.
+// The informers are reported by name with the number of objects in their cache.
+func Serve(ctx context.Context, address string, informers map[string]cache.SharedIndexInformer) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.HandleFunc("/debug/controllers", func(rw http.ResponseWriter, req *http.Request) {
+		state := Controllers{
+			QueueDepths: metrics.QueueDepths(),
+			InFlight:    reconciles(),
+			CacheSizes:  map[string]int{},
+		}
+		for name, informer := range informers {
+			state.CacheSizes[name] = len(informer.GetStore().ListKeys())
+		}
+		rw.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(rw).Encode(state); err != nil {
+			logrus.Errorf("failed to write controller state: %v", err)
+		}
+	})
+	server := &http.Server{
+		Addr:    address,
+		Handler: mux,
+	}
+
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logrus.Errorf("debug server failed: %v", err)
+		}
+	}()
+}
@@ -0,0 +1,130 @@
+package decommission
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+type Result struct {
+	Done    bool
+	Message string
+}
+
+// protected reports namespaces that are never deleted because the cluster can't function without them
+func protected(namespace string) bool {
+	return namespace == "default" ||
+		strings.HasPrefix(namespace, "kube-") ||
+		strings.HasPrefix(namespace, "cattle-")
+}
+
+// Run deletes the downstream resources selected by policy. Deletion is asynchronous, Run should be called until
+// the result is Done, which is once everything is gone including the volumes backing deleted claims. Load balancers
+// and claims are only deleted in the namespaces matching NamespaceSelector, all of them if it is not set, and never
+// in protected namespaces.
+func Run(cfg *rest.Config, policy *v1.DecommissionPolicy) (Result, error) {
+	k8s, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return Result{}, err
+	}
+
+	sel := labels.Everything()
+	if policy.NamespaceSelector != nil {
+		sel, err = metav1.LabelSelectorAsSelector(policy.NamespaceSelector)
+		if err != nil {
+			return Result{}, err
+		}
+	}
+	namespaces, err := k8s.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{
+		LabelSelector: sel.String(),
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	selected := map[string]bool{}
+	for _, ns := range namespaces.Items {
+		if !protected(ns.Name) {
+			selected[ns.Name] = true
+		}
+	}
+
+	var remaining []string
+
+	if policy.NamespaceSelector != nil {
+		for _, ns := range namespaces.Items {
+			if !selected[ns.Name] {
+				continue
+			}
+			if err := deleteIgnoreNotFound(k8s.CoreV1().Namespaces().Delete(context.TODO(), ns.Name, metav1.DeleteOptions{})); err != nil {
+				return Result{}, err
+			}
+			remaining = append(remaining, "namespace "+ns.Name)
+		}
+	}
+
+	if policy.DeleteLoadBalancers {
+		services, err := k8s.CoreV1().Services("").List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return Result{}, err
+		}
+		for _, svc := range services.Items {
+			if svc.Spec.Type != corev1.ServiceTypeLoadBalancer || !selected[svc.Namespace] {
+				continue
+			}
+			if err := deleteIgnoreNotFound(k8s.CoreV1().Services(svc.Namespace).Delete(context.TODO(), svc.Name, metav1.DeleteOptions{})); err != nil {
+				return Result{}, err
+			}
+			remaining = append(remaining, fmt.Sprintf("service %s/%s", svc.Namespace, svc.Name))
+		}
+	}
+
+	if policy.DeletePersistentVolumeClaims {
+		pvcs, err := k8s.CoreV1().PersistentVolumeClaims("").List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return Result{}, err
+		}
+		for _, pvc := range pvcs.Items {
+			if !selected[pvc.Namespace] {
+				continue
+			}
+			if err := deleteIgnoreNotFound(k8s.CoreV1().PersistentVolumeClaims(pvc.Namespace).Delete(context.TODO(), pvc.Name, metav1.DeleteOptions{})); err != nil {
+				return Result{}, err
+			}
+			remaining = append(remaining, fmt.Sprintf("persistentvolumeclaim %s/%s", pvc.Namespace, pvc.Name))
+		}
+
+		// volumes with the Delete policy are only gone once the provisioner has removed the backing storage, the
+		// claim reference is kept after the claim is deleted
+		pvs, err := k8s.CoreV1().PersistentVolumes().List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return Result{}, err
+		}
+		for _, pv := range pvs.Items {
+			if pv.Spec.PersistentVolumeReclaimPolicy != corev1.PersistentVolumeReclaimDelete ||
+				pv.Spec.ClaimRef == nil || !selected[pv.Spec.ClaimRef.Namespace] {
+				continue
+			}
+			remaining = append(remaining, "persistentvolume "+pv.Name)
+		}
+	}
+
+	if len(remaining) > 0 {
+		return Result{Message: "waiting for " + strings.Join(remaining, ", ")}, nil
+	}
+	return Result{Done: true, Message: "downstream resources removed"}, nil
+}
+
+func deleteIgnoreNotFound(err error) error {
+	if apierror.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,114 @@
+package downstream
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	DefaultConcurrency        = 50
+	DefaultClusterConcurrency = 2
+)
+
+var (
+	waiting = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "rancher_operator",
+		Subsystem: "downstream",
+		Name:      "waiting",
+		Help:      "Number of downstream operations waiting for a free slot",
+	})
+	active = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "rancher_operator",
+		Subsystem: "downstream",
+		Name:      "active",
+		Help:      "Number of downstream operations in progress",
+	})
+	waitSeconds = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "rancher_operator",
+		Subsystem: "downstream",
+		Name:      "wait_seconds",
+		Help:      "Time downstream operations waited for a free slot",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 4, 8),
+	})
+)
+
+func init() {
+	prometheus.MustRegister(waiting, active, waitSeconds)
+}
+
+// Limiter caps the number of concurrent connections to downstream clusters, both in total and per cluster, so
+// that many clusters becoming ready at once don't exhaust file descriptors or egress bandwidth.
+type Limiter struct {
+	global             chan struct{}
+	clusterConcurrency int
+
+	lock     sync.Mutex
+	clusters map[string]*clusterSlots
+}
+
+type clusterSlots struct {
+	slots chan struct{}
+	users int
+}
+
+func NewLimiter(concurrency, clusterConcurrency int) *Limiter {
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	if clusterConcurrency <= 0 {
+		clusterConcurrency = DefaultClusterConcurrency
+	}
+	return &Limiter{
+		global:             make(chan struct{}, concurrency),
+		clusterConcurrency: clusterConcurrency,
+		clusters:           map[string]*clusterSlots{},
+	}
+}
+
+// Do runs f once a slot is free for cluster and globally
+func (l *Limiter) Do(cluster string, f func() error) error {
+	start := time.Now()
+	waiting.Inc()
+
+	c := l.acquireCluster(cluster)
+	defer l.releaseCluster(cluster, c)
+
+	c.slots <- struct{}{}
+	defer func() { <-c.slots }()
+	l.global <- struct{}{}
+	defer func() { <-l.global }()
+
+	waiting.Dec()
+	waitSeconds.Observe(time.Since(start).Seconds())
+
+	active.Inc()
+	defer active.Dec()
+	return f()
+}
+
+func (l *Limiter) acquireCluster(cluster string) *clusterSlots {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	c, ok := l.clusters[cluster]
+	if !ok {
+		c = &clusterSlots{
+			slots: make(chan struct{}, l.clusterConcurrency),
+		}
+		l.clusters[cluster] = c
+	}
+	c.users++
+	return c
+}
+
+func (l *Limiter) releaseCluster(cluster string, c *clusterSlots) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	c.users--
+	if c.users == 0 {
+		delete(l.clusters, cluster)
+	}
+}
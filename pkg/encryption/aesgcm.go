@@ -0,0 +1,54 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+const (
+	AESGCMProvider = "aesgcm"
+)
+
+type aesGCM struct {
+	aead cipher.AEAD
+}
+
+// NewAESGCMFromFile reads a base64 encoded 16, 24 or 32 byte key from path. The ciphertext of values
+// is expected to be the nonce followed by the sealed data.
+func NewAESGCMFromFile(path string) (Decrypter, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(content)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid key in %s: %w", path, err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &aesGCM{
+		aead: aead,
+	}, nil
+}
+
+func (a *aesGCM) Decrypt(ciphertext []byte) ([]byte, error) {
+	nonceSize := a.aead.NonceSize()
+	if len(ciphertext) < nonceSize {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+	return a.aead.Open(nil, ciphertext[:nonceSize], ciphertext[nonceSize:], nil)
+}
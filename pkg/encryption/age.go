@@ -0,0 +1,45 @@
+package encryption
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"filippo.io/age"
+)
+
+const (
+	AgeProvider = "age"
+)
+
+type ageDecrypter struct {
+	identities []age.Identity
+}
+
+// NewAgeFromFile reads the age identities, like those written by age-keygen, from path. The ciphertext of values is
+// expected to be the binary, not armored, age file encrypted to one of their recipients.
+func NewAgeFromFile(path string) (Decrypter, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	identities, err := age.ParseIdentities(f)
+	if err != nil {
+		return nil, fmt.Errorf("invalid identities in %s: %w", path, err)
+	}
+
+	return &ageDecrypter{
+		identities: identities,
+	}, nil
+}
+
+func (a *ageDecrypter) Decrypt(ciphertext []byte) ([]byte, error) {
+	r, err := age.Decrypt(bytes.NewReader(ciphertext), a.identities...)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadAll(r)
+}
@@ -0,0 +1,48 @@
+package encryption
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+)
+
+func TestDecryptAge(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "identities")
+	if err := ioutil.WriteFile(path, []byte(identity.String()+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := &bytes.Buffer{}
+	w, err := age.Encrypt(buf, identity.Recipient())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("secret")); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	decrypters, err := New("", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := map[string]interface{}{
+		"password": "ENC[age," + base64.StdEncoding.EncodeToString(buf.Bytes()) + "]",
+	}
+	if err := decrypters.DecryptValues(data); err != nil {
+		t.Fatal(err)
+	}
+	if data["password"] != "secret" {
+		t.Errorf("got %v, want secret", data["password"])
+	}
+}
@@ -0,0 +1,93 @@
+package encryption
+
+import (
+	"encoding/base64"
+	"fmt"
+	"regexp"
+)
+
+var (
+	// encryptedValue matches values like ENC[aesgcm,base64data]
+	encryptedValue = regexp.MustCompile(`^ENC\[([a-z0-9-]+),([A-Za-z0-9+/=]+)\]$`)
+)
+
+// Decrypter turns the ciphertext of an encrypted value back into plaintext. It is selected by the provider
+// name in the value, a local AES-GCM key or age identities. A KMS provider is added by registering its
+// Decrypter under its own name in New.
+type Decrypter interface {
+	Decrypt(ciphertext []byte) ([]byte, error)
+}
+
+// Decrypters maps a provider name to its Decrypter
+type Decrypters map[string]Decrypter
+
+// New returns the decrypters configured for the operator
+func New(keyFile, ageIdentityFile string) (Decrypters, error) {
+	result := Decrypters{}
+	if keyFile != "" {
+		aesgcm, err := NewAESGCMFromFile(keyFile)
+		if err != nil {
+			return nil, err
+		}
+		result[AESGCMProvider] = aesgcm
+	}
+	if ageIdentityFile != "" {
+		age, err := NewAgeFromFile(ageIdentityFile)
+		if err != nil {
+			return nil, err
+		}
+		result[AgeProvider] = age
+	}
+	return result, nil
+}
+
+// DecryptValues replaces in place all encrypted string values found in data with their plaintext
+func (d Decrypters) DecryptValues(data map[string]interface{}) error {
+	for k, v := range data {
+		newValue, err := d.decrypt(v)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt %s: %w", k, err)
+		}
+		data[k] = newValue
+	}
+	return nil
+}
+
+func (d Decrypters) decrypt(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v, d.DecryptValues(v)
+	case []interface{}:
+		for i := range v {
+			newValue, err := d.decrypt(v[i])
+			if err != nil {
+				return nil, err
+			}
+			v[i] = newValue
+		}
+		return v, nil
+	case string:
+		return d.decryptString(v)
+	}
+	return value, nil
+}
+
+func (d Decrypters) decryptString(value string) (string, error) {
+	match := encryptedValue.FindStringSubmatch(value)
+	if len(match) != 3 {
+		return value, nil
+	}
+
+	decrypter, ok := d[match[1]]
+	if !ok {
+		return "", fmt.Errorf("no decrypter configured for provider %s", match[1])
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(match[2])
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := decrypter.Decrypt(ciphertext)
+	return string(plaintext), err
+}
@@ -0,0 +1,127 @@
+package features
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// The maturity of a feature, alpha features are off by default
+const (
+	Alpha = "Alpha"
+	Beta  = "Beta"
+	GA    = "GA"
+)
+
+// Feature is a subsystem or behavior that can be turned on or off with --feature-gates
+type Feature struct {
+	Name    string
+	Default bool
+	Stage   string
+}
+
+var (
+	// ServerDryRun sends management clusters to the API server as a dry run before they are applied
+	ServerDryRun = register("ServerDryRun", true, Beta)
+	// ResyncPriority applies new and changed clusters before the resyncs of settled clusters
+	ResyncPriority = register("ResyncPriority", true, Beta)
+)
+
+var (
+	lock    sync.RWMutex
+	known   = map[string]*Feature{}
+	enabled = map[string]bool{}
+)
+
+func register(name string, def bool, stage string) *Feature {
+	f := &Feature{
+		Name:    name,
+		Default: def,
+		Stage:   stage,
+	}
+	known[name] = f
+	return f
+}
+
+// Lookup returns the feature called name, nil if there is none
+func Lookup(name string) *Feature {
+	return known[name]
+}
+
+// Enabled returns if the feature was turned on by --feature-gates, otherwise its default
+func (f *Feature) Enabled() bool {
+	lock.RLock()
+	defer lock.RUnlock()
+	if on, ok := enabled[f.Name]; ok {
+		return on
+	}
+	return f.Default
+}
+
+// Parse parses comma separated <feature>=<true|false> pairs, unknown features are an error
+func Parse(value string) (map[string]bool, error) {
+	gates := map[string]bool{}
+	for _, gate := range strings.Split(value, ",") {
+		if gate = strings.TrimSpace(gate); gate == "" {
+			continue
+		}
+		parts := strings.SplitN(gate, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("%q must be <feature>=<true|false>", gate)
+		}
+		name := strings.TrimSpace(parts[0])
+		if Lookup(name) == nil {
+			return nil, fmt.Errorf("unknown feature %q, known features are %s", name, strings.Join(Names(), ", "))
+		}
+		on, err := strconv.ParseBool(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, fmt.Errorf("%q must be <feature>=<true|false>", gate)
+		}
+		gates[name] = on
+	}
+	return gates, nil
+}
+
+// Set turns the features of the --feature-gates value on or off
+func Set(value string) error {
+	gates, err := Parse(value)
+	if err != nil {
+		return err
+	}
+	lock.Lock()
+	enabled = gates
+	lock.Unlock()
+
+	for _, name := range Names() {
+		f := known[name]
+		if on := f.Enabled(); on != f.Default || f.Stage == Alpha {
+			logrus.Infof("Feature %s (%s) enabled: %v", f.Name, f.Stage, on)
+		}
+	}
+	return nil
+}
+
+// EnabledNames returns the sorted names of the enabled features
+func EnabledNames() []string {
+	var names []string
+	for _, name := range Names() {
+		if known[name].Enabled() {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Names returns the sorted names of the known features
+func Names() []string {
+	var names []string
+	for name := range known {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,134 @@
+package fleetstatus
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	fleet "github.com/rancher/fleet/pkg/apis/fleet.cattle.io/v1alpha1"
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/wrangler/pkg/condition"
+	"github.com/rancher/wrangler/pkg/yaml"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+)
+
+// ConditionClustersReady is set on bundles and git repos that deploy clusters, false lists the clusters that are
+// not ready
+const ConditionClustersReady condition.Cond = "ClustersReady"
+
+// ClusterRef is a cluster deployed by a bundle
+type ClusterRef struct {
+	Namespace string
+	Name      string
+}
+
+func (c ClusterRef) String() string {
+	return c.Namespace + "/" + c.Name
+}
+
+// Clusters returns the rancher.cattle.io clusters in the plain YAML and JSON resources of bundle, charts and
+// kustomizations are not rendered
+func Clusters(bundle *fleet.Bundle) ([]ClusterRef, error) {
+	var refs []ClusterRef
+	for _, resource := range bundle.Spec.Resources {
+		switch strings.ToLower(filepath.Ext(resource.Name)) {
+		case ".yaml", ".yml", ".json":
+		default:
+			continue
+		}
+
+		content, err := decode(resource)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", resource.Name, err)
+		}
+		objs, err := yaml.ToObjects(bytes.NewReader(content))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", resource.Name, err)
+		}
+
+		for _, obj := range objs {
+			gvk := obj.GetObjectKind().GroupVersionKind()
+			if gvk.Group != v1.SchemeGroupVersion.Group || gvk.Kind != "Cluster" {
+				continue
+			}
+			m, err := meta.Accessor(obj)
+			if err != nil {
+				return nil, err
+			}
+			refs = append(refs, ClusterRef{
+				Namespace: clusterNamespace(bundle, m.GetNamespace()),
+				Name:      m.GetName(),
+			})
+		}
+	}
+	return refs, nil
+}
+
+// clusterNamespace returns the namespace fleet deploys an object of namespace to
+func clusterNamespace(bundle *fleet.Bundle, namespace string) string {
+	switch {
+	case bundle.Spec.TargetNamespace != "":
+		return bundle.Spec.TargetNamespace
+	case namespace != "":
+		return namespace
+	case bundle.Spec.DefaultNamespace != "":
+		return bundle.Spec.DefaultNamespace
+	}
+	return "default"
+}
+
+func decode(resource fleet.BundleResource) ([]byte, error) {
+	switch resource.Encoding {
+	case "":
+		return []byte(resource.Content), nil
+	case "base64":
+		return base64.StdEncoding.DecodeString(resource.Content)
+	case "base64+gz":
+		data, err := base64.StdEncoding.DecodeString(resource.Content)
+		if err != nil {
+			return nil, err
+		}
+		r, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		return ioutil.ReadAll(r)
+	}
+	return nil, fmt.Errorf("unsupported encoding %q", resource.Encoding)
+}
+
+// Summarize returns true if every cluster is ready, otherwise a message with the phase of those that are not.
+// Clusters get returns not found for are reported as pending, fleet may not have created them yet.
+func Summarize(refs []ClusterRef, get func(namespace, name string) (*v1.Cluster, error)) (bool, string, error) {
+	var notReady []string
+	for _, ref := range refs {
+		cluster, err := get(ref.Namespace, ref.Name)
+		if apierror.IsNotFound(err) {
+			notReady = append(notReady, fmt.Sprintf("%s is %s", ref, v1.ClusterPhasePending))
+			continue
+		} else if err != nil {
+			return false, "", err
+		}
+		if v1.ClusterConditionReady.IsTrue(cluster) {
+			continue
+		}
+		phase := cluster.Status.Phase
+		if phase == "" {
+			phase = v1.ClusterPhasePending
+		}
+		notReady = append(notReady, fmt.Sprintf("%s is %s", ref, phase))
+	}
+
+	if len(notReady) == 0 {
+		return true, "", nil
+	}
+	sort.Strings(notReady)
+	return false, strings.Join(notReady, ", "), nil
+}
@@ -30,6 +30,7 @@ func init() {
 }
 
 type Interface interface {
+	Bundle() BundleController
 	Cluster() ClusterController
 	ClusterGroup() ClusterGroupController
 	ClusterRegistrationToken() ClusterRegistrationTokenController
@@ -46,6 +47,9 @@ type version struct {
 	controllerFactory controller.SharedControllerFactory
 }
 
+func (c *version) Bundle() BundleController {
+	return NewBundleController(schema.GroupVersionKind{Group: "fleet.cattle.io", Version: "v1alpha1", Kind: "Bundle"}, "bundles", true, c.controllerFactory)
+}
 func (c *version) Cluster() ClusterController {
 	return NewClusterController(schema.GroupVersionKind{Group: "fleet.cattle.io", Version: "v1alpha1", Kind: "Cluster"}, "clusters", true, c.controllerFactory)
 }
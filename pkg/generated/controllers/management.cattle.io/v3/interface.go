@@ -33,7 +33,12 @@ type Interface interface {
 	Cluster() ClusterController
 	ClusterRegistrationToken() ClusterRegistrationTokenController
 	ClusterRoleTemplateBinding() ClusterRoleTemplateBindingController
+	EtcdBackup() EtcdBackupController
 	FleetWorkspace() FleetWorkspaceController
+	GlobalRole() GlobalRoleController
+	GlobalRoleBinding() GlobalRoleBindingController
+	Node() NodeController
+	NodePool() NodePoolController
 	Project() ProjectController
 	ProjectRoleTemplateBinding() ProjectRoleTemplateBindingController
 	RoleTemplate() RoleTemplateController
@@ -61,9 +66,24 @@ func (c *version) ClusterRegistrationToken() ClusterRegistrationTokenController
 func (c *version) ClusterRoleTemplateBinding() ClusterRoleTemplateBindingController {
 	return NewClusterRoleTemplateBindingController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "ClusterRoleTemplateBinding"}, "clusterroletemplatebindings", true, c.controllerFactory)
 }
+func (c *version) EtcdBackup() EtcdBackupController {
+	return NewEtcdBackupController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "EtcdBackup"}, "etcdbackups", true, c.controllerFactory)
+}
 func (c *version) FleetWorkspace() FleetWorkspaceController {
 	return NewFleetWorkspaceController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "FleetWorkspace"}, "fleetworkspaces", false, c.controllerFactory)
 }
+func (c *version) GlobalRole() GlobalRoleController {
+	return NewGlobalRoleController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "GlobalRole"}, "globalroles", false, c.controllerFactory)
+}
+func (c *version) GlobalRoleBinding() GlobalRoleBindingController {
+	return NewGlobalRoleBindingController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "GlobalRoleBinding"}, "globalrolebindings", false, c.controllerFactory)
+}
+func (c *version) Node() NodeController {
+	return NewNodeController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "Node"}, "nodes", true, c.controllerFactory)
+}
+func (c *version) NodePool() NodePoolController {
+	return NewNodePoolController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "NodePool"}, "nodepools", true, c.controllerFactory)
+}
 func (c *version) Project() ProjectController {
 	return NewProjectController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "Project"}, "projects", true, c.controllerFactory)
 }
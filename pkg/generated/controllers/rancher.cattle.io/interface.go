@@ -21,10 +21,12 @@ package rancher
 import (
 	"github.com/rancher/lasso/pkg/controller"
 	v1 "github.com/rancher/rancher-operator/pkg/generated/controllers/rancher.cattle.io/v1"
+	v1beta2 "github.com/rancher/rancher-operator/pkg/generated/controllers/rancher.cattle.io/v1beta2"
 )
 
 type Interface interface {
 	V1() v1.Interface
+	V1beta2() v1beta2.Interface
 }
 
 type group struct {
@@ -41,3 +43,7 @@ func New(controllerFactory controller.SharedControllerFactory) Interface {
 func (g *group) V1() v1.Interface {
 	return v1.New(g.controllerFactory)
 }
+
+func (g *group) V1beta2() v1beta2.Interface {
+	return v1beta2.New(g.controllerFactory)
+}
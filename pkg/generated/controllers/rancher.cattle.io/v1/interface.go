@@ -31,7 +31,21 @@ func init() {
 
 type Interface interface {
 	Cluster() ClusterController
+	ClusterBackup() ClusterBackupController
+	ClusterDiscovery() ClusterDiscoveryController
+	ClusterExport() ClusterExportController
+	ClusterGroup() ClusterGroupController
+	ClusterReferenceGrant() ClusterReferenceGrantController
+	EncryptionKeyRotation() EncryptionKeyRotationController
+	EtcdSnapshotRestore() EtcdSnapshotRestoreController
+	GlobalRole() GlobalRoleController
+	GlobalRoleBinding() GlobalRoleBindingController
+	GlobalRoleTemplateBinding() GlobalRoleTemplateBindingController
+	NodePool() NodePoolController
+	NodeTemplate() NodeTemplateController
 	Project() ProjectController
+	RancherToken() RancherTokenController
+	RancherUser() RancherUserController
 	RoleTemplate() RoleTemplateController
 	RoleTemplateBinding() RoleTemplateBindingController
 }
@@ -49,9 +63,51 @@ type version struct {
 func (c *version) Cluster() ClusterController {
 	return NewClusterController(schema.GroupVersionKind{Group: "rancher.cattle.io", Version: "v1", Kind: "Cluster"}, "clusters", true, c.controllerFactory)
 }
+func (c *version) ClusterBackup() ClusterBackupController {
+	return NewClusterBackupController(schema.GroupVersionKind{Group: "rancher.cattle.io", Version: "v1", Kind: "ClusterBackup"}, "clusterbackups", true, c.controllerFactory)
+}
+func (c *version) ClusterDiscovery() ClusterDiscoveryController {
+	return NewClusterDiscoveryController(schema.GroupVersionKind{Group: "rancher.cattle.io", Version: "v1", Kind: "ClusterDiscovery"}, "clusterdiscoveries", true, c.controllerFactory)
+}
+func (c *version) ClusterExport() ClusterExportController {
+	return NewClusterExportController(schema.GroupVersionKind{Group: "rancher.cattle.io", Version: "v1", Kind: "ClusterExport"}, "clusterexports", true, c.controllerFactory)
+}
+func (c *version) ClusterGroup() ClusterGroupController {
+	return NewClusterGroupController(schema.GroupVersionKind{Group: "rancher.cattle.io", Version: "v1", Kind: "ClusterGroup"}, "clustergroups", true, c.controllerFactory)
+}
+func (c *version) ClusterReferenceGrant() ClusterReferenceGrantController {
+	return NewClusterReferenceGrantController(schema.GroupVersionKind{Group: "rancher.cattle.io", Version: "v1", Kind: "ClusterReferenceGrant"}, "clusterreferencegrants", true, c.controllerFactory)
+}
+func (c *version) EncryptionKeyRotation() EncryptionKeyRotationController {
+	return NewEncryptionKeyRotationController(schema.GroupVersionKind{Group: "rancher.cattle.io", Version: "v1", Kind: "EncryptionKeyRotation"}, "encryptionkeyrotations", true, c.controllerFactory)
+}
+func (c *version) EtcdSnapshotRestore() EtcdSnapshotRestoreController {
+	return NewEtcdSnapshotRestoreController(schema.GroupVersionKind{Group: "rancher.cattle.io", Version: "v1", Kind: "EtcdSnapshotRestore"}, "etcdsnapshotrestores", true, c.controllerFactory)
+}
+func (c *version) GlobalRole() GlobalRoleController {
+	return NewGlobalRoleController(schema.GroupVersionKind{Group: "rancher.cattle.io", Version: "v1", Kind: "GlobalRole"}, "globalroles", false, c.controllerFactory)
+}
+func (c *version) GlobalRoleBinding() GlobalRoleBindingController {
+	return NewGlobalRoleBindingController(schema.GroupVersionKind{Group: "rancher.cattle.io", Version: "v1", Kind: "GlobalRoleBinding"}, "globalrolebindings", false, c.controllerFactory)
+}
+func (c *version) GlobalRoleTemplateBinding() GlobalRoleTemplateBindingController {
+	return NewGlobalRoleTemplateBindingController(schema.GroupVersionKind{Group: "rancher.cattle.io", Version: "v1", Kind: "GlobalRoleTemplateBinding"}, "globalroletemplatebindings", false, c.controllerFactory)
+}
+func (c *version) NodePool() NodePoolController {
+	return NewNodePoolController(schema.GroupVersionKind{Group: "rancher.cattle.io", Version: "v1", Kind: "NodePool"}, "nodepools", true, c.controllerFactory)
+}
+func (c *version) NodeTemplate() NodeTemplateController {
+	return NewNodeTemplateController(schema.GroupVersionKind{Group: "rancher.cattle.io", Version: "v1", Kind: "NodeTemplate"}, "nodetemplates", true, c.controllerFactory)
+}
 func (c *version) Project() ProjectController {
 	return NewProjectController(schema.GroupVersionKind{Group: "rancher.cattle.io", Version: "v1", Kind: "Project"}, "projects", true, c.controllerFactory)
 }
+func (c *version) RancherToken() RancherTokenController {
+	return NewRancherTokenController(schema.GroupVersionKind{Group: "rancher.cattle.io", Version: "v1", Kind: "RancherToken"}, "ranchertokens", true, c.controllerFactory)
+}
+func (c *version) RancherUser() RancherUserController {
+	return NewRancherUserController(schema.GroupVersionKind{Group: "rancher.cattle.io", Version: "v1", Kind: "RancherUser"}, "rancherusers", true, c.controllerFactory)
+}
 func (c *version) RoleTemplate() RoleTemplateController {
 	return NewRoleTemplateController(schema.GroupVersionKind{Group: "rancher.cattle.io", Version: "v1", Kind: "RoleTemplate"}, "roletemplates", false, c.controllerFactory)
 }
@@ -0,0 +1,118 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// syncTimeout is how long the leader may take to sync its caches before it is reported unhealthy
+	syncTimeout = 5 * time.Minute
+	// pingInterval is how long a Rancher API check result is reused
+	pingInterval = 10 * time.Second
+)
+
+// Checker tracks leader election and cache sync of the operator and whether the Rancher API is reachable
+type Checker struct {
+	sync.Mutex
+
+	ping         func() error
+	leadingSince time.Time
+	synced       bool
+	pinged       time.Time
+	pingErr      error
+}
+
+// NewChecker returns a Checker that calls ping to test the Rancher API
+func NewChecker(ping func() error) *Checker {
+	return &Checker{
+		ping: ping,
+	}
+}
+
+// Leading records that this replica was elected leader and is starting its controllers
+func (c *Checker) Leading() {
+	c.Lock()
+	defer c.Unlock()
+	c.leadingSince = time.Now()
+}
+
+// Synced records that the caches of the leader are synced and the controllers are running
+func (c *Checker) Synced() {
+	c.Lock()
+	defer c.Unlock()
+	c.synced = true
+}
+
+// Healthz fails once the leader took longer than syncTimeout to sync its caches, restarting the process
+// is the only way out of that state
+func (c *Checker) Healthz() error {
+	c.Lock()
+	defer c.Unlock()
+	if !c.leadingSince.IsZero() && !c.synced && time.Since(c.leadingSince) > syncTimeout {
+		return fmt.Errorf("caches not synced %s after becoming leader", time.Since(c.leadingSince).Round(time.Second))
+	}
+	return nil
+}
+
+// Readyz fails while the leader is syncing its caches or when the Rancher API can not be reached. Replicas
+// waiting for leader election are ready.
+func (c *Checker) Readyz() error {
+	c.Lock()
+	defer c.Unlock()
+	if !c.leadingSince.IsZero() && !c.synced {
+		return fmt.Errorf("caches not synced")
+	}
+
+	if time.Since(c.pinged) > pingInterval {
+		c.pinged = time.Now()
+		c.pingErr = c.ping()
+	}
+	if c.pingErr != nil {
+		return fmt.Errorf("rancher API not reachable: %v", c.pingErr)
+	}
+	return nil
+}
+
+func (c *Checker) leader() bool {
+	c.Lock()
+	defer c.Unlock()
+	return !c.leadingSince.IsZero()
+}
+
+func (c *Checker) handler(check func() error) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if err := check(); err != nil {
+			http.Error(rw, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintf(rw, "ok, leader: %t\n", c.leader())
+	})
+}
+
+// Serve exposes /healthz and /readyz on address until ctx is done
+func (c *Checker) Serve(ctx context.Context, address string) {
+	mux := http.NewServeMux()
+	mux.Handle("/healthz", c.handler(c.Healthz))
+	mux.Handle("/readyz", c.handler(c.Readyz))
+	server := &http.Server{
+		Addr:    address,
+		Handler: mux,
+	}
+
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logrus.Errorf("health server failed: %v", err)
+		}
+	}()
+}
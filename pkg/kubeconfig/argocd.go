@@ -0,0 +1,122 @@
+package kubeconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/wrangler/pkg/name"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	argoCDSecretTypeLabel = "argocd.argoproj.io/secret-type"
+)
+
+type argoCDConfig struct {
+	BearerToken        string              `json:"bearerToken,omitempty"`
+	TLSClientConfig    argoCDTLSConfig     `json:"tlsClientConfig"`
+	ExecProviderConfig *argoCDExecProvider `json:"execProviderConfig,omitempty"`
+}
+
+type argoCDTLSConfig struct {
+	Insecure bool   `json:"insecure"`
+	CAData   []byte `json:"caData,omitempty"`
+}
+
+type argoCDExecProvider struct {
+	Command    string            `json:"command,omitempty"`
+	Args       []string          `json:"args,omitempty"`
+	Env        map[string]string `json:"env,omitempty"`
+	APIVersion string            `json:"apiVersion,omitempty"`
+}
+
+// ArgoCDSecret converts a generated kubeconfig secret to an ArgoCD declarative cluster secret
+func ArgoCDSecret(namespace string, cluster *v1.Cluster, kubeConfigSecret *corev1.Secret) (*corev1.Secret, error) {
+	kc, err := clientcmd.Load(kubeConfigSecret.Data["value"])
+	if err != nil {
+		return nil, err
+	}
+
+	context := kc.Contexts[kc.CurrentContext]
+	if context == nil || kc.Clusters[context.Cluster] == nil || kc.AuthInfos[context.AuthInfo] == nil {
+		return nil, fmt.Errorf("invalid kubeconfig in secret %s/%s", kubeConfigSecret.Namespace, kubeConfigSecret.Name)
+	}
+
+	var (
+		clusterInfo = kc.Clusters[context.Cluster]
+		authInfo    = kc.AuthInfos[context.AuthInfo]
+		config      = argoCDConfig{
+			BearerToken: authInfo.Token,
+			TLSClientConfig: argoCDTLSConfig{
+				Insecure: clusterInfo.InsecureSkipTLSVerify,
+				CAData:   clusterInfo.CertificateAuthorityData,
+			},
+		}
+	)
+
+	if authInfo.Exec != nil {
+		config.ExecProviderConfig = &argoCDExecProvider{
+			Command:    authInfo.Exec.Command,
+			Args:       authInfo.Exec.Args,
+			APIVersion: authInfo.Exec.APIVersion,
+		}
+		for _, env := range authInfo.Exec.Env {
+			if config.ExecProviderConfig.Env == nil {
+				config.ExecProviderConfig.Env = map[string]string{}
+			}
+			config.ExecProviderConfig.Env[env.Name] = env.Value
+		}
+	}
+
+	configData, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := argoCDLabels(cluster.Labels)
+	labels[argoCDSecretTypeLabel] = "cluster"
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name.SafeConcatName("cluster", cluster.Namespace, cluster.Name),
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Data: map[string][]byte{
+			// cluster names are only unique within their namespace, ArgoCD needs a unique name
+			"name":   []byte(name.SafeConcatName(cluster.Namespace, cluster.Name)),
+			"server": []byte(clusterInfo.Server),
+			"config": configData,
+		},
+	}, nil
+}
+
+// argoCDLabels copies the labels of a cluster for the cluster generators of ArgoCD to select on, the labels of the
+// domains Rancher, Kubernetes and ArgoCD reserve are left out
+func argoCDLabels(clusterLabels map[string]string) map[string]string {
+	result := map[string]string{}
+	for k, v := range clusterLabels {
+		if !reservedLabel(k) {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+func reservedLabel(key string) bool {
+	i := strings.Index(key, "/")
+	if i < 0 {
+		return false
+	}
+	domain := key[:i]
+	for _, reserved := range []string{"cattle.io", "kubernetes.io", "k8s.io", "argoproj.io"} {
+		if domain == reserved || strings.HasSuffix(domain, "."+reserved) {
+			return true
+		}
+	}
+	return false
+}
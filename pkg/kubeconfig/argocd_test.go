@@ -0,0 +1,26 @@
+package kubeconfig
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestArgoCDLabels(t *testing.T) {
+	got := argoCDLabels(map[string]string{
+		"env":                               "prod",
+		"example.com/team":                  "a",
+		"objectset.rio.cattle.io/hash":      "abc",
+		"app.kubernetes.io/managed-by":      "helm",
+		"argocd.argoproj.io/secret-type":    "repository",
+		"notcattle.io/label":                "kept",
+		"rancher.cattle.io/claimed-by-name": "test",
+	})
+	want := map[string]string{
+		"env":                "prod",
+		"example.com/team":   "a",
+		"notcattle.io/label": "kept",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
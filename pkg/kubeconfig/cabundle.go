@@ -0,0 +1,51 @@
+package kubeconfig
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// caBundle returns the CA to embed in the kubeconfig of cluster. A bundle referenced by the cluster takes
+// precedence over the operator wide bundle file, which takes precedence over the CA served by Rancher.
+func (m *Manager) caBundle(cluster *v1.Cluster, cacert string) (string, error) {
+	if ref := cluster.Spec.KubeConfigCABundle; ref != nil {
+		switch {
+		case ref.SecretKeyRef != nil:
+			secret, err := m.secrets.Get(cluster.Namespace, ref.SecretKeyRef.Name, metav1.GetOptions{})
+			if apierror.IsNotFound(err) && ref.SecretKeyRef.Optional != nil && *ref.SecretKeyRef.Optional {
+				break
+			} else if err != nil {
+				return "", err
+			}
+			if data, ok := secret.Data[ref.SecretKeyRef.Key]; ok {
+				return string(data), nil
+			}
+			return "", fmt.Errorf("key %s not found in CA bundle secret %s/%s", ref.SecretKeyRef.Key, cluster.Namespace, ref.SecretKeyRef.Name)
+		case ref.ConfigMapKeyRef != nil:
+			configMap, err := m.configMapCache.Get(cluster.Namespace, ref.ConfigMapKeyRef.Name)
+			if apierror.IsNotFound(err) && ref.ConfigMapKeyRef.Optional != nil && *ref.ConfigMapKeyRef.Optional {
+				break
+			} else if err != nil {
+				return "", err
+			}
+			if data, ok := configMap.Data[ref.ConfigMapKeyRef.Key]; ok {
+				return data, nil
+			}
+			return "", fmt.Errorf("key %s not found in CA bundle configmap %s/%s", ref.ConfigMapKeyRef.Key, cluster.Namespace, ref.ConfigMapKeyRef.Name)
+		}
+	}
+
+	if m.caBundleFile != "" {
+		data, err := ioutil.ReadFile(m.caBundleFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read CA bundle %s: %w", m.caBundleFile, err)
+		}
+		return string(data), nil
+	}
+
+	return cacert, nil
+}
@@ -0,0 +1,184 @@
+package fake
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/kubeconfig"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// Provider is an in-memory kubeconfig.Provider. Tokens are plain counters so tests can exercise the kubeconfig
+// paths of handlers without a running Rancher. Generated secrets are only saved once the test applies them with
+// SaveKubeConfig, like the real provider only reads back the applied secret, and a minted token is kept as pending
+// until then.
+type Provider struct {
+	sync.Mutex
+
+	ServerURL string
+	CACert    string
+	// ValidateErr is returned by Validate when set
+	ValidateErr error
+
+	secrets map[string]*corev1.Secret
+	tokens  map[string]string
+	pending map[string]string
+	counter int
+}
+
+var _ kubeconfig.Provider = (*Provider)(nil)
+
+func NewProvider() *Provider {
+	return &Provider{
+		ServerURL: "https://rancher.example.com",
+		secrets:   map[string]*corev1.Secret{},
+		tokens:    map[string]string{},
+		pending:   map[string]string{},
+	}
+}
+
+func key(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+func (p *Provider) GetKubeConfig(cluster *v1.Cluster, status v1.ClusterStatus) (*corev1.Secret, error) {
+	p.Lock()
+	defer p.Unlock()
+
+	clusterKey := key(cluster.Namespace, cluster.Name)
+	token, ok := p.tokens[clusterKey]
+	if !ok || (token != p.savedToken(clusterKey) && token != p.pending[clusterKey]) {
+		p.counter++
+		token = fmt.Sprintf("token-%d", p.counter)
+		p.tokens[clusterKey] = token
+		p.pending[clusterKey] = token
+	}
+
+	data, err := clientcmd.Write(clientcmdapi.Config{
+		Clusters: map[string]*clientcmdapi.Cluster{
+			"cluster": {
+				Server:                   fmt.Sprintf("%s/k8s/clusters/%s", p.ServerURL, status.ClusterName),
+				CertificateAuthorityData: []byte(p.CACert),
+			},
+		},
+		AuthInfos: map[string]*clientcmdapi.AuthInfo{
+			"user": {
+				Token: token,
+			},
+		},
+		Contexts: map[string]*clientcmdapi.Context{
+			"default": {
+				Cluster:  "cluster",
+				AuthInfo: "user",
+			},
+		},
+		CurrentContext: "default",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   cluster.Namespace,
+			Name:        kubeconfig.GetKubeConfigSecretName(cluster),
+			Labels:      cluster.Spec.ClientSecretLabels,
+			Annotations: cluster.Spec.ClientSecretAnnotations,
+		},
+		Data: map[string][]byte{
+			"value": data,
+			"token": []byte(token),
+		},
+	}
+	return secret, nil
+}
+
+// SaveKubeConfig stores secret as the applied kubeconfig of its cluster
+func (p *Provider) SaveKubeConfig(cluster *v1.Cluster, secret *corev1.Secret) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.secrets[key(cluster.Namespace, cluster.Name)] = secret.DeepCopy()
+}
+
+func (p *Provider) GetSavedKubeConfig(cluster *v1.Cluster) (*corev1.Secret, error) {
+	p.Lock()
+	defer p.Unlock()
+
+	secret, ok := p.secrets[key(cluster.Namespace, cluster.Name)]
+	if !ok {
+		return nil, nil
+	}
+	return secret.DeepCopy(), nil
+}
+
+func (p *Provider) savedToken(clusterKey string) string {
+	if secret, ok := p.secrets[clusterKey]; ok {
+		return string(secret.Data["token"])
+	}
+	return ""
+}
+
+// Validate fails with ValidateErr, or if the token of secret is not live
+func (p *Provider) Validate(secret *corev1.Secret) error {
+	p.Lock()
+	defer p.Unlock()
+
+	if p.ValidateErr != nil {
+		return p.ValidateErr
+	}
+	for _, token := range p.tokens {
+		if string(secret.Data["token"]) == token {
+			return nil
+		}
+	}
+	return fmt.Errorf("token of secret %s/%s is not valid", secret.Namespace, secret.Name)
+}
+
+func (p *Provider) TokenValid(cluster *v1.Cluster) (bool, error) {
+	p.Lock()
+	defer p.Unlock()
+
+	_, ok := p.tokens[key(cluster.Namespace, cluster.Name)]
+	return ok, nil
+}
+
+func (p *Provider) RevokeToken(cluster *v1.Cluster) error {
+	p.Lock()
+	defer p.Unlock()
+
+	delete(p.tokens, key(cluster.Namespace, cluster.Name))
+	return nil
+}
+
+func (p *Provider) DeleteUser(clusterNamespace, clusterName string) error {
+	p.Lock()
+	defer p.Unlock()
+
+	delete(p.tokens, key(clusterNamespace, clusterName))
+	delete(p.pending, key(clusterNamespace, clusterName))
+	delete(p.secrets, key(clusterNamespace, clusterName))
+	return nil
+}
+
+func (p *Provider) ClusterForUser(userName string) (string, string, bool) {
+	p.Lock()
+	defer p.Unlock()
+
+	for clusterKey := range p.tokens {
+		parts := strings.SplitN(clusterKey, "/", 2)
+		if kubeconfig.GetUserName(parts[0], parts[1]) == userName {
+			return parts[0], parts[1], true
+		}
+	}
+	return "", "", false
+}
+
+func (p *Provider) GetServerURLAndCA() (string, string, error) {
+	return p.ServerURL, p.CACert, nil
+}
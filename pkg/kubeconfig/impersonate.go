@@ -0,0 +1,46 @@
+package kubeconfig
+
+import (
+	"fmt"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/wrangler/pkg/name"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// AdditionalKubeConfigs derives a kubeconfig secret for each of spec.additionalKubeConfigs from the generated
+// kubeconfig. They use the same credentials but impersonate the requested user and groups.
+func AdditionalKubeConfigs(cluster *v1.Cluster, secret *corev1.Secret) ([]*corev1.Secret, error) {
+	var result []*corev1.Secret
+	for _, additional := range cluster.Spec.AdditionalKubeConfigs {
+		if additional.Name == "" {
+			return nil, fmt.Errorf("additional kubeconfig for cluster %s/%s is missing a name", cluster.Namespace, cluster.Name)
+		}
+		if additional.User == "" {
+			return nil, fmt.Errorf("additional kubeconfig %s for cluster %s/%s is missing a user", additional.Name,
+				cluster.Namespace, cluster.Name)
+		}
+
+		config, err := clientcmd.Load(secret.Data["value"])
+		if err != nil {
+			return nil, err
+		}
+		for _, authInfo := range config.AuthInfos {
+			authInfo.Impersonate = additional.User
+			authInfo.ImpersonateGroups = additional.Groups
+		}
+
+		data, err := clientcmd.Write(*config)
+		if err != nil {
+			return nil, err
+		}
+
+		additionalSecret := newSecret(cluster, map[string][]byte{
+			"value": data,
+		})
+		additionalSecret.Name = name.SafeConcatName(secret.Name, additional.Name)
+		result = append(result, additionalSecret)
+	}
+	return result, nil
+}
@@ -3,19 +3,26 @@ package kubeconfig
 import (
 	"crypto/rand"
 	"crypto/sha256"
+	"crypto/subtle"
 	"encoding/base32"
 	"encoding/base64"
 	"fmt"
 	"strings"
+	"time"
 
 	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
 	"github.com/rancher/rancher-operator/pkg/clients"
+	"github.com/rancher/rancher-operator/pkg/downstream"
 	mgmtcontrollers "github.com/rancher/rancher-operator/pkg/generated/controllers/management.cattle.io/v3"
+	"github.com/rancher/rancher-operator/pkg/operatorconfig"
 	"github.com/rancher/rancher-operator/pkg/settings"
+	"github.com/rancher/rancher-operator/pkg/tracing"
 	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
 	appcontroller "github.com/rancher/wrangler/pkg/generated/controllers/apps/v1"
 	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
+	"github.com/rancher/wrangler/pkg/name"
 	"github.com/rancher/wrangler/pkg/randomtoken"
+	"go.opentelemetry.io/otel/attribute"
 	corev1 "k8s.io/api/core/v1"
 	apierror "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -24,77 +31,252 @@ import (
 )
 
 const (
-	userIDLabel     = "authn.management.cattle.io/token-userId"
-	tokenKindLabel  = "authn.management.cattle.io/kind"
-	tokenHashedAnno = "authn.management.cattle.io/token-hashed"
-	systemNamespace = "cattle-system"
+	userIDLabel           = "authn.management.cattle.io/token-userId"
+	TokenKindLabel        = "authn.management.cattle.io/kind"
+	TokenKindProvisioning = "provisioning"
+	tokenHashedAnno       = "authn.management.cattle.io/token-hashed"
+	systemNamespace       = "cattle-system"
+	principalPrefix       = "system://provisioning/"
 
 	hashFormat = "$%d:%s:%s" // $version:salt:hash -> $1:abc:def
 	Version    = 2
+
+	execAPIVersion = "client.authentication.k8s.io/v1beta1"
+	execCommand    = "rancher"
 )
 
 type Manager struct {
+	caBundleFile    string
+	standalone      bool
+	config          *operatorconfig.Config
+	limiter         *downstream.Limiter
+	clusterCache    mgmtcontrollers.ClusterCache
 	deploymentCache appcontroller.DeploymentCache
 	daemonsetCache  appcontroller.DaemonSetCache
+	tokenCache      mgmtcontrollers.TokenCache
 	tokens          mgmtcontrollers.TokenClient
 	userCache       mgmtcontrollers.UserCache
 	users           mgmtcontrollers.UserClient
 	secretCache     corecontrollers.SecretCache
+	configMapCache  corecontrollers.ConfigMapCache
 	secrets         corecontrollers.SecretClient
 	settings        mgmtcontrollers.SettingCache
 }
 
-func New(clients *clients.Clients) *Manager {
+func New(clients *clients.Clients, caBundleFile string, limiter *downstream.Limiter, config *operatorconfig.Config) *Manager {
 	return &Manager{
+		caBundleFile:    caBundleFile,
+		standalone:      clients.Standalone,
+		config:          config,
+		limiter:         limiter,
+		clusterCache:    clients.Management.Cluster().Cache(),
 		deploymentCache: clients.Apps.Deployment().Cache(),
 		daemonsetCache:  clients.Apps.DaemonSet().Cache(),
+		tokenCache:      clients.Management.Token().Cache(),
 		tokens:          clients.Management.Token(),
 		userCache:       clients.Management.User().Cache(),
 		users:           clients.Management.User(),
 		secretCache:     clients.Core.Secret().Cache(),
+		configMapCache:  clients.Core.ConfigMap().Cache(),
 		secrets:         clients.Core.Secret(),
 		settings:        clients.Management.Setting().Cache(),
 	}
 }
 
-func GetKubeConfigSecretName(clusterName string) string {
-	return clusterName + "-kubeconfig"
+func GetKubeConfigSecretName(cluster *v1.Cluster) string {
+	if cluster.Spec.ClientSecretName != "" {
+		return cluster.Spec.ClientSecretName
+	}
+	return cluster.Name + "-kubeconfig"
 }
 
-func (m *Manager) GetToken(clusterNamespace, clusterName string) (string, error) {
-	kubeConfigSecretName := GetKubeConfigSecretName(clusterName)
-	if token, err := m.getSavedToken(clusterNamespace, kubeConfigSecretName); err != nil || token != "" {
-		return token, err
+func (m *Manager) GetToken(cluster *v1.Cluster) (string, error) {
+	var (
+		clusterNamespace     = cluster.Namespace
+		clusterName          = cluster.Name
+		kubeConfigSecretName = GetKubeConfigSecretName(cluster)
+	)
+
+	liveToken, valid, err := m.ValidToken(GetUserName(clusterNamespace, clusterName))
+	if err != nil {
+		return "", err
 	}
 
-	// Need to be careful about caches being out of sync since we are dealing with multiple objects that
-	// arent eventually consistent (because we delete and create the token for the user)
-	if token, err := m.getSavedTokenNoCache(clusterNamespace, kubeConfigSecretName); err != nil || token != "" {
-		return token, err
+	// A token that was revoked or expired in Rancher is replaced by a new one. The saved kubeconfig may still hold
+	// the previous token when the kubeconfig of the live one failed validation, that token is kept in the pending
+	// secret until it is published.
+	if valid {
+		if token, err := m.getSavedToken(clusterNamespace, kubeConfigSecretName); err != nil || tokenMatches(liveToken, token) {
+			return token, err
+		}
+
+		if token, err := m.getSavedTokenNoCache(clusterNamespace, pendingTokenSecretName(cluster)); err != nil || tokenMatches(liveToken, token) {
+			return token, err
+		}
+
+		// Need to be careful about caches being out of sync since we are dealing with multiple objects that
+		// arent eventually consistent (because we delete and create the token for the user)
+		if token, err := m.getSavedTokenNoCache(clusterNamespace, kubeConfigSecretName); err != nil || tokenMatches(liveToken, token) {
+			return token, err
+		}
 	}
 
+	span := tracing.Start(clusterNamespace, clusterName, "rancher.user.ensure")
 	userName, err := m.EnsureUser(clusterNamespace, clusterName)
+	tracing.End(span, err)
 	if err != nil {
 		return "", err
 	}
 
-	return m.createUserToken(userName)
+	span = tracing.Start(clusterNamespace, clusterName, "rancher.token.create")
+	token, err := m.createUserToken(userName)
+	tracing.End(span, err)
+	if err != nil {
+		return "", err
+	}
+	return token, m.savePendingToken(cluster, token)
+}
+
+// pendingTokenSecretName is the secret holding the token minted for cluster until a kubeconfig containing it is
+// verified and published
+func pendingTokenSecretName(cluster *v1.Cluster) string {
+	return name.SafeConcatName(GetKubeConfigSecretName(cluster), "pending-token")
+}
+
+// savePendingToken stores token outside of the published kubeconfig secret, the old token is already deleted so the
+// new one must survive a kubeconfig that fails validation
+func (m *Manager) savePendingToken(cluster *v1.Cluster, token string) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cluster.Namespace,
+			Name:      pendingTokenSecretName(cluster),
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(cluster, v1.SchemeGroupVersion.WithKind("Cluster")),
+			},
+		},
+		Data: map[string][]byte{
+			"token": []byte(token),
+		},
+	}
+
+	existing, err := m.secrets.Get(secret.Namespace, secret.Name, metav1.GetOptions{})
+	if apierror.IsNotFound(err) {
+		_, err = m.secrets.Create(secret)
+		return err
+	} else if err != nil {
+		return err
+	}
+
+	existing = existing.DeepCopy()
+	existing.Data = secret.Data
+	_, err = m.secrets.Update(existing)
+	return err
+}
+
+// tokenMatches returns true if value, in the <name>:<value> bearer token form, is the secret of token
+func tokenMatches(token *v3.Token, value string) bool {
+	parts := strings.SplitN(value, ":", 2)
+	if token == nil || len(parts) != 2 || parts[0] != token.Name {
+		return false
+	}
+	if token.Annotations[tokenHashedAnno] == "true" {
+		return verifySHA256Hash(token.Token, parts[1])
+	}
+	return subtle.ConstantTimeCompare([]byte(token.Token), []byte(parts[1])) == 1
+}
+
+func GetUserName(clusterNamespace, clusterName string) string {
+	return UserNameForPrincipal(getPrincipalID(clusterNamespace, clusterName))
 }
 
 func (m *Manager) EnsureUser(clusterNamespace, clusterName string) (string, error) {
 	principalID := getPrincipalID(clusterNamespace, clusterName)
-	userName := getUserNameForPrincipal(principalID)
+	userName := UserNameForPrincipal(principalID)
 	return userName, m.createUser(principalID, userName)
 }
 
-func getUserNameForPrincipal(principal string) string {
+// TokenValid returns false if the token minted for cluster was deleted, disabled or has expired in Rancher
+func (m *Manager) TokenValid(cluster *v1.Cluster) (bool, error) {
+	_, valid, err := m.ValidToken(GetUserName(cluster.Namespace, cluster.Name))
+	return valid, err
+}
+
+// ValidToken returns the token tokenName and false if it was deleted, disabled or has expired in Rancher
+func (m *Manager) ValidToken(tokenName string) (*v3.Token, bool, error) {
+	token, err := m.tokenCache.Get(tokenName)
+	if apierror.IsNotFound(err) {
+		// the cache may not have seen a token that was just created
+		token, err = m.tokens.Get(tokenName, metav1.GetOptions{})
+	}
+	if apierror.IsNotFound(err) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+
+	if token.Expired || (token.Enabled != nil && !*token.Enabled) {
+		return token, false, nil
+	}
+	if token.ExpiresAt != "" {
+		expiresAt, err := time.Parse(time.RFC3339, token.ExpiresAt)
+		if err == nil && time.Now().After(expiresAt) {
+			return token, false, nil
+		}
+	}
+	return token, true, nil
+}
+
+// ClusterForUser returns the namespace and name of the cluster the provisioning user was created for
+func (m *Manager) ClusterForUser(userName string) (string, string, bool) {
+	user, err := m.userCache.Get(userName)
+	if err != nil {
+		return "", "", false
+	}
+	for _, principalID := range user.PrincipalIDs {
+		parts := strings.Split(strings.TrimPrefix(principalID, principalPrefix), "/")
+		if strings.HasPrefix(principalID, principalPrefix) && len(parts) == 2 {
+			return parts[0], parts[1], true
+		}
+	}
+	return "", "", false
+}
+
+// RevokeToken deletes the token used by the generated kubeconfig so it can no longer be used
+func (m *Manager) RevokeToken(cluster *v1.Cluster) error {
+	return m.DeleteToken(GetUserName(cluster.Namespace, cluster.Name))
+}
+
+// DeleteToken deletes the token tokenName, it is not an error if it is already gone
+func (m *Manager) DeleteToken(tokenName string) error {
+	err := m.tokens.Delete(tokenName, nil)
+	if apierror.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// DeleteUser removes the provisioning user and its token created for the cluster
+func (m *Manager) DeleteUser(clusterNamespace, clusterName string) error {
+	userName := GetUserName(clusterNamespace, clusterName)
+	if err := m.tokens.Delete(userName, nil); err != nil && !apierror.IsNotFound(err) {
+		return err
+	}
+	if err := m.users.Delete(userName, nil); err != nil && !apierror.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// UserNameForPrincipal returns the name of the management user created for principal
+func UserNameForPrincipal(principal string) string {
 	hasher := sha256.New()
 	hasher.Write([]byte(principal))
 	sha := base32.StdEncoding.WithPadding(-1).EncodeToString(hasher.Sum(nil))[:10]
 	return "u-" + strings.ToLower(sha)
 }
 
-func labelsForUser(principalID string) map[string]string {
+// LabelsForUser returns the labels Rancher looks users up by principal with
+func LabelsForUser(principalID string) map[string]string {
 	encodedPrincipalID := base32.HexEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte(principalID))
 	if len(encodedPrincipalID) > 63 {
 		encodedPrincipalID = encodedPrincipalID[:63]
@@ -125,7 +307,7 @@ func (m *Manager) getSavedTokenNoCache(kubeConfigNamespace, kubeConfigName strin
 }
 
 func getPrincipalID(clusterNamespace, clusterName string) string {
-	return fmt.Sprintf("system://provisioning/%s/%s", clusterNamespace, clusterName)
+	return fmt.Sprintf("%s%s/%s", principalPrefix, clusterNamespace, clusterName)
 }
 
 func (m *Manager) createUser(principalID, userName string) error {
@@ -134,7 +316,7 @@ func (m *Manager) createUser(principalID, userName string) error {
 		_, err = m.users.Create(&v3.User{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:   userName,
-				Labels: labelsForUser(principalID),
+				Labels: LabelsForUser(principalID),
 			},
 			PrincipalIDs: []string{
 				principalID,
@@ -145,9 +327,15 @@ func (m *Manager) createUser(principalID, userName string) error {
 }
 
 func (m *Manager) createUserToken(userName string) (string, error) {
-	_, err := m.tokens.Get(userName, metav1.GetOptions{})
+	return m.CreateToken(userName, userName, TokenKindProvisioning, m.config.Get().KubeConfigTokenTTL)
+}
+
+// CreateToken replaces the token tokenName of userName with a new one that expires after ttl, if not zero, and
+// returns it in the <name>:<value> bearer token form
+func (m *Manager) CreateToken(tokenName, userName, kind string, ttl time.Duration) (string, error) {
+	_, err := m.tokens.Get(tokenName, metav1.GetOptions{})
 	if err == nil {
-		err = m.tokens.Delete(userName, nil)
+		err = m.tokens.Delete(tokenName, nil)
 	}
 	if err != nil && !apierror.IsNotFound(err) {
 		return "", err
@@ -160,10 +348,9 @@ func (m *Manager) createUserToken(userName string) (string, error) {
 
 	token := &v3.Token{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: userName,
+			Name: tokenName,
 			Labels: map[string]string{
-				userIDLabel:    userName,
-				tokenKindLabel: "provisioning",
+				userIDLabel: userName,
 			},
 			Annotations: map[string]string{},
 		},
@@ -171,6 +358,10 @@ func (m *Manager) createUserToken(userName string) (string, error) {
 		AuthProvider: "local",
 		IsDerived:    true,
 		Token:        tokenValue,
+		TTLMillis:    ttl.Milliseconds(),
+	}
+	if kind != "" {
+		token.Labels[TokenKindLabel] = kind
 	}
 
 	if ok, err := settings.Bool(m.settings, "token-hashing"); err != nil {
@@ -185,7 +376,7 @@ func (m *Manager) createUserToken(userName string) (string, error) {
 	}
 
 	_, err = m.tokens.Create(token)
-	return fmt.Sprintf("%s:%s", userName, tokenValue), err
+	return fmt.Sprintf("%s:%s", tokenName, tokenValue), err
 }
 
 func createSHA256Hash(secretKey string) (string, error) {
@@ -200,37 +391,97 @@ func createSHA256Hash(secretKey string) (string, error) {
 	return fmt.Sprintf(hashFormat, Version, encSalt, encKey), nil
 }
 
-func (m *Manager) GetKubeConfig(cluster *v1.Cluster, status v1.ClusterStatus) (*corev1.Secret, error) {
-	var (
-		name       = GetKubeConfigSecretName(cluster.Name)
-		tokenValue string
-	)
+func verifySHA256Hash(hash, secretKey string) bool {
+	parts := strings.Split(hash, ":")
+	if len(parts) != 3 || parts[0] != fmt.Sprintf("$%d", Version) {
+		return false
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return false
+	}
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s%s", salt, secretKey)))
+	encKey := base64.RawStdEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(encKey), []byte(parts[2])) == 1
+}
+
+func (m *Manager) GetKubeConfig(cluster *v1.Cluster, status v1.ClusterStatus) (_ *corev1.Secret, err error) {
+	span := tracing.Start(cluster.Namespace, cluster.Name, "kubeconfig.generate",
+		attribute.String("format", cluster.Spec.KubeConfigFormat))
+	defer func() { tracing.End(span, err) }()
+
+	name := GetKubeConfigSecretName(cluster)
 
 	if cluster.Spec.ImportedConfig != nil && cluster.Spec.ImportedConfig.KubeConfigSecret == name {
 		return nil, nil
 	}
 
-	tokenValue, err := m.GetToken(cluster.Namespace, cluster.Name)
+	serverURL, cacert, err := m.GetServerURLAndCA()
+	if err != nil {
+		return nil, err
+	}
+
+	cacert, err = m.caBundle(cluster, cacert)
 	if err != nil {
 		return nil, err
 	}
 
-	serverURL, cacert, err := m.GetServerURLAndCA()
+	switch cluster.Spec.KubeConfigFormat {
+	case "", v1.KubeConfigFormatToken:
+		return m.tokenKubeConfig(cluster, status, serverURL, cacert)
+	case v1.KubeConfigFormatExec:
+		return m.execKubeConfig(cluster, status, serverURL, cacert)
+	case v1.KubeConfigFormatServiceAccount:
+		return m.serviceAccountKubeConfig(cluster, status, serverURL, cacert)
+	}
+
+	return nil, fmt.Errorf("unsupported kubeConfigFormat %q for cluster %s/%s", cluster.Spec.KubeConfigFormat,
+		cluster.Namespace, cluster.Name)
+}
+
+func (m *Manager) tokenKubeConfig(cluster *v1.Cluster, status v1.ClusterStatus, serverURL, cacert string) (*corev1.Secret, error) {
+	tokenValue, err := m.GetToken(cluster)
 	if err != nil {
 		return nil, err
 	}
 
+	return kubeConfigSecret(cluster, &clientcmdapi.Cluster{
+		Server:                   proxyURL(serverURL, status.ClusterName),
+		CertificateAuthorityData: []byte(strings.TrimSpace(cacert)),
+	}, &clientcmdapi.AuthInfo{
+		Token: tokenValue,
+	}, tokenValue)
+}
+
+func (m *Manager) execKubeConfig(cluster *v1.Cluster, status v1.ClusterStatus, serverURL, cacert string) (*corev1.Secret, error) {
+	// No token is minted for this format, the helper authenticates the caller against Rancher
+	return kubeConfigSecret(cluster, &clientcmdapi.Cluster{
+		Server:                   proxyURL(serverURL, status.ClusterName),
+		CertificateAuthorityData: []byte(strings.TrimSpace(cacert)),
+	}, &clientcmdapi.AuthInfo{
+		Exec: &clientcmdapi.ExecConfig{
+			APIVersion: execAPIVersion,
+			Command:    execCommand,
+			Args: []string{
+				"token",
+				"--server=" + serverURL,
+				"--cluster=" + status.ClusterName,
+			},
+		},
+	}, "")
+}
+
+func proxyURL(serverURL, clusterName string) string {
+	return fmt.Sprintf("%s/k8s/clusters/%s", serverURL, clusterName)
+}
+
+func kubeConfigSecret(cluster *v1.Cluster, clusterInfo *clientcmdapi.Cluster, authInfo *clientcmdapi.AuthInfo, tokenValue string) (*corev1.Secret, error) {
 	data, err := clientcmd.Write(clientcmdapi.Config{
 		Clusters: map[string]*clientcmdapi.Cluster{
-			"cluster": {
-				Server:                   fmt.Sprintf("%s/k8s/clusters/%s", serverURL, status.ClusterName),
-				CertificateAuthorityData: []byte(strings.TrimSpace(cacert)),
-			},
+			"cluster": clusterInfo,
 		},
 		AuthInfos: map[string]*clientcmdapi.AuthInfo{
-			"user": {
-				Token: tokenValue,
-			},
+			"user": authInfo,
 		},
 		Contexts: map[string]*clientcmdapi.Context{
 			"default": {
@@ -244,16 +495,36 @@ func (m *Manager) GetKubeConfig(cluster *v1.Cluster, status v1.ClusterStatus) (*
 		return nil, err
 	}
 
+	secret := newSecret(cluster, map[string][]byte{
+		"value": data,
+	})
+	if tokenValue != "" {
+		secret.Data["token"] = []byte(tokenValue)
+	}
+	return secret, nil
+}
+
+func newSecret(cluster *v1.Cluster, data map[string][]byte) *corev1.Secret {
 	return &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: cluster.Namespace,
-			Name:      name,
-		},
-		Data: map[string][]byte{
-			"value": data,
-			"token": []byte(tokenValue),
+			Namespace:   cluster.Namespace,
+			Name:        GetKubeConfigSecretName(cluster),
+			Labels:      cluster.Spec.ClientSecretLabels,
+			Annotations: cluster.Spec.ClientSecretAnnotations,
 		},
-	}, nil
+		Data: data,
+	}
+}
+
+// GetSavedKubeConfig returns the previously generated kubeconfig secret without generating a new one
+func (m *Manager) GetSavedKubeConfig(cluster *v1.Cluster) (*corev1.Secret, error) {
+	secret, err := m.secretCache.Get(cluster.Namespace, GetKubeConfigSecretName(cluster))
+	if apierror.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return newSecret(cluster, secret.Data), nil
 }
 
 func (m *Manager) GetServerURLAndCA() (string, string, error) {
@@ -262,7 +533,12 @@ func (m *Manager) GetServerURLAndCA() (string, string, error) {
 		return "", "", err
 	}
 
-	tlsSecret, err := m.secretCache.Get(systemNamespace, "tls-rancher-internal-ca")
+	// the internal service of Rancher is only reachable from its own cluster
+	if m.standalone {
+		return serverURL, ca, nil
+	}
+
+	tlsSecret, err := m.secrets.Get(systemNamespace, "tls-rancher-internal-ca", metav1.GetOptions{})
 	if err != nil {
 		return "", "", err
 	}
@@ -0,0 +1,39 @@
+package kubeconfig
+
+import (
+	"testing"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTokenMatches(t *testing.T) {
+	hash, err := createSHA256Hash("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain := &v3.Token{ObjectMeta: metav1.ObjectMeta{Name: "u-abc"}, Token: "secret"}
+	hashed := &v3.Token{
+		ObjectMeta: metav1.ObjectMeta{Name: "u-abc", Annotations: map[string]string{tokenHashedAnno: "true"}},
+		Token:      hash,
+	}
+
+	tests := []struct {
+		name  string
+		token *v3.Token
+		value string
+		want  bool
+	}{
+		{"plain", plain, "u-abc:secret", true},
+		{"hashed", hashed, "u-abc:secret", true},
+		{"wrong secret", hashed, "u-abc:other", false},
+		{"wrong name", plain, "u-def:secret", false},
+		{"no name", plain, "secret", false},
+		{"no token", nil, "u-abc:secret", false},
+	}
+	for _, tt := range tests {
+		if got := tokenMatches(tt.token, tt.value); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package kubeconfig
+
+import (
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Provider generates the kubeconfig secrets of clusters and manages the credentials they contain. Manager is
+// the Rancher token based implementation.
+type Provider interface {
+	// GetKubeConfig generates the kubeconfig secret for a ready cluster, nil is returned if no secret should be written
+	GetKubeConfig(cluster *v1.Cluster, status v1.ClusterStatus) (*corev1.Secret, error)
+	// GetSavedKubeConfig returns the previously generated secret, or nil if there is none
+	GetSavedKubeConfig(cluster *v1.Cluster) (*corev1.Secret, error)
+	// Validate checks that the kubeconfig in secret can reach and authenticate to the cluster
+	Validate(secret *corev1.Secret) error
+	// TokenValid returns false if the credentials of the generated kubeconfig are no longer valid
+	TokenValid(cluster *v1.Cluster) (bool, error)
+	// RevokeToken invalidates the credentials of the generated kubeconfig
+	RevokeToken(cluster *v1.Cluster) error
+	// DeleteUser removes everything created for the cluster's credentials
+	DeleteUser(clusterNamespace, clusterName string) error
+	// ClusterForUser maps the name of a credential back to the cluster it was created for
+	ClusterForUser(userName string) (string, string, bool)
+	// GetServerURLAndCA returns the URL and CA used to reach Rancher
+	GetServerURLAndCA() (string, string, error)
+}
+
+var _ Provider = (*Manager)(nil)
@@ -0,0 +1,153 @@
+package kubeconfig
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/tracing"
+	"github.com/rancher/wrangler/pkg/apply"
+	"github.com/rancher/wrangler/pkg/name"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	serviceAccountNamespace = "kube-system"
+	serviceAccountName      = "rancher-operator-kubeconfig"
+	serviceAccountTokenName = "rancher-operator-kubeconfig-token"
+
+	// defaultServiceAccountClusterRole is bound to the service account unless kubeConfigClusterRole is set, the
+	// built-in read-only role
+	defaultServiceAccountClusterRole = "view"
+)
+
+// serviceAccountKubeConfig builds a kubeconfig that talks to the downstream API server directly, bypassing
+// the Rancher proxy, with the permissions of kubeConfigClusterRole. The Rancher token is still minted and saved
+// so the service account can be re-read later.
+func (m *Manager) serviceAccountKubeConfig(cluster *v1.Cluster, status v1.ClusterStatus, serverURL, cacert string) (*corev1.Secret, error) {
+	rCluster, err := m.clusterCache.Get(status.ClusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	if rCluster.Status.APIEndpoint == "" {
+		return nil, fmt.Errorf("cluster %s has not reported an API endpoint", status.ClusterName)
+	}
+
+	caData, err := base64.StdEncoding.DecodeString(rCluster.Status.CACert)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CA certificate reported by cluster %s: %w", status.ClusterName, err)
+	}
+
+	tokenValue, err := m.GetToken(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterRole := cluster.Spec.KubeConfigClusterRole
+	if clusterRole == "" {
+		clusterRole = defaultServiceAccountClusterRole
+	}
+
+	var saToken string
+	err = m.limiter.Do(status.ClusterName, func() (err error) {
+		span := tracing.Start(cluster.Namespace, cluster.Name, "serviceaccount.token")
+		defer func() { tracing.End(span, err) }()
+		saToken, err = ensureServiceAccountToken(&rest.Config{
+			Host:        proxyURL(serverURL, status.ClusterName),
+			BearerToken: tokenValue,
+			TLSClientConfig: rest.TLSClientConfig{
+				CAData: []byte(strings.TrimSpace(cacert)),
+			},
+		}, clusterRole)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return kubeConfigSecret(cluster, &clientcmdapi.Cluster{
+		Server:                   rCluster.Status.APIEndpoint,
+		CertificateAuthorityData: caData,
+	}, &clientcmdapi.AuthInfo{
+		Token: saToken,
+	}, tokenValue)
+}
+
+// ensureServiceAccountToken binds clusterRole to the kubeconfig service account and returns the token of its
+// kubernetes.io/service-account-token secret. The secret is created explicitly, service accounts no longer get one
+// automatically since Kubernetes 1.24.
+func ensureServiceAccountToken(cfg *rest.Config, clusterRole string) (string, error) {
+	apply, err := apply.NewForConfig(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	// the role of a binding can't be changed, a binding per role is applied and the previous one pruned
+	err = apply.
+		WithDynamicLookup().
+		WithSetID("rancher-operator-kubeconfig").
+		ApplyObjects(
+			&corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      serviceAccountName,
+					Namespace: serviceAccountNamespace,
+				},
+			},
+			&rbacv1.ClusterRoleBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name.SafeConcatName(serviceAccountName, clusterRole),
+				},
+				RoleRef: rbacv1.RoleRef{
+					APIGroup: rbacv1.GroupName,
+					Kind:     "ClusterRole",
+					Name:     clusterRole,
+				},
+				Subjects: []rbacv1.Subject{
+					{
+						Kind:      "ServiceAccount",
+						Name:      serviceAccountName,
+						Namespace: serviceAccountNamespace,
+					},
+				},
+			})
+	if err != nil {
+		return "", err
+	}
+
+	k8s, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	// not part of the apply set, the token controller fills in the data after it is created
+	secret, err := k8s.CoreV1().Secrets(serviceAccountNamespace).Get(context.TODO(), serviceAccountTokenName, metav1.GetOptions{})
+	if apierror.IsNotFound(err) {
+		secret, err = k8s.CoreV1().Secrets(serviceAccountNamespace).Create(context.TODO(), &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      serviceAccountTokenName,
+				Namespace: serviceAccountNamespace,
+				Annotations: map[string]string{
+					corev1.ServiceAccountNameKey: serviceAccountName,
+				},
+			},
+			Type: corev1.SecretTypeServiceAccountToken,
+		}, metav1.CreateOptions{})
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if token := secret.Data[corev1.ServiceAccountTokenKey]; len(token) > 0 {
+		return string(token), nil
+	}
+	return "", fmt.Errorf("waiting for token of service account %s/%s", serviceAccountNamespace, serviceAccountName)
+}
@@ -0,0 +1,56 @@
+package kubeconfig
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const validateTimeout = 10 * time.Second
+
+// Validate checks that the kubeconfig in secret can reach and authenticate to the cluster. Kubeconfigs using an
+// exec credential plugin can only be checked for connectivity.
+func (m *Manager) Validate(secret *corev1.Secret) error {
+	cfg, err := clientcmd.RESTConfigFromKubeConfig(secret.Data["value"])
+	if err != nil {
+		return fmt.Errorf("invalid kubeconfig: %w", err)
+	}
+
+	u, err := url.Parse(cfg.Host)
+	if err != nil {
+		return fmt.Errorf("invalid server URL %s: %w", cfg.Host, err)
+	}
+	host := u.Host
+	if u.Port() == "" {
+		if u.Scheme == "http" {
+			host = net.JoinHostPort(u.Hostname(), "80")
+		} else {
+			host = net.JoinHostPort(u.Hostname(), "443")
+		}
+	}
+
+	conn, err := net.DialTimeout("tcp", host, validateTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", host, err)
+	}
+	conn.Close()
+
+	if cfg.ExecProvider != nil {
+		return nil
+	}
+
+	cfg.Timeout = validateTimeout
+	client, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	if _, err := client.ServerVersion(); err != nil {
+		return fmt.Errorf("failed to get version from %s: %w", cfg.Host, err)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package leader
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+const (
+	DefaultNamespace     = "kube-system"
+	DefaultName          = "rancher-controller-lock"
+	DefaultLeaseDuration = 45 * time.Second
+	DefaultRenewDeadline = 30 * time.Second
+	DefaultRetryPeriod   = 2 * time.Second
+)
+
+// Options configure the lock the replicas of the operator elect their leader with
+type Options struct {
+	Namespace     string
+	Name          string
+	LeaseDuration time.Duration
+	RenewDeadline time.Duration
+	RetryPeriod   time.Duration
+}
+
+// RunOrDie blocks until ctx is done and calls cb once this replica is elected leader. The process exits when
+// leadership is lost, so no two replicas run the controllers at the same time. The lock is held in a config map
+// and a lease, replicas of earlier versions only know the config map.
+func RunOrDie(ctx context.Context, k8s kubernetes.Interface, opts Options, cb func(ctx context.Context)) {
+	opts = opts.withDefaults()
+
+	id, err := os.Hostname()
+	if err != nil {
+		logrus.Fatalf("leader election: %v", err)
+	}
+
+	lock, err := resourcelock.New(resourcelock.ConfigMapsLeasesResourceLock,
+		opts.Namespace,
+		opts.Name,
+		k8s.CoreV1(),
+		k8s.CoordinationV1(),
+		resourcelock.ResourceLockConfig{
+			Identity: id,
+		})
+	if err != nil {
+		logrus.Fatalf("leader election: %v", err)
+	}
+
+	logrus.Infof("Waiting to become leader of %s/%s", opts.Namespace, opts.Name)
+	leaderelection.RunOrDie(ctx, leaderelection.LeaderElectionConfig{
+		Lock:          lock,
+		LeaseDuration: opts.LeaseDuration,
+		RenewDeadline: opts.RenewDeadline,
+		RetryPeriod:   opts.RetryPeriod,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(ctx context.Context) {
+				logrus.Infof("Became leader of %s/%s", opts.Namespace, opts.Name)
+				cb(ctx)
+			},
+			OnStoppedLeading: func() {
+				select {
+				case <-ctx.Done():
+				default:
+					logrus.Fatalf("leader election lost for %s/%s", opts.Namespace, opts.Name)
+				}
+			},
+			OnNewLeader: func(identity string) {
+				if identity != id {
+					logrus.Infof("%s is the leader of %s/%s", identity, opts.Namespace, opts.Name)
+				}
+			},
+		},
+		ReleaseOnCancel: true,
+		Name:            opts.Name,
+	})
+}
+
+func (o Options) withDefaults() Options {
+	if o.Namespace == "" {
+		o.Namespace = DefaultNamespace
+	}
+	if o.Name == "" {
+		o.Name = DefaultName
+	}
+	if o.LeaseDuration == 0 {
+		o.LeaseDuration = DefaultLeaseDuration
+	}
+	if o.RenewDeadline == 0 {
+		o.RenewDeadline = DefaultRenewDeadline
+	}
+	if o.RetryPeriod == 0 {
+		o.RetryPeriod = DefaultRetryPeriod
+	}
+	return o
+}
@@ -0,0 +1,62 @@
+package logging
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Setup configures the level and output format of the global logger
+func Setup(level, format string) error {
+	if level != "" {
+		lvl, err := logrus.ParseLevel(level)
+		if err != nil {
+			return err
+		}
+		logrus.SetLevel(lvl)
+	}
+
+	switch format {
+	case "", FormatText:
+		logrus.SetFormatter(&logrus.TextFormatter{
+			FullTimestamp: true,
+		})
+	case FormatJSON:
+		logrus.SetFormatter(&logrus.JSONFormatter{})
+	default:
+		return fmt.Errorf("unsupported log format %q, must be %s or %s", format, FormatText, FormatJSON)
+	}
+	return nil
+}
+
+// ForObject returns a logger with the namespace/name of the object being reconciled
+func ForObject(namespace, name string) *logrus.Entry {
+	if namespace == "" {
+		return logrus.WithField("object", name)
+	}
+	return logrus.WithField("object", namespace+"/"+name)
+}
+
+// ForReconcile returns a logger for a single run of handler on key, all lines carry the same reconcile ID
+func ForReconcile(handler, key string) *logrus.Entry {
+	return logrus.WithFields(logrus.Fields{
+		"handler":     handler,
+		"object":      key,
+		"reconcileID": reconcileID(),
+	})
+}
+
+func reconcileID() string {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rancher/wrangler/pkg/generic"
+)
+
+var (
+	reconcileTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "rancher_operator",
+		Name:      "reconcile_total",
+		Help:      "Number of reconciles by handler and result",
+	}, []string{"handler", "result"})
+	reconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "rancher_operator",
+		Name:      "reconcile_duration_seconds",
+		Help:      "Duration of reconciles by handler",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 4, 10),
+	}, []string{"handler"})
+	rancherRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "rancher_operator",
+		Name:      "rancher_request_duration_seconds",
+		Help:      "Latency of requests to the Rancher API by verb and status code",
+		Buckets:   prometheus.ExponentialBuckets(0.005, 4, 8),
+	}, []string{"verb", "code"})
+
+	// KubeConfigRotations counts kubeconfig secrets that were regenerated with different content
+	KubeConfigRotations = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "rancher_operator",
+		Name:      "kubeconfig_rotations_total",
+		Help:      "Number of kubeconfig secrets that were rewritten with new content",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(reconcileTotal, reconcileDuration, rancherRequestDuration, KubeConfigRotations)
+}
+
+// ObserveReconcile records a reconcile of handler that started at start and returned err
+func ObserveReconcile(handler string, start time.Time, err error) {
+	result := "success"
+	switch {
+	case err == generic.ErrSkip:
+		result = "skip"
+	case err != nil:
+		result = "error"
+	}
+	reconcileTotal.WithLabelValues(handler, result).Inc()
+	reconcileDuration.WithLabelValues(handler).Observe(time.Since(start).Seconds())
+}
+
+// InstrumentRancherTransport records the latency of requests sent through rt, to be used with rest.Config.Wrap
+func InstrumentRancherTransport(rt http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		start := time.Now()
+		resp, err := rt.RoundTrip(req)
+		code := "error"
+		if err == nil {
+			code = strconv.Itoa(resp.StatusCode)
+		}
+		rancherRequestDuration.WithLabelValues(req.Method, code).Observe(time.Since(start).Seconds())
+		return resp, err
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
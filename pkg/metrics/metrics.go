@@ -0,0 +1,30 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
+)
+
+// Serve exposes the prometheus metrics on address until ctx is done
+func Serve(ctx context.Context, address string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	server := &http.Server{
+		Addr:    address,
+		Handler: mux,
+	}
+
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logrus.Errorf("metrics server failed: %v", err)
+		}
+	}()
+}
@@ -0,0 +1,125 @@
+package metrics
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/util/workqueue"
+)
+
+var (
+	queueDepth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "rancher_operator",
+		Subsystem: "workqueue",
+		Name:      "depth",
+		Help:      "Current depth of the workqueue",
+	}, []string{"name"})
+	queueAdds = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "rancher_operator",
+		Subsystem: "workqueue",
+		Name:      "adds_total",
+		Help:      "Total number of adds handled by the workqueue",
+	}, []string{"name"})
+	queueLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "rancher_operator",
+		Subsystem: "workqueue",
+		Name:      "queue_duration_seconds",
+		Help:      "How long an item stays in the workqueue before being processed",
+		Buckets:   prometheus.ExponentialBuckets(10e-9, 10, 10),
+	}, []string{"name"})
+	queueWorkDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "rancher_operator",
+		Subsystem: "workqueue",
+		Name:      "work_duration_seconds",
+		Help:      "How long processing an item from the workqueue takes",
+		Buckets:   prometheus.ExponentialBuckets(10e-9, 10, 10),
+	}, []string{"name"})
+	queueUnfinished = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "rancher_operator",
+		Subsystem: "workqueue",
+		Name:      "unfinished_work_seconds",
+		Help:      "Seconds of work in progress that has not been observed by work_duration yet",
+	}, []string{"name"})
+	queueLongestRunning = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "rancher_operator",
+		Subsystem: "workqueue",
+		Name:      "longest_running_processor_seconds",
+		Help:      "Seconds the longest running processor of the workqueue has been running",
+	}, []string{"name"})
+	queueRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "rancher_operator",
+		Subsystem: "workqueue",
+		Name:      "retries_total",
+		Help:      "Total number of retries handled by the workqueue",
+	}, []string{"name"})
+)
+
+func init() {
+	prometheus.MustRegister(queueDepth, queueAdds, queueLatency, queueWorkDuration, queueUnfinished,
+		queueLongestRunning, queueRetries)
+	// must be set before any controller creates its queue
+	workqueue.SetProvider(workqueueProvider{})
+}
+
+type workqueueProvider struct{}
+
+// depths holds the current depth of each workqueue by name for QueueDepths
+var depths sync.Map
+
+// QueueDepths returns the number of items waiting in each workqueue
+func QueueDepths() map[string]int64 {
+	result := map[string]int64{}
+	depths.Range(func(name, depth interface{}) bool {
+		result[name.(string)] = atomic.LoadInt64(depth.(*int64))
+		return true
+	})
+	return result
+}
+
+type depthGauge struct {
+	workqueue.GaugeMetric
+	depth *int64
+}
+
+func (g depthGauge) Inc() {
+	atomic.AddInt64(g.depth, 1)
+	g.GaugeMetric.Inc()
+}
+
+func (g depthGauge) Dec() {
+	atomic.AddInt64(g.depth, -1)
+	g.GaugeMetric.Dec()
+}
+
+func (workqueueProvider) NewDepthMetric(name string) workqueue.GaugeMetric {
+	depth, _ := depths.LoadOrStore(name, new(int64))
+	return depthGauge{
+		GaugeMetric: queueDepth.WithLabelValues(name),
+		depth:       depth.(*int64),
+	}
+}
+
+func (workqueueProvider) NewAddsMetric(name string) workqueue.CounterMetric {
+	return queueAdds.WithLabelValues(name)
+}
+
+func (workqueueProvider) NewLatencyMetric(name string) workqueue.HistogramMetric {
+	return queueLatency.WithLabelValues(name)
+}
+
+func (workqueueProvider) NewWorkDurationMetric(name string) workqueue.HistogramMetric {
+	return queueWorkDuration.WithLabelValues(name)
+}
+
+func (workqueueProvider) NewUnfinishedWorkSecondsMetric(name string) workqueue.SettableGaugeMetric {
+	return queueUnfinished.WithLabelValues(name)
+}
+
+func (workqueueProvider) NewLongestRunningProcessorSecondsMetric(name string) workqueue.SettableGaugeMetric {
+	return queueLongestRunning.WithLabelValues(name)
+}
+
+func (workqueueProvider) NewRetriesMetric(name string) workqueue.CounterMetric {
+	return queueRetries.WithLabelValues(name)
+}
@@ -0,0 +1,101 @@
+package namespaces
+
+import (
+	"context"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// ProjectIDAnnotation and ProjectIDLabel are how Rancher records the project of a namespace, the annotation
+	// holds <cluster>:<project> and the label only the project
+	ProjectIDAnnotation = "field.cattle.io/projectId"
+	ProjectIDLabel      = "field.cattle.io/projectId"
+
+	// assignedAnnotation marks namespaces assigned by the operator, only those are removed from a project
+	assignedAnnotation = "rancher.cattle.io/assigned-project"
+)
+
+// Assign creates namespaces in the downstream cluster of cfg and moves them to projectID, in the <cluster>:<project>
+// form. Namespaces assigned to projectID by a previous call that are no longer listed are removed from the project,
+// they are never deleted.
+func Assign(cfg *rest.Config, projectID string, namespaces []string) error {
+	k8s, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	project := projectID
+	if i := strings.Index(projectID, ":"); i >= 0 {
+		project = projectID[i+1:]
+	}
+
+	wanted := map[string]bool{}
+	for _, namespace := range namespaces {
+		wanted[namespace] = true
+
+		ns, err := k8s.CoreV1().Namespaces().Get(context.TODO(), namespace, metav1.GetOptions{})
+		if apierror.IsNotFound(err) {
+			ns = &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: namespace,
+				},
+			}
+			assign(ns, projectID, project)
+			if _, err := k8s.CoreV1().Namespaces().Create(context.TODO(), ns, metav1.CreateOptions{}); err != nil {
+				return err
+			}
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		if ns.Annotations[ProjectIDAnnotation] == projectID && ns.Annotations[assignedAnnotation] == projectID &&
+			ns.Labels[ProjectIDLabel] == project {
+			continue
+		}
+		ns = ns.DeepCopy()
+		assign(ns, projectID, project)
+		if _, err := k8s.CoreV1().Namespaces().Update(context.TODO(), ns, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+
+	assigned, err := k8s.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{
+		LabelSelector: ProjectIDLabel + "=" + project,
+	})
+	if err != nil {
+		return err
+	}
+	for _, ns := range assigned.Items {
+		if wanted[ns.Name] || ns.Annotations[assignedAnnotation] != projectID {
+			continue
+		}
+		ns := ns.DeepCopy()
+		delete(ns.Labels, ProjectIDLabel)
+		delete(ns.Annotations, ProjectIDAnnotation)
+		delete(ns.Annotations, assignedAnnotation)
+		if _, err := k8s.CoreV1().Namespaces().Update(context.TODO(), ns, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func assign(ns *corev1.Namespace, projectID, project string) {
+	if ns.Labels == nil {
+		ns.Labels = map[string]string{}
+	}
+	if ns.Annotations == nil {
+		ns.Annotations = map[string]string{}
+	}
+	ns.Labels[ProjectIDLabel] = project
+	ns.Annotations[ProjectIDAnnotation] = projectID
+	ns.Annotations[assignedAnnotation] = projectID
+}
@@ -0,0 +1,181 @@
+package operatorconfig
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/rancher/rancher-operator/pkg/features"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ConfigMapName is the config map in the operator's namespace holding the settings below, they are reloaded
+// whenever it changes
+const ConfigMapName = "rancher-operator-config"
+
+// The keys of the config map
+const (
+	DefaultControlPlanePortKey = "defaultControlPlanePort"
+	KubeConfigTokenTTLKey      = "kubeconfigTokenTTL"
+	ClusterResyncIntervalKey   = "clusterResyncInterval"
+	FeatureGatesKey            = "featureGates"
+	LabelSyncPolicyKey         = "labelSyncPolicy"
+)
+
+// The values of labelSyncPolicy, which decides the labels of management clusters copied to their fleet clusters
+const (
+	LabelSyncPolicyAll  = "All"
+	LabelSyncPolicyNone = "None"
+)
+
+// Settings are the operator settings that can be changed without a restart
+type Settings struct {
+	// DefaultControlPlanePort is set by the webhook on clusters without a control plane port
+	DefaultControlPlanePort int
+	// KubeConfigTokenTTL is the lifetime of the tokens minted for generated kubeconfigs, zero never expires.
+	// Expired tokens are replaced on the next reconcile of the cluster.
+	KubeConfigTokenTTL time.Duration
+	// ClusterResyncInterval requeues every cluster this long after it was reconciled, zero only requeues on
+	// changes and informer resyncs
+	ClusterResyncInterval time.Duration
+	// FeatureGates override --feature-gates
+	FeatureGates    map[string]bool
+	LabelSyncPolicy string
+}
+
+// Enabled returns if feature is turned on in featureGates, otherwise by --feature-gates or its default
+func (s Settings) Enabled(feature *features.Feature) bool {
+	if enabled, ok := s.FeatureGates[feature.Name]; ok {
+		return enabled
+	}
+	return feature.Enabled()
+}
+
+func defaults() Settings {
+	return Settings{
+		DefaultControlPlanePort: 6443,
+		LabelSyncPolicy:         LabelSyncPolicyAll,
+	}
+}
+
+// Config holds the current settings
+type Config struct {
+	settings atomic.Value
+}
+
+// Watch reloads the settings from the config map in namespace until ctx is done. The config map is watched on
+// its own, so the settings are current before this replica is elected leader. Without a namespace the defaults
+// are kept.
+func Watch(ctx context.Context, k8s kubernetes.Interface, namespace string) *Config {
+	c := &Config{}
+	c.settings.Store(defaults())
+	if namespace == "" {
+		return c
+	}
+
+	lw := cache.NewListWatchFromClient(k8s.CoreV1().RESTClient(), "configmaps", namespace,
+		fields.OneTermEqualSelector("metadata.name", ConfigMapName))
+	informer := cache.NewSharedInformer(lw, &corev1.ConfigMap{}, 0)
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.load(obj)
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			c.load(obj)
+		},
+		DeleteFunc: func(interface{}) {
+			logrus.Infof("Config map %s/%s was deleted, using the default settings", namespace, ConfigMapName)
+			c.settings.Store(defaults())
+		},
+	})
+	go informer.Run(ctx.Done())
+	return c
+}
+
+// Get returns the current settings
+func (c *Config) Get() Settings {
+	return c.settings.Load().(Settings)
+}
+
+func (c *Config) load(obj interface{}) {
+	configMap, ok := obj.(*corev1.ConfigMap)
+	if !ok {
+		return
+	}
+	c.settings.Store(Parse(configMap.Data))
+	logrus.Infof("Loaded settings from config map %s/%s", configMap.Namespace, configMap.Name)
+}
+
+// Parse reads the settings from the data of the config map. Invalid values are logged and keep their default,
+// so a typo doesn't stop the other settings from applying.
+func Parse(data map[string]string) Settings {
+	settings := defaults()
+
+	if value, ok := data[DefaultControlPlanePortKey]; ok {
+		port, err := strconv.Atoi(value)
+		if err != nil || port < 1 || port > 65535 {
+			invalid(DefaultControlPlanePortKey, value, "must be a port between 1 and 65535")
+		} else {
+			settings.DefaultControlPlanePort = port
+		}
+	}
+
+	if value, ok := data[KubeConfigTokenTTLKey]; ok {
+		if ttl, err := parseDuration(value); err != nil {
+			invalid(KubeConfigTokenTTLKey, value, "must be a duration")
+		} else {
+			settings.KubeConfigTokenTTL = ttl
+		}
+	}
+
+	if value, ok := data[ClusterResyncIntervalKey]; ok {
+		if interval, err := parseDuration(value); err != nil {
+			invalid(ClusterResyncIntervalKey, value, "must be a duration")
+		} else {
+			settings.ClusterResyncInterval = interval
+		}
+	}
+
+	if value, ok := data[FeatureGatesKey]; ok {
+		settings.FeatureGates = map[string]bool{}
+		for _, gate := range strings.Split(value, ",") {
+			gates, err := features.Parse(gate)
+			if err != nil {
+				invalid(FeatureGatesKey, gate, err.Error())
+				continue
+			}
+			for name, enabled := range gates {
+				settings.FeatureGates[name] = enabled
+			}
+		}
+	}
+
+	if value, ok := data[LabelSyncPolicyKey]; ok {
+		if value != LabelSyncPolicyAll && value != LabelSyncPolicyNone {
+			invalid(LabelSyncPolicyKey, value, "must be All or None")
+		} else {
+			settings.LabelSyncPolicy = value
+		}
+	}
+
+	return settings
+}
+
+// parseDuration is time.ParseDuration without negative durations
+func parseDuration(value string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if err == nil && d < 0 {
+		return 0, strconv.ErrRange
+	}
+	return d, err
+}
+
+func invalid(key, value, reason string) {
+	logrus.Warnf("Ignoring %s %q in config map %s: %s", key, value, ConfigMapName, reason)
+}
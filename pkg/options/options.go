@@ -0,0 +1,158 @@
+package options
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+const (
+	VersionSkewPolicyWarn    = "warn"
+	VersionSkewPolicyEnforce = "enforce"
+
+	OrphanClusterPolicyFlag   = "flag"
+	OrphanClusterPolicyDelete = "delete"
+)
+
+// Options are the operator wide settings configured from the command line
+type Options struct {
+	DeletionGracePeriod    time.Duration
+	ProvisioningTimeout    time.Duration
+	RegistrationTokenTTL   time.Duration
+	CacheSyncTimeout       time.Duration
+	ResyncPeriod           time.Duration
+	ResyncJitter           float64
+	ShutdownTimeout        time.Duration
+	ArgoCDNamespace        string
+	EncryptionKeyFile      string
+	AgeIdentityFile        string
+	ConfigNamespace        string
+	VersionSkewPolicy      string
+	OrphanClusterPolicy    string
+	KubeConfigCABundleFile string
+	MetricsAddress         string
+	HealthAddress          string
+	DebugAddress           string
+	WebhookAddress         string
+	WebhookCertDir         string
+	WebhookService         string
+	WebhookNamespace       string
+	TracingEndpoint        string
+	TracingInsecure        bool
+
+	LeaderElectionNamespace     string
+	LeaderElectionName          string
+	LeaderElectionLeaseDuration time.Duration
+	LeaderElectionRenewDeadline time.Duration
+	LeaderElectionRetryPeriod   time.Duration
+
+	DownstreamConcurrency        int
+	DownstreamClusterConcurrency int
+	MaxClustersPerNamespace      int
+
+	// Workers is the number of workers per kind, the others override it for a kind when set
+	Workers            int
+	ClusterWorkers     int
+	KubeconfigWorkers  int
+	ProjectWorkers     int
+	FleetBundleWorkers int
+
+	// KubeAPIQPS and KubeAPIBurst throttle the clients, the management values throttle the requests served by
+	// Rancher and the cluster values the reconciles of clusters. Zero keeps the defaults.
+	KubeAPIQPS            float64
+	KubeAPIBurst          int
+	ManagementAPIQPS      float64
+	ManagementAPIBurst    int
+	ClusterReconcileQPS   float64
+	ClusterReconcileBurst int
+
+	// WatchNamespaces restricts the rancher.cattle.io objects reconciled to these namespaces, empty for all
+	WatchNamespaces []string
+
+	// ShardLabel and ShardValue select the clusters reconciled by this deployment, clusters without the label
+	// belong to the shard with an empty value
+	ShardLabel string
+	ShardValue string
+
+	// The kubeconfig, or URL and token, of the cluster Rancher runs in when the operator runs in another cluster
+	ManagementKubeConfig string
+	ManagementURL        string
+	ManagementToken      string
+	ManagementCAFile     string
+
+	// InstanceName is set on the v3 clusters and secrets the operator applies, objects of another instance are
+	// never updated or pruned
+	InstanceName string
+
+	// FleetWorkspaces maps namespaces to the fleet workspace of their clusters, the * key matches any namespace
+	FleetWorkspaces map[string]string
+	// AllowedFleetWorkspaces may be set in spec.fleetWorkspaceName of clusters of any namespace
+	AllowedFleetWorkspaces []string
+
+	// Controllers are the controllers to run, all of them when empty
+	Controllers []string
+}
+
+// ParseList parses a comma separated list
+func ParseList(value string) []string {
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// ShardSelector returns the label selector of the clusters of a shard, empty if the clusters aren't sharded
+func ShardSelector(label, value string) (string, error) {
+	if label == "" {
+		if value != "" {
+			return "", fmt.Errorf("shard value %q is set without a shard label", value)
+		}
+		return "", nil
+	}
+
+	op, values := selection.Equals, []string{value}
+	if value == "" {
+		op, values = selection.DoesNotExist, nil
+	}
+	req, err := labels.NewRequirement(label, op, values)
+	if err != nil {
+		return "", err
+	}
+	return req.String(), nil
+}
+
+// ParseMapping parses comma separated key=value pairs
+func ParseMapping(value string) (map[string]string, error) {
+	result := map[string]string{}
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("%q is not a key=value pair", pair)
+		}
+		result[parts[0]] = parts[1]
+	}
+	return result, nil
+}
+
+// Enabled returns true if the controller called name runs
+func (o Options) Enabled(name string) bool {
+	if len(o.Controllers) == 0 {
+		return true
+	}
+	for _, controller := range o.Controllers {
+		if controller == name {
+			return true
+		}
+	}
+	return false
+}
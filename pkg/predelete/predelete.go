@@ -0,0 +1,152 @@
+package predelete
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/wrangler/pkg/name"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	DefaultNamespace = "cattle-pre-delete-hooks"
+
+	hookLabel      = "rancher.cattle.io/pre-delete-hook"
+	webhookTimeout = 30 * time.Second
+)
+
+type Result struct {
+	Done    bool
+	Message string
+}
+
+// Payload is posted to the URL of a webhook hook
+type Payload struct {
+	Namespace   string `json:"namespace"`
+	Name        string `json:"name"`
+	ClusterName string `json:"clusterName"`
+}
+
+// Job runs the job of hook in the downstream cluster. The hook is asynchronous, Job should be called until the
+// result is Done. The job is removed once it completed, a failed job is kept for inspection.
+func Job(cfg *rest.Config, hook v1.PreDeleteHook) (Result, error) {
+	k8s, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return Result{}, err
+	}
+
+	namespace := hook.Job.Namespace
+	if namespace == "" {
+		namespace = DefaultNamespace
+	}
+	jobName := name.SafeConcatName("pre-delete", hook.Name)
+
+	job, err := k8s.BatchV1().Jobs(namespace).Get(context.TODO(), jobName, metav1.GetOptions{})
+	if apierror.IsNotFound(err) {
+		return Result{Message: fmt.Sprintf("starting job %s/%s", namespace, jobName)}, create(k8s, namespace, jobName, hook)
+	} else if err != nil {
+		return Result{}, err
+	}
+
+	for _, cond := range job.Status.Conditions {
+		if cond.Type == batchv1.JobFailed && cond.Status == corev1.ConditionTrue {
+			return Result{Message: fmt.Sprintf("job %s/%s failed: %s", namespace, jobName, cond.Message)}, nil
+		}
+	}
+	if job.Status.Succeeded == 0 {
+		return Result{Message: fmt.Sprintf("waiting for job %s/%s", namespace, jobName)}, nil
+	}
+
+	propagation := metav1.DeletePropagationBackground
+	err = k8s.BatchV1().Jobs(namespace).Delete(context.TODO(), jobName, metav1.DeleteOptions{
+		PropagationPolicy: &propagation,
+	})
+	if apierror.IsNotFound(err) {
+		err = nil
+	}
+	return Result{Done: true, Message: fmt.Sprintf("job %s/%s completed", namespace, jobName)}, err
+}
+
+func create(k8s kubernetes.Interface, namespace, jobName string, hook v1.PreDeleteHook) error {
+	_, err := k8s.CoreV1().Namespaces().Create(context.TODO(), &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: namespace,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil && !apierror.IsAlreadyExists(err) {
+		return err
+	}
+
+	labels := map[string]string{
+		hookLabel: hook.Name,
+	}
+	_, err = k8s.BatchV1().Jobs(namespace).Create(context.TODO(), &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: hook.Job.BackoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy:      corev1.RestartPolicyNever,
+					ServiceAccountName: hook.Job.ServiceAccountName,
+					Containers: []corev1.Container{
+						{
+							Name:    "hook",
+							Image:   hook.Job.Image,
+							Command: hook.Job.Command,
+							Args:    hook.Job.Args,
+							Env:     hook.Job.Env,
+						},
+					},
+				},
+			},
+		},
+	}, metav1.CreateOptions{})
+	if apierror.IsAlreadyExists(err) {
+		return nil
+	}
+	return err
+}
+
+// Webhook posts the cluster to the URL of hook, the hook is Done once the URL returns a 2xx status. Request
+// failures are reported in the message so that the hook is retried until it times out.
+func Webhook(cluster *v1.Cluster, hook v1.PreDeleteHook) (Result, error) {
+	body, err := json.Marshal(Payload{
+		Namespace:   cluster.Namespace,
+		Name:        cluster.Name,
+		ClusterName: cluster.Status.ClusterName,
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	client := http.Client{
+		Timeout: webhookTimeout,
+	}
+	resp, err := client.Post(hook.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return Result{Message: fmt.Sprintf("calling %s: %v", hook.URL, err)}, nil
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return Result{Message: fmt.Sprintf("%s returned %s", hook.URL, resp.Status)}, nil
+	}
+	return Result{Done: true, Message: fmt.Sprintf("%s returned %s", hook.URL, resp.Status)}, nil
+}
@@ -1,6 +1,7 @@
 package principals
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
@@ -10,12 +11,14 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	apierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
 
 	"github.com/rancher/rancher-operator/pkg/clients"
 
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/flowcontrol"
 
 	"github.com/rancher/norman/clientbase"
 	mgmtcontrollers "github.com/rancher/rancher-operator/pkg/generated/controllers/management.cattle.io/v3"
@@ -36,7 +39,7 @@ type Lookup struct {
 	cache           cache.Store
 	userCache       mgmtcontrollers.UserCache
 	tokenCache      mgmtcontrollers.TokenCache
-	secret          corecontrollers.SecretCache
+	secret          corecontrollers.SecretClient
 	secretName      string
 	secretNamespace string
 
@@ -44,6 +47,8 @@ type Lookup struct {
 	settings          mgmtcontrollers.SettingCache
 	client            *client.Client
 	collection        *client.PrincipalCollection
+	// rateLimiter is shared with the management.cattle.io clients, nil if Rancher isn't throttled
+	rateLimiter flowcontrol.RateLimiter
 }
 
 type entry struct {
@@ -69,13 +74,31 @@ func NewLookup(secretNamespace, secretName string, clients *clients.Clients) *Lo
 		}, 5*time.Minute),
 		userCache:       clients.Management.User().Cache(),
 		tokenCache:      clients.Management.Token().Cache(),
-		secret:          clients.Core.Secret().Cache(),
+		secret:          clients.Core.Secret(),
 		secretName:      secretName,
 		secretNamespace: secretNamespace,
 		settings:        clients.Management.Setting().Cache(),
+		rateLimiter:     clients.ManagementRateLimiter,
 	}
 }
 
+// NotFoundError is returned when no principal matches a name
+type NotFoundError struct {
+	Type string
+	Name string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("principle not found for %s %s", e.Type, e.Name)
+}
+
+func IsNotFound(err error) bool {
+	var notFound *NotFoundError
+	return errors.As(err, &notFound)
+}
+
+// LookupUser returns the principal ID of the user name. The name can be qualified with the auth provider, such as
+// github:octocat or activedirectory:jdoe, and must be when more than one provider has a match.
 func (l *Lookup) LookupUser(name string) (string, error) {
 	return l.lookupPrincipal(name, "user")
 }
@@ -85,8 +108,18 @@ func (l *Lookup) LookupGroup(name string) (string, error) {
 }
 
 func (l *Lookup) lookupPrincipal(name, principleType string) (string, error) {
+	// principal IDs such as github_user://1234 need no lookup
+	if strings.Contains(name, "://") {
+		return name, nil
+	}
+
 	cacheKey := fmt.Sprintf("%s/%s", principleType, name)
 
+	provider := ""
+	if i := strings.Index(name, ":"); i > 0 {
+		provider, name = name[:i], name[i+1:]
+	}
+
 	val, ok, err := l.cache.GetByKey(cacheKey)
 	if err != nil {
 		return "", err
@@ -99,6 +132,7 @@ func (l *Lookup) lookupPrincipal(name, principleType string) (string, error) {
 		return "", err
 	}
 
+	l.throttle()
 	col, err := c.Principal.CollectionActionSearch(l.collection, &client.SearchPrincipalsInput{
 		Name:          name,
 		PrincipalType: principleType,
@@ -107,13 +141,26 @@ func (l *Lookup) lookupPrincipal(name, principleType string) (string, error) {
 		return "", err
 	}
 
+	var (
+		ids       []string
+		providers []string
+	)
 	for _, col := range col.Data {
-		if strings.EqualFold(col.Name, name) && col.PrincipalType == principleType {
-			return col.ID, l.cache.Add(entry{key: cacheKey, value: col.ID})
+		if strings.EqualFold(col.Name, name) && col.PrincipalType == principleType &&
+			(provider == "" || strings.EqualFold(col.Provider, provider)) {
+			ids = append(ids, col.ID)
+			providers = append(providers, col.Provider)
 		}
 	}
 
-	return "", fmt.Errorf("principle not found for %s %s", principleType, name)
+	switch len(ids) {
+	case 0:
+		return "", &NotFoundError{Type: principleType, Name: name}
+	case 1:
+		return ids[0], l.cache.Add(entry{key: cacheKey, value: ids[0]})
+	}
+	return "", fmt.Errorf("%s %s matches principals of the %s providers, qualify it as <provider>:%s",
+		principleType, name, strings.Join(providers, ", "), name)
 }
 
 func (l *Lookup) getClient() (*client.Client, error) {
@@ -144,6 +191,7 @@ func (l *Lookup) getClient() (*client.Client, error) {
 		return nil, err
 	}
 
+	l.throttle()
 	collection, err := c.Principal.List(nil)
 	if err != nil {
 		return nil, err
@@ -156,7 +204,7 @@ func (l *Lookup) getClient() (*client.Client, error) {
 
 func (l *Lookup) getAccessKeyAndSecretKey() (string, string, error) {
 	if l.secretName != "" {
-		secret, err := l.secret.Get(l.secretNamespace, l.secretName)
+		secret, err := l.secret.Get(l.secretNamespace, l.secretName, metav1.GetOptions{})
 		if err != nil && !apierror.IsNotFound(err) {
 			return "", "", err
 		} else if !apierror.IsNotFound(err) {
@@ -188,3 +236,10 @@ func (l *Lookup) getAccessKeyAndSecretKey() (string, string, error) {
 
 	return "", "", fmt.Errorf("failed to find token for bootstrap admin user")
 }
+
+// throttle waits for the rate limiter before a request to the Rancher API
+func (l *Lookup) throttle() {
+	if l.rateLimiter != nil {
+		l.rateLimiter.Accept()
+	}
+}
@@ -0,0 +1,190 @@
+package rbac
+
+import (
+	"io/ioutil"
+	"sort"
+
+	"github.com/rancher/rancher-operator/pkg/features"
+	"github.com/rancher/wrangler/pkg/yaml"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ClusterRoleName = "rancher-operator"
+
+	// Core holds the rules every deployment needs, Webhook the rules of the admission webhooks, which are only
+	// served with --webhook-address. Neither is a controller that can be turned off with --controllers.
+	Core    = "core"
+	Webhook = "webhook"
+)
+
+var (
+	readVerbs  = []string{"get", "list", "watch"}
+	writeVerbs = []string{"get", "list", "watch", "create", "update", "patch", "delete"}
+
+	// controllerRules are the permissions each controller needs. A controller that is not registered must not
+	// be listed so its permissions are not granted.
+	controllerRules = map[string][]rbacv1.PolicyRule{
+		Core: {
+			rule("apiextensions.k8s.io", []string{"customresourcedefinitions"}, []string{"get", "list", "watch", "create", "update"}),
+			// leader election lock
+			rule("", []string{"configmaps"}, []string{"get", "list", "watch", "create", "update"}),
+			rule("coordination.k8s.io", []string{"leases"}, []string{"get", "list", "watch", "create", "update"}),
+			rule("management.cattle.io", []string{"settings"}, readVerbs),
+		},
+		"cluster": {
+			rule("rancher.cattle.io", []string{"clusters", "clusters/status", "clusterexports", "clusterexports/status"}, writeVerbs),
+			rule("management.cattle.io", []string{"clusters", "clusterregistrationtokens", "nodes", "nodepools", "tokens", "users"}, writeVerbs),
+			rule("", []string{"secrets"}, writeVerbs),
+			rule("", []string{"events"}, []string{"get", "list", "create", "patch"}),
+			rule("apps", []string{"daemonsets", "deployments"}, readVerbs),
+			rule("management.cattle.io", []string{"fleetworkspaces"}, readVerbs),
+			// images of a cluster
+			rule("management.cattle.io", []string{"rkek8ssystemimages"}, readVerbs),
+			rule("rancher.cattle.io", []string{"clustergroups", "clusterreferencegrants"}, readVerbs),
+		},
+		"clusterbackups": {
+			rule("rancher.cattle.io", []string{"clusterbackups", "clusterbackups/status", "clusters", "nodepools", "nodetemplates",
+				"projects", "roletemplatebindings"}, writeVerbs),
+			rule("", []string{"configmaps", "secrets"}, writeVerbs),
+		},
+		"clustergroups": {
+			rule("rancher.cattle.io", []string{"clustergroups", "clustergroups/status", "roletemplatebindings"}, writeVerbs),
+			rule("rancher.cattle.io", []string{"clusters"}, readVerbs),
+		},
+		"discovery": {
+			rule("rancher.cattle.io", []string{"clusterdiscoveries", "clusterdiscoveries/status", "clusters"}, writeVerbs),
+			rule("", []string{"secrets"}, readVerbs),
+		},
+		"encryptionkeys": {
+			rule("rancher.cattle.io", []string{"encryptionkeyrotations", "encryptionkeyrotations/status"}, writeVerbs),
+			rule("rancher.cattle.io", []string{"clusters"}, readVerbs),
+			rule("management.cattle.io", []string{"clusters"}, writeVerbs),
+		},
+		"etcdrestore": {
+			rule("rancher.cattle.io", []string{"etcdsnapshotrestores", "etcdsnapshotrestores/status"}, writeVerbs),
+			rule("rancher.cattle.io", []string{"clusters"}, readVerbs),
+			rule("management.cattle.io", []string{"clusters"}, writeVerbs),
+			rule("management.cattle.io", []string{"etcdbackups"}, readVerbs),
+		},
+		"nodepools": {
+			rule("rancher.cattle.io", []string{"nodepools", "nodepools/status"}, writeVerbs),
+			rule("rancher.cattle.io", []string{"clusters", "nodetemplates"}, readVerbs),
+			rule("management.cattle.io", []string{"nodepools"}, writeVerbs),
+			rule("management.cattle.io", []string{"nodes"}, readVerbs),
+		},
+		"nodetemplates": {
+			rule("rancher.cattle.io", []string{"nodetemplates", "nodetemplates/status"}, writeVerbs),
+			rule("management.cattle.io", []string{"nodetemplates"}, writeVerbs),
+		},
+		"projects": {
+			rule("rancher.cattle.io", []string{"projects", "projects/status"}, writeVerbs),
+			rule("management.cattle.io", []string{"projects", "podsecuritypolicytemplateprojectbindings"}, writeVerbs),
+			// kubeconfigs of downstream clusters
+			rule("", []string{"secrets"}, readVerbs),
+		},
+		"serviceusers": {
+			rule("rancher.cattle.io", []string{"rancherusers", "rancherusers/status", "ranchertokens", "ranchertokens/status"}, writeVerbs),
+			rule("management.cattle.io", []string{"users", "globalrolebindings", "tokens"}, writeVerbs),
+			rule("", []string{"secrets"}, writeVerbs),
+		},
+		"auth": {
+			rule("rancher.cattle.io", []string{"roletemplates", "roletemplates/status", "roletemplatebindings", "roletemplatebindings/status",
+				"globalroletemplatebindings", "globalroletemplatebindings/status", "globalroles", "globalroles/status",
+				"globalrolebindings", "globalrolebindings/status"}, writeVerbs),
+			rule("management.cattle.io", []string{"roletemplates", "clusterroletemplatebindings", "projectroletemplatebindings",
+				"globalroles", "globalrolebindings"}, writeVerbs),
+			// global role bindings of users are made to the management user of their principal
+			rule("management.cattle.io", []string{"users"}, readVerbs),
+			rule("", []string{"secrets"}, readVerbs),
+		},
+		Webhook: {
+			// cluster quota
+			rule("rancher.cattle.io", []string{"clusters"}, readVerbs),
+			// namespace isolation of referenced clusters
+			rule("management.cattle.io", []string{"clusters"}, readVerbs),
+			rule("rancher.cattle.io", []string{"clusterreferencegrants"}, readVerbs),
+			rule("", []string{"namespaces"}, readVerbs),
+		},
+		"workspace": {
+			rule("management.cattle.io", []string{"fleetworkspaces"}, writeVerbs),
+			rule("", []string{"namespaces"}, writeVerbs),
+		},
+		"fleetcluster": {
+			rule("fleet.cattle.io", []string{"clusters", "clustergroups", "clusterregistrationtokens", "gitrepos"}, writeVerbs),
+		},
+		"fleetbundle": {
+			rule("fleet.cattle.io", []string{"bundles", "bundles/status", "gitrepos", "gitrepos/status"}, writeVerbs),
+			rule("rancher.cattle.io", []string{"clusters"}, readVerbs),
+		},
+	}
+
+	// featureRules are the permissions a feature needs besides the ones of its controller, they are only
+	// granted while the feature is enabled
+	featureRules = map[string][]rbacv1.PolicyRule{
+		features.ServerDryRun.Name: {
+			// dry runs of management clusters through the dynamic client
+			rule("management.cattle.io", []string{"clusters"}, []string{"create", "patch"}),
+		},
+	}
+)
+
+func rule(group string, resources, verbs []string) rbacv1.PolicyRule {
+	return rbacv1.PolicyRule{
+		APIGroups: []string{group},
+		Resources: resources,
+		Verbs:     verbs,
+	}
+}
+
+// Controllers returns the sorted names of the controllers that can be turned on or off with --controllers
+func Controllers() []string {
+	var names []string
+	for name := range controllerRules {
+		if name != Core && name != Webhook {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Rules returns the rules needed by the given controllers and the enabled features, the Core rules are always
+// included
+func Rules(controllers, enabledFeatures []string) []rbacv1.PolicyRule {
+	controllers = append([]string{Core}, controllers...)
+	sort.Strings(controllers)
+	enabledFeatures = append([]string(nil), enabledFeatures...)
+	sort.Strings(enabledFeatures)
+
+	var rules []rbacv1.PolicyRule
+	for _, name := range controllers {
+		rules = append(rules, controllerRules[name]...)
+	}
+	for _, name := range enabledFeatures {
+		rules = append(rules, featureRules[name]...)
+	}
+	return rules
+}
+
+func ClusterRole(controllers, enabledFeatures []string) *rbacv1.ClusterRole {
+	return &rbacv1.ClusterRole{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "rbac.authorization.k8s.io/v1",
+			Kind:       "ClusterRole",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: ClusterRoleName,
+		},
+		Rules: Rules(controllers, enabledFeatures),
+	}
+}
+
+func WriteFile(filename string, controllers, enabledFeatures []string) error {
+	data, err := yaml.Export(ClusterRole(controllers, enabledFeatures))
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filename, data, 0644)
+}
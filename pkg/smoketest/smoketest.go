@@ -0,0 +1,131 @@
+package smoketest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rancher/wrangler/pkg/apply"
+	corev1 "k8s.io/api/core/v1"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	DefaultImage = "busybox:1.32"
+
+	namespace = "cattle-smoke-test"
+	name      = "smoke-test"
+	setID     = "rancher-operator-smoke-test"
+)
+
+type Result struct {
+	Done    bool
+	Passed  bool
+	Message string
+}
+
+// Run checks that a pod can be scheduled on the cluster and resolve a service through cluster DNS. The
+// test is asynchronous, Run should be called until the result is Done. The test objects are removed once
+// the test is done so that running it again starts from scratch.
+func Run(cfg *rest.Config, image string) (Result, error) {
+	if image == "" {
+		image = DefaultImage
+	}
+
+	k8s, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return Result{}, err
+	}
+
+	pod, err := k8s.CoreV1().Pods(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if apierror.IsNotFound(err) {
+		return Result{Message: "starting smoke test"}, create(cfg, image)
+	} else if err != nil {
+		return Result{}, err
+	}
+
+	var result Result
+	switch pod.Status.Phase {
+	case corev1.PodSucceeded:
+		result = Result{Done: true, Passed: true, Message: "smoke test passed"}
+	case corev1.PodFailed:
+		result = Result{Done: true, Message: fmt.Sprintf("smoke test pod failed: %s", podMessage(pod))}
+	default:
+		return Result{Message: fmt.Sprintf("smoke test pod is %s", pod.Status.Phase)}, nil
+	}
+
+	err = k8s.CoreV1().Namespaces().Delete(context.TODO(), namespace, metav1.DeleteOptions{})
+	if apierror.IsNotFound(err) {
+		err = nil
+	}
+	return result, err
+}
+
+func podMessage(pod *corev1.Pod) string {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Terminated != nil {
+			return fmt.Sprintf("exit code %d %s", status.State.Terminated.ExitCode, status.State.Terminated.Message)
+		}
+	}
+	return pod.Status.Message
+}
+
+func create(cfg *rest.Config, image string) error {
+	apply, err := apply.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	labels := map[string]string{
+		"app": name,
+	}
+
+	return apply.
+		WithDynamicLookup().
+		WithSetID(setID).
+		ApplyObjects(
+			&corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: namespace,
+				},
+			},
+			&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: namespace,
+				},
+				Spec: corev1.ServiceSpec{
+					Selector: labels,
+					Ports: []corev1.ServicePort{
+						{
+							Name:       "http",
+							Port:       80,
+							TargetPort: intstr.FromInt(80),
+						},
+					},
+				},
+			},
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: namespace,
+					Labels:    labels,
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{
+						{
+							Name:  name,
+							Image: image,
+							Command: []string{
+								"nslookup",
+								fmt.Sprintf("%s.%s.svc.cluster.local", name, namespace),
+							},
+						},
+					},
+				},
+			})
+}
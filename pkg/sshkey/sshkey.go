@@ -0,0 +1,238 @@
+package sshkey
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"fmt"
+	"path"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	Namespace                 = "kube-system"
+	DaemonSetName             = "rancher-operator-ssh-authorized-keys"
+	DefaultAuthorizedKeysPath = "/root/.ssh/authorized_keys"
+
+	keysAnnotation = "rancher.cattle.io/authorized-keys-hash"
+	keyType        = "ssh-ed25519"
+	beginBlock     = "# BEGIN rancher-operator"
+	endBlock       = "# END rancher-operator"
+)
+
+// authorizeScript replaces the block of the operator in the authorized keys file with $AUTHORIZED_KEYS and keeps
+// every other line, the pod then sleeps so the daemon set reports the node as done
+const authorizeScript = `set -e
+mkdir -p "$(dirname "$AUTHORIZED_KEYS_FILE")"
+touch "$AUTHORIZED_KEYS_FILE"
+{
+  sed '/^` + beginBlock + `$/,/^` + endBlock + `$/d' "$AUTHORIZED_KEYS_FILE"
+  echo "` + beginBlock + `"
+  echo "$AUTHORIZED_KEYS"
+  echo "` + endBlock + `"
+} > "$AUTHORIZED_KEYS_FILE.tmp"
+chmod 600 "$AUTHORIZED_KEYS_FILE.tmp"
+mv "$AUTHORIZED_KEYS_FILE.tmp" "$AUTHORIZED_KEYS_FILE"
+exec sleep 2147483647
+`
+
+type Key struct {
+	// PrivateKey is in OpenSSH format, unencrypted
+	PrivateKey []byte
+	// PublicKey is in authorized keys format
+	PublicKey   []byte
+	Fingerprint string
+}
+
+// Generate returns a new ed25519 key
+func Generate() (Key, error) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return Key{}, err
+	}
+	check := make([]byte, 4)
+	if _, err := rand.Read(check); err != nil {
+		return Key{}, err
+	}
+
+	wire := wireFormat(public)
+	return Key{
+		PrivateKey:  pem.EncodeToMemory(&pem.Block{Type: "OPENSSH PRIVATE KEY", Bytes: openSSHPrivateKey(wire, public, private, check)}),
+		PublicKey:   []byte(keyType + " " + base64.StdEncoding.EncodeToString(wire) + "\n"),
+		Fingerprint: fingerprint(wire),
+	}, nil
+}
+
+// Fingerprint returns the SHA256 fingerprint of a public key in authorized keys format, as printed by ssh-keygen
+func Fingerprint(publicKey []byte) (string, error) {
+	fields := strings.Fields(string(publicKey))
+	if len(fields) < 2 {
+		return "", fmt.Errorf("invalid public key")
+	}
+	wire, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return "", fmt.Errorf("invalid public key: %w", err)
+	}
+	return fingerprint(wire), nil
+}
+
+// Authorize runs a daemon set in the downstream cluster that writes publicKeys to the authorized keys file of every
+// node. It returns true once the daemon set rolled out, keys that are no longer passed are removed from the nodes.
+func Authorize(cfg *rest.Config, image, authorizedKeysPath string, publicKeys []string) (bool, error) {
+	k8s, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return false, err
+	}
+
+	desired := daemonSet(image, authorizedKeysPath, publicKeys)
+	existing, err := k8s.AppsV1().DaemonSets(Namespace).Get(context.TODO(), DaemonSetName, metav1.GetOptions{})
+	if apierror.IsNotFound(err) {
+		_, err = k8s.AppsV1().DaemonSets(Namespace).Create(context.TODO(), desired, metav1.CreateOptions{})
+		return false, err
+	} else if err != nil {
+		return false, err
+	}
+
+	if existing.Annotations[keysAnnotation] != desired.Annotations[keysAnnotation] || existing.Spec.Template.Spec.Containers[0].Image != image {
+		existing = existing.DeepCopy()
+		existing.Annotations = desired.Annotations
+		existing.Spec.Template = desired.Spec.Template
+		_, err = k8s.AppsV1().DaemonSets(Namespace).Update(context.TODO(), existing, metav1.UpdateOptions{})
+		return false, err
+	}
+
+	status := existing.Status
+	return status.ObservedGeneration >= existing.Generation &&
+		status.UpdatedNumberScheduled == status.DesiredNumberScheduled &&
+		status.NumberAvailable == status.DesiredNumberScheduled, nil
+}
+
+// Remove deletes the daemon set, the keys stay authorized on the nodes
+func Remove(cfg *rest.Config) error {
+	k8s, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	err = k8s.AppsV1().DaemonSets(Namespace).Delete(context.TODO(), DaemonSetName, metav1.DeleteOptions{})
+	if apierror.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func daemonSet(image, authorizedKeysPath string, publicKeys []string) *appsv1.DaemonSet {
+	keys := strings.Join(publicKeys, "\n")
+	hash := sha256.Sum256([]byte(image + "\x00" + authorizedKeysPath + "\x00" + keys))
+	labels := map[string]string{
+		"app": DaemonSetName,
+	}
+	dir := path.Dir(authorizedKeysPath)
+	hostPathType := corev1.HostPathDirectoryOrCreate
+	privileged := true
+
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      DaemonSetName,
+			Namespace: Namespace,
+			Annotations: map[string]string{
+				keysAnnotation: base64.RawURLEncoding.EncodeToString(hash[:]),
+			},
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					Tolerations: []corev1.Toleration{
+						{Operator: corev1.TolerationOpExists},
+					},
+					Containers: []corev1.Container{
+						{
+							Name:    "authorized-keys",
+							Image:   image,
+							Command: []string{"sh", "-c", authorizeScript},
+							Env: []corev1.EnvVar{
+								{Name: "AUTHORIZED_KEYS_FILE", Value: authorizedKeysPath},
+								{Name: "AUTHORIZED_KEYS", Value: keys},
+							},
+							SecurityContext: &corev1.SecurityContext{
+								Privileged: &privileged,
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{Name: "ssh", MountPath: dir},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "ssh",
+							VolumeSource: corev1.VolumeSource{
+								HostPath: &corev1.HostPathVolumeSource{
+									Path: dir,
+									Type: &hostPathType,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// wireFormat is the SSH encoding of an ed25519 public key
+func wireFormat(public ed25519.PublicKey) []byte {
+	var buf bytes.Buffer
+	writeStrings(&buf, []byte(keyType), public)
+	return buf.Bytes()
+}
+
+// openSSHPrivateKey is the openssh-key-v1 encoding of an unencrypted ed25519 key, as written by ssh-keygen
+func openSSHPrivateKey(wire []byte, public ed25519.PublicKey, private ed25519.PrivateKey, check []byte) []byte {
+	var keys bytes.Buffer
+	keys.Write(check)
+	keys.Write(check)
+	writeStrings(&keys, []byte(keyType), public, private, nil)
+	for i := byte(1); keys.Len()%8 != 0; i++ {
+		keys.WriteByte(i)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("openssh-key-v1\x00")
+	writeStrings(&buf, []byte("none"), []byte("none"), nil)
+	count := make([]byte, 4)
+	binary.BigEndian.PutUint32(count, 1)
+	buf.Write(count)
+	writeStrings(&buf, wire, keys.Bytes())
+	return buf.Bytes()
+}
+
+func writeStrings(buf *bytes.Buffer, fields ...[]byte) {
+	for _, field := range fields {
+		length := make([]byte, 4)
+		binary.BigEndian.PutUint32(length, uint32(len(field)))
+		buf.Write(length)
+		buf.Write(field)
+	}
+}
+
+func fingerprint(wire []byte) string {
+	sum := sha256.Sum256(wire)
+	return "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:])
+}
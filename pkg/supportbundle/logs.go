@@ -0,0 +1,55 @@
+package supportbundle
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+const maxLogLines = 2000
+
+// Logs keeps the most recent operator log lines so they can be included in support bundles
+var Logs = &LogBuffer{}
+
+type LogBuffer struct {
+	sync.Mutex
+	lines []string
+}
+
+func (l *LogBuffer) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (l *LogBuffer) Fire(entry *logrus.Entry) error {
+	line, err := entry.String()
+	if err != nil {
+		return err
+	}
+
+	l.Lock()
+	defer l.Unlock()
+	l.lines = append(l.lines, line)
+	if len(l.lines) > maxLogLines {
+		l.lines = l.lines[len(l.lines)-maxLogLines:]
+	}
+	return nil
+}
+
+// Filter returns the buffered lines that contain any of the matches
+func (l *LogBuffer) Filter(matches ...string) []byte {
+	l.Lock()
+	defer l.Unlock()
+
+	buf := &bytes.Buffer{}
+	for _, line := range l.lines {
+		for _, match := range matches {
+			if match != "" && strings.Contains(line, match) {
+				buf.WriteString(line)
+				break
+			}
+		}
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,48 @@
+package supportbundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"sort"
+	"time"
+)
+
+const FileName = "support-bundle.tar.gz"
+
+// Archive writes files into a gzipped tarball under a directory named prefix
+func Archive(prefix string, files map[string][]byte) ([]byte, error) {
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	buf := &bytes.Buffer{}
+	gz := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gz)
+
+	now := time.Now()
+	for _, name := range names {
+		data := files[name]
+		if err := tw.WriteHeader(&tar.Header{
+			Name:    prefix + "/" + name,
+			Mode:    0644,
+			Size:    int64(len(data)),
+			ModTime: now,
+		}); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write(data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
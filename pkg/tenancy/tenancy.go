@@ -0,0 +1,129 @@
+package tenancy
+
+import (
+	"fmt"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// The annotations wrangler sets on the management clusters generated for a cluster
+const (
+	ownerGVKAnnotation       = "objectset.rio.cattle.io/owner-gvk"
+	ownerNamespaceAnnotation = "objectset.rio.cattle.io/owner-namespace"
+	ownerNameAnnotation      = "objectset.rio.cattle.io/owner-name"
+)
+
+// Owner returns the cluster the management cluster was generated for, false for clusters created in Rancher
+func Owner(cluster *v3.Cluster) (string, string, bool) {
+	if cluster.Annotations[ownerGVKAnnotation] != v1.SchemeGroupVersion.WithKind("Cluster").String() {
+		return "", "", false
+	}
+	return cluster.Annotations[ownerNamespaceAnnotation], cluster.Annotations[ownerNameAnnotation], true
+}
+
+// Namespace returns the namespace that owns the management cluster, the namespace of the cluster it was generated
+// for, or its fleet workspace for clusters created in Rancher
+func Namespace(cluster *v3.Cluster) string {
+	if namespace, _, ok := Owner(cluster); ok {
+		return namespace
+	}
+	return cluster.Spec.FleetWorkspaceName
+}
+
+// Owns returns true if the management cluster belongs to namespace, clusters, projects and bindings in it may
+// always reference the cluster
+func Owns(namespace string, cluster *v3.Cluster) bool {
+	return Namespace(cluster) == namespace
+}
+
+// GrantLister lists the cluster reference grants of a namespace
+type GrantLister func(namespace string) ([]*v1.ClusterReferenceGrant, error)
+
+// CanReference returns true if namespace owns the management cluster or a grant in the owning namespace allows it
+// to reference the cluster
+func CanReference(namespace string, cluster *v3.Cluster, grants GrantLister) (bool, error) {
+	owner := Namespace(cluster)
+	if owner == namespace {
+		return true, nil
+	} else if owner == "" {
+		return false, nil
+	}
+
+	list, err := grants(owner)
+	if err != nil {
+		return false, err
+	}
+	for _, grant := range list {
+		if !grantedTo(grant, namespace) {
+			continue
+		}
+		sel := labels.Everything()
+		if grant.Spec.Selector != nil {
+			sel, err = metav1.LabelSelectorAsSelector(grant.Spec.Selector)
+			if err != nil {
+				return false, fmt.Errorf("invalid selector of cluster reference grant %s/%s: %w", grant.Namespace, grant.Name, err)
+			}
+		}
+		if sel.Matches(labels.Set(cluster.Labels)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// CanReplicateKubeConfig returns true if the clusters of namespace may replicate their kubeconfig secrets into
+// target, their own namespace or one with a grant that allows kubeconfig secrets from namespace
+func CanReplicateKubeConfig(namespace, target string, grants GrantLister) (bool, error) {
+	if target == namespace {
+		return true, nil
+	}
+
+	list, err := grants(target)
+	if err != nil {
+		return false, err
+	}
+	for _, grant := range list {
+		if grant.Spec.KubeConfigSecrets && grantedTo(grant, namespace) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// MappedFleetWorkspace returns the fleet workspace the mapping of namespaces to workspaces maps namespace to, the
+// namespace name if it isn't mapped
+func MappedFleetWorkspace(namespace string, mapping map[string]string) string {
+	if workspace, ok := mapping[namespace]; ok {
+		return workspace
+	}
+	if workspace, ok := mapping["*"]; ok {
+		return workspace
+	}
+	return namespace
+}
+
+// CanUseFleetWorkspace returns true if the clusters of namespace may choose workspace with spec.fleetWorkspaceName,
+// the workspace named after namespace or mapped to it or one of the allowed workspaces
+func CanUseFleetWorkspace(namespace, workspace string, mapping map[string]string, allowed []string) bool {
+	if workspace == namespace || workspace == MappedFleetWorkspace(namespace, mapping) {
+		return true
+	}
+	for _, allowed := range allowed {
+		if workspace == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+func grantedTo(grant *v1.ClusterReferenceGrant, namespace string) bool {
+	for _, from := range grant.Spec.From {
+		if from.Namespace == namespace {
+			return true
+		}
+	}
+	return false
+}
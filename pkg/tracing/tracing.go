@@ -0,0 +1,90 @@
+package tracing
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	instrumentationName = "github.com/rancher/rancher-operator"
+	serviceName         = "rancher-operator"
+)
+
+var (
+	// reconciles holds the context of the reconcile in progress per namespace/name, wrangler never runs
+	// the same key concurrently within a controller so spans started for a key belong to its current reconcile
+	reconciles sync.Map
+)
+
+// Setup exports spans over OTLP/gRPC to endpoint, without an endpoint spans are not recorded
+func Setup(ctx context.Context, endpoint string, insecure bool) error {
+	if endpoint == "" {
+		return nil
+	}
+
+	opts := []otlptracegrpc.Option{
+		otlptracegrpc.WithEndpoint(endpoint),
+	}
+	if insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes("",
+			attribute.String("service.name", serviceName))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	go func() {
+		<-ctx.Done()
+		provider.Shutdown(context.Background())
+	}()
+
+	return nil
+}
+
+// StartReconcile starts the root span of handler reconciling key, spans started for the same key before the
+// returned func is called are its children
+func StartReconcile(handler, key string) func(error) {
+	ctx, span := otel.Tracer(instrumentationName).Start(context.Background(), handler,
+		trace.WithAttributes(attribute.String("object", key)))
+	reconciles.Store(key, ctx)
+	return func(err error) {
+		reconciles.Delete(key)
+		End(span, err)
+	}
+}
+
+// Start starts a span as a child of the reconcile in progress for namespace/name
+func Start(namespace, name, spanName string, attrs ...attribute.KeyValue) trace.Span {
+	ctx := context.Background()
+	if parent, ok := reconciles.Load(namespace + "/" + name); ok {
+		ctx = parent.(context.Context)
+	}
+	_, span := otel.Tracer(instrumentationName).Start(ctx, spanName, trace.WithAttributes(attrs...))
+	return span
+}
+
+// End records err on span and ends it
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,289 @@
+package validation
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"time"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var (
+	rkeNetworkPlugins = map[string]bool{
+		"":        true,
+		"aci":     true,
+		"calico":  true,
+		"canal":   true,
+		"flannel": true,
+		"none":    true,
+		"weave":   true,
+	}
+
+	rkeVersion  = regexp.MustCompile(`^v\d+\.\d+\.\d+-rancher\d+-\d+$`)
+	k3sVersion  = regexp.MustCompile(`^v\d+\.\d+\.\d+\+k3s\d+$`)
+	rke2Version = regexp.MustCompile(`^v\d+\.\d+\.\d+\+rke2r\d+$`)
+
+	nodeCount      = regexp.MustCompile(`^\d+$`)
+	nodePercentage = regexp.MustCompile(`^\d+%$`)
+
+	hookName = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+)
+
+// ProviderConfig checks the fields of the embedded provider configs that Rancher would otherwise only reject
+// once it starts provisioning, it returns one message per invalid field
+func ProviderConfig(cluster *v1.Cluster) []string {
+	var errs []string
+
+	if rke := cluster.Spec.RancherKubernetesEngineConfig; rke != nil {
+		if !rkeNetworkPlugins[rke.Network.Plugin] {
+			errs = append(errs, fmt.Sprintf("spec.rancherKubernetesEngineConfig.network.plugin %q must be one of aci, calico, canal, flannel, none or weave",
+				rke.Network.Plugin))
+		}
+		if rke.Version != "" && !rkeVersion.MatchString(rke.Version) {
+			errs = append(errs, fmt.Sprintf("spec.rancherKubernetesEngineConfig.kubernetesVersion %q must look like v1.20.4-rancher1-1", rke.Version))
+		}
+		errs = append(errs, duration("spec.rancherKubernetesEngineConfig.services.etcd.creation", rke.Services.Etcd.Creation)...)
+		errs = append(errs, duration("spec.rancherKubernetesEngineConfig.services.etcd.retention", rke.Services.Etcd.Retention)...)
+		if backup := rke.Services.Etcd.BackupConfig; backup != nil && (backup.IntervalHours < 0 || backup.Retention < 0) {
+			errs = append(errs, "spec.rancherKubernetesEngineConfig.services.etcd.backupConfig intervalHours and retention must not be negative")
+		}
+	}
+
+	if k3s := cluster.Spec.K3SConfig; k3s != nil && k3s.Version != "" && !k3sVersion.MatchString(k3s.Version) {
+		errs = append(errs, fmt.Sprintf("spec.k3sConfig.kubernetesVersion %q must look like v1.20.4+k3s1", k3s.Version))
+	}
+
+	if rke2 := cluster.Spec.RKE2Config; rke2 != nil && rke2.Version != "" && !rke2Version.MatchString(rke2.Version) {
+		errs = append(errs, fmt.Sprintf("spec.rke2Config.kubernetesVersion %q must look like v1.20.4+rke2r1", rke2.Version))
+	}
+
+	if schedule := cluster.Spec.EtcdSnapshotSchedule; schedule != nil {
+		if cluster.Spec.RancherKubernetesEngineConfig == nil {
+			errs = append(errs, "spec.etcdSnapshotSchedule is only supported for RKE clusters, k3s and rke2 clusters take their own snapshots")
+		}
+		if schedule.IntervalHours < 0 || schedule.Retention < 0 {
+			errs = append(errs, "spec.etcdSnapshotSchedule intervalHours and retention must not be negative")
+		}
+		if s3 := schedule.S3; s3 != nil && (s3.BucketName == "" || s3.CredentialsSecretName == "") {
+			errs = append(errs, "spec.etcdSnapshotSchedule.s3 bucketName and credentialsSecretName are required")
+		}
+	}
+
+	if strategy := cluster.Spec.UpgradeStrategy; strategy != nil {
+		errs = append(errs, maxUnavailable(cluster, "spec.upgradeStrategy.maxUnavailableControlPlane", strategy.MaxUnavailableControlPlane)...)
+		errs = append(errs, maxUnavailable(cluster, "spec.upgradeStrategy.maxUnavailableWorker", strategy.MaxUnavailableWorker)...)
+	}
+
+	if schedule := cluster.Spec.MaintenanceWindow; schedule != nil {
+		if _, err := time.LoadLocation(schedule.TimeZone); err != nil {
+			errs = append(errs, fmt.Sprintf("spec.maintenanceWindow.timeZone %q must be a time zone such as Europe/Berlin", schedule.TimeZone))
+		}
+		errs = append(errs, maintenanceWindows("spec.maintenanceWindow.windows", schedule.Windows)...)
+	}
+
+	if files := cluster.Spec.APIServerFiles; files != nil {
+		if cluster.Spec.RancherKubernetesEngineConfig == nil {
+			errs = append(errs, "spec.apiServerFiles is only supported for RKE clusters, the operator doesn't provision the nodes of other clusters")
+		}
+		errs = append(errs, secretKeyRef("spec.apiServerFiles.auditPolicy", files.AuditPolicy)...)
+		errs = append(errs, secretKeyRef("spec.apiServerFiles.admissionConfiguration", files.AdmissionConfiguration)...)
+	}
+
+	if sshKey := cluster.Spec.SSHKey; sshKey != nil {
+		if cluster.Spec.RancherKubernetesEngineConfig == nil {
+			errs = append(errs, "spec.sshKey is only supported for RKE clusters")
+		}
+		if sshKey.AuthorizedKeysPath != "" && !path.IsAbs(sshKey.AuthorizedKeysPath) {
+			errs = append(errs, fmt.Sprintf("spec.sshKey.authorizedKeysPath %q must be an absolute path", sshKey.AuthorizedKeysPath))
+		}
+		if sshKey.RotationIntervalHours < 0 {
+			errs = append(errs, "spec.sshKey.rotationIntervalHours must not be negative")
+		}
+	}
+
+	errs = append(errs, autoscaling(cluster)...)
+
+	errs = append(errs, preDeleteHooks(cluster.Spec.PreDeleteHooks)...)
+
+	for i, manifest := range cluster.Spec.BootstrapManifests {
+		if manifest.Kind != "" && manifest.Kind != "ConfigMap" && manifest.Kind != "Secret" {
+			errs = append(errs, fmt.Sprintf("spec.bootstrapManifests[%d].kind %q must be ConfigMap or Secret", i, manifest.Kind))
+		}
+		if manifest.Name == "" {
+			errs = append(errs, fmt.Sprintf("spec.bootstrapManifests[%d].name is required", i))
+		}
+	}
+
+	errs = append(errs, bootstrapCharts(cluster.Spec.BootstrapCharts)...)
+
+	if proxy := cluster.Spec.Proxy; proxy != nil {
+		errs = append(errs, proxyURL("spec.proxy.httpProxy", proxy.HTTPProxy)...)
+		errs = append(errs, proxyURL("spec.proxy.httpsProxy", proxy.HTTPSProxy)...)
+	}
+
+	return errs
+}
+
+func preDeleteHooks(hooks []v1.PreDeleteHook) []string {
+	var errs []string
+	names := map[string]bool{}
+	for i, hook := range hooks {
+		field := fmt.Sprintf("spec.preDeleteHooks[%d]", i)
+		if !hookName.MatchString(hook.Name) || len(hook.Name) > 52 {
+			errs = append(errs, fmt.Sprintf("%s.name %q must be a lowercase DNS label of at most 52 characters", field, hook.Name))
+		} else if names[hook.Name] {
+			errs = append(errs, fmt.Sprintf("%s.name %q is used by another hook", field, hook.Name))
+		}
+		names[hook.Name] = true
+
+		switch {
+		case (hook.Job == nil) == (hook.URL == ""):
+			errs = append(errs, fmt.Sprintf("%s must set exactly one of job and url", field))
+		case hook.Job != nil && hook.Job.Image == "":
+			errs = append(errs, fmt.Sprintf("%s.job.image is required", field))
+		case hook.URL != "":
+			if u, err := url.Parse(hook.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				errs = append(errs, fmt.Sprintf("%s.url %q must be an http or https URL", field, hook.URL))
+			}
+		}
+		if hook.TimeoutSeconds < 0 {
+			errs = append(errs, fmt.Sprintf("%s.timeoutSeconds must not be negative", field))
+		}
+	}
+	return errs
+}
+
+// bootstrapCharts checks the release names, which are also the names of the HelmChart objects downstream
+func bootstrapCharts(charts []v1.BootstrapChart) []string {
+	var errs []string
+	names := map[string]bool{}
+	for i, chart := range charts {
+		field := fmt.Sprintf("spec.bootstrapCharts[%d]", i)
+		if chart.Chart == "" {
+			errs = append(errs, fmt.Sprintf("%s.chart is required", field))
+			continue
+		}
+		release := chart.Name
+		if release == "" {
+			release = chart.Chart
+		}
+		if !hookName.MatchString(release) || len(release) > 53 {
+			errs = append(errs, fmt.Sprintf("%s.name %q must be a lowercase DNS label of at most 53 characters", field, release))
+		} else if names[release] {
+			errs = append(errs, fmt.Sprintf("%s.name %q is used by another chart", field, release))
+		}
+		names[release] = true
+
+		if chart.Repo != "" {
+			if u, err := url.Parse(chart.Repo); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				errs = append(errs, fmt.Sprintf("%s.repo %q must be an http or https URL", field, chart.Repo))
+			}
+		}
+	}
+	return errs
+}
+
+// autoscaling checks the bounds and options of each node group, which must be a node group of the EKS config
+func autoscaling(cluster *v1.Cluster) []string {
+	if len(cluster.Spec.Autoscaling) == 0 {
+		return nil
+	}
+	if cluster.Spec.EKSConfig == nil {
+		return []string{"spec.autoscaling is only supported for EKS clusters, the node pools of RKE clusters are scaled with NodePools"}
+	}
+
+	groups := map[string]bool{}
+	for _, group := range cluster.Spec.EKSConfig.NodeGroups {
+		if group.NodegroupName != nil {
+			groups[*group.NodegroupName] = true
+		}
+	}
+
+	var errs []string
+	seen := map[string]bool{}
+	for i, autoscaling := range cluster.Spec.Autoscaling {
+		field := fmt.Sprintf("spec.autoscaling[%d]", i)
+		if !groups[autoscaling.NodeGroupName] {
+			errs = append(errs, fmt.Sprintf("%s.nodeGroupName %q must be a node group of spec.eksConfig", field, autoscaling.NodeGroupName))
+		} else if seen[autoscaling.NodeGroupName] {
+			errs = append(errs, fmt.Sprintf("%s.nodeGroupName %q is listed more than once", field, autoscaling.NodeGroupName))
+		}
+		seen[autoscaling.NodeGroupName] = true
+
+		if autoscaling.MinSize < 0 || autoscaling.MaxSize < 1 || autoscaling.MinSize > autoscaling.MaxSize {
+			errs = append(errs, fmt.Sprintf("%s maxSize must be positive and not less than minSize", field))
+		}
+		errs = append(errs, duration(field+".scaleDownUnneededTime", autoscaling.ScaleDownUnneededTime)...)
+		errs = append(errs, duration(field+".scaleDownUnreadyTime", autoscaling.ScaleDownUnreadyTime)...)
+		if threshold := autoscaling.ScaleDownUtilizationThreshold; threshold != "" {
+			if value, err := strconv.ParseFloat(threshold, 64); err != nil || value <= 0 || value > 1 {
+				errs = append(errs, fmt.Sprintf("%s.scaleDownUtilizationThreshold %q must be a number above 0 and up to 1", field, threshold))
+			}
+		}
+	}
+	return errs
+}
+
+func secretKeyRef(field string, ref *corev1.SecretKeySelector) []string {
+	if ref != nil && (ref.Name == "" || ref.Key == "") {
+		return []string{fmt.Sprintf("%s name and key are required", field)}
+	}
+	return nil
+}
+
+func maintenanceWindows(field string, windows []v1.MaintenanceWindow) []string {
+	var errs []string
+	for i, window := range windows {
+		field := fmt.Sprintf("%s[%d]", field, i)
+		if _, err := time.Parse("15:04", window.Start); err != nil {
+			errs = append(errs, fmt.Sprintf("%s.start %q must be a time such as 22:00", field, window.Start))
+		}
+		if window.DurationMinutes <= 0 {
+			errs = append(errs, fmt.Sprintf("%s.durationMinutes must be positive", field))
+		}
+		for _, day := range window.Days {
+			if _, ok := v1.Weekday(day); !ok {
+				errs = append(errs, fmt.Sprintf("%s.days %q must be one of Mon, Tue, Wed, Thu, Fri, Sat or Sun", field, day))
+			}
+		}
+	}
+	return errs
+}
+
+// maxUnavailable checks a node count of the upgrade strategy, only RKE upgrades a percentage of the nodes
+func maxUnavailable(cluster *v1.Cluster, field, value string) []string {
+	if value == "" || nodeCount.MatchString(value) {
+		return nil
+	}
+	if cluster.Spec.RancherKubernetesEngineConfig != nil {
+		if !nodePercentage.MatchString(value) {
+			return []string{fmt.Sprintf("%s %q must be a number or percentage of nodes", field, value)}
+		}
+		return nil
+	}
+	return []string{fmt.Sprintf("%s %q must be a number of nodes", field, value)}
+}
+
+func proxyURL(field, value string) []string {
+	if value == "" {
+		return nil
+	}
+	if u, err := url.Parse(value); err != nil || (u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "socks5") || u.Host == "" {
+		return []string{fmt.Sprintf("%s %q must be a URL such as http://proxy.example.com:3128", field, value)}
+	}
+	return nil
+}
+
+func duration(field, value string) []string {
+	if value == "" {
+		return nil
+	}
+	if _, err := time.ParseDuration(value); err != nil {
+		return []string{fmt.Sprintf("%s %q must be a duration such as 12h", field, value)}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package webhook
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/validation"
+	"github.com/rancher/wrangler/pkg/name"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// maxNameLength keeps cluster names usable as label values, they are the value of the claimed cluster labels. The
+// management cluster is named c-<namespace>-<name>, which SafeConcatName truncates and hashes past this length.
+const maxNameLength = 63
+
+func validateCluster(req *admissionv1.AdmissionRequest) ([]patchOperation, error) {
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return nil, nil
+	}
+
+	cluster := &v1.Cluster{}
+	if err := decode(req.Object.Raw, cluster); err != nil {
+		return nil, err
+	}
+
+	// objects being deleted only have their finalizers and status changed
+	if cluster.DeletionTimestamp != nil {
+		return nil, nil
+	}
+
+	var errs []string
+	if len(cluster.Name) > maxNameLength {
+		errs = append(errs, fmt.Sprintf("metadata.name must be no more than %d characters", maxNameLength))
+	}
+	// existing clusters keep the management cluster they were created with
+	if req.Operation == admissionv1.Create {
+		namespace := cluster.Namespace
+		if namespace == "" {
+			namespace = req.Namespace
+		}
+		rClusterName := strings.Join([]string{"c", namespace, cluster.Name}, "-")
+		if name.SafeConcatName("c", namespace, cluster.Name) != rClusterName {
+			errs = append(errs, fmt.Sprintf("metadata.namespace and metadata.name must be no more than %d characters together, "+
+				"the management cluster name %s would be truncated", maxNameLength-3, rClusterName))
+		}
+	}
+
+	providers := clusterProviders(cluster)
+	if len(providers) != 1 {
+		errs = append(errs, fmt.Sprintf("exactly one of %s must be set, found %d",
+			strings.Join(providerFields, ", "), len(providers)))
+	}
+
+	if endpoint := cluster.Spec.ControlPlaneEndpoint; endpoint != nil && (endpoint.Port < 1 || endpoint.Port > 65535) {
+		errs = append(errs, fmt.Sprintf("spec.controlPlaneEndpoint.port %d must be between 1 and 65535", endpoint.Port))
+	}
+
+	errs = append(errs, validation.ProviderConfig(cluster)...)
+
+	for _, err := range metav1validation.ValidateLabels(cluster.Spec.FleetLabels, field.NewPath("spec", "fleetLabels")) {
+		errs = append(errs, err.Error())
+	}
+
+	if req.Operation == admissionv1.Update {
+		old := &v1.Cluster{}
+		if err := decode(req.OldObject.Raw, old); err != nil {
+			return nil, err
+		}
+		if oldProviders := clusterProviders(old); len(oldProviders) == 1 && len(providers) == 1 && oldProviders[0] != providers[0] {
+			errs = append(errs, fmt.Sprintf("the provider can not be changed from %s to %s", oldProviders[0], providers[0]))
+		}
+		changed, err := destructiveChanges(old, cluster)
+		if err != nil {
+			return nil, err
+		}
+		if len(changed) > 0 {
+			errs = append(errs, destructiveChangeError(changed))
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil, nil
+}
+
+var providerFields = []string{
+	"spec.importedConfig",
+	"spec.referencedConfig",
+	"spec.rancherKubernetesEngineConfig",
+	"spec.eksConfig",
+	"spec.k3sConfig",
+	"spec.rke2Config",
+}
+
+// clusterProviders returns the spec fields of cluster that select how it is provisioned
+func clusterProviders(cluster *v1.Cluster) []string {
+	set := []bool{
+		cluster.Spec.ImportedConfig != nil,
+		cluster.Spec.ReferencedConfig != nil,
+		cluster.Spec.RancherKubernetesEngineConfig != nil,
+		cluster.Spec.EKSConfig != nil,
+		cluster.Spec.K3SConfig != nil,
+		cluster.Spec.RKE2Config != nil,
+	}
+
+	var providers []string
+	for i, ok := range set {
+		if ok {
+			providers = append(providers, providerFields[i])
+		}
+	}
+	return providers
+}
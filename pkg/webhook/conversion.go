@@ -0,0 +1,112 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1beta2"
+	"github.com/rancher/rancher-operator/pkg/crd"
+	"github.com/sirupsen/logrus"
+	apiextv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const ConvertClusterPath = "/convert-rancher-cattle-io-cluster"
+
+func convertHandler() http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		review := &apiextv1beta1.ConversionReview{}
+		if err := json.NewDecoder(req.Body).Decode(review); err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if review.Request == nil {
+			http.Error(rw, "missing conversion request", http.StatusBadRequest)
+			return
+		}
+
+		response := &apiextv1beta1.ConversionResponse{
+			UID: review.Request.UID,
+			Result: metav1.Status{
+				Status: metav1.StatusSuccess,
+			},
+		}
+		for _, obj := range review.Request.Objects {
+			converted, err := convertCluster(obj.Raw, review.Request.DesiredAPIVersion)
+			if err != nil {
+				response.ConvertedObjects = nil
+				response.Result = metav1.Status{
+					Status:  metav1.StatusFailure,
+					Message: err.Error(),
+				}
+				break
+			}
+			response.ConvertedObjects = append(response.ConvertedObjects, runtime.RawExtension{Raw: converted})
+		}
+
+		review.Response = response
+		review.Request = nil
+		rw.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(rw).Encode(review); err != nil {
+			logrus.Errorf("failed to write conversion response: %v", err)
+		}
+	})
+}
+
+func convertCluster(raw []byte, desiredAPIVersion string) ([]byte, error) {
+	typeMeta := metav1.TypeMeta{}
+	if err := json.Unmarshal(raw, &typeMeta); err != nil {
+		return nil, err
+	}
+	if typeMeta.APIVersion == desiredAPIVersion {
+		return raw, nil
+	}
+
+	var stored *v1.Cluster
+	switch typeMeta.APIVersion {
+	case v1.SchemeGroupVersion.String():
+		stored = &v1.Cluster{}
+		if err := json.Unmarshal(raw, stored); err != nil {
+			return nil, err
+		}
+	case v1beta2.SchemeGroupVersion.String():
+		cluster := &v1beta2.Cluster{}
+		if err := json.Unmarshal(raw, cluster); err != nil {
+			return nil, err
+		}
+		stored = cluster.ToV1()
+	default:
+		return nil, fmt.Errorf("can not convert from %s", typeMeta.APIVersion)
+	}
+
+	switch desiredAPIVersion {
+	case v1.SchemeGroupVersion.String():
+		return json.Marshal(stored)
+	case v1beta2.SchemeGroupVersion.String():
+		return json.Marshal(v1beta2.FromV1(stored))
+	}
+	return nil, fmt.Errorf("can not convert to %s", desiredAPIVersion)
+}
+
+// Conversion returns the CRD conversion config for the webhook service, nil when certDir has no ca.crt to verify
+// it with
+func Conversion(certDir, namespace, service string) (*crd.Conversion, error) {
+	caBundle, err := ioutil.ReadFile(filepath.Join(certDir, "ca.crt"))
+	if os.IsNotExist(err) || namespace == "" {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &crd.Conversion{
+		ServiceNamespace: namespace,
+		ServiceName:      service,
+		Path:             ConvertClusterPath,
+		CABundle:         caBundle,
+	}, nil
+}
@@ -0,0 +1,57 @@
+package webhook
+
+import (
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/operatorconfig"
+	admissionv1 "k8s.io/api/admission/v1"
+)
+
+// DefaultControlPlaneHost is set on clusters on admission, the port is the defaultControlPlanePort setting
+const DefaultControlPlaneHost = "localhost"
+
+// defaulter sets the defaults of unset cluster fields so the controller never has to write the spec
+func defaulter(config *operatorconfig.Config) admitFunc {
+	return func(req *admissionv1.AdmissionRequest) ([]patchOperation, error) {
+		return defaultCluster(req, config.Get().DefaultControlPlanePort)
+	}
+}
+
+func defaultCluster(req *admissionv1.AdmissionRequest, port int) ([]patchOperation, error) {
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return nil, nil
+	}
+
+	cluster := &v1.Cluster{}
+	if err := decode(req.Object.Raw, cluster); err != nil {
+		return nil, err
+	}
+
+	var patch []patchOperation
+	switch endpoint := cluster.Spec.ControlPlaneEndpoint; {
+	case endpoint == nil:
+		patch = append(patch, patchOperation{
+			Op:   "add",
+			Path: "/spec/controlPlaneEndpoint",
+			Value: v1.Endpoint{
+				Host: DefaultControlPlaneHost,
+				Port: port,
+			},
+		})
+	default:
+		if endpoint.Host == "" {
+			patch = append(patch, patchOperation{
+				Op:    "add",
+				Path:  "/spec/controlPlaneEndpoint/host",
+				Value: DefaultControlPlaneHost,
+			})
+		}
+		if endpoint.Port == 0 {
+			patch = append(patch, patchOperation{
+				Op:    "add",
+				Path:  "/spec/controlPlaneEndpoint/port",
+				Value: port,
+			})
+		}
+	}
+	return patch, nil
+}
@@ -0,0 +1,82 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+)
+
+// AllowDestructiveUpdateAnnotation must be "true" to change fields that rebuild or break a provisioned cluster
+const AllowDestructiveUpdateAnnotation = "rancher.cattle.io/allow-destructive-update"
+
+// destructiveFields are the JSON paths under spec that can not be changed in place by the provider
+var destructiveFields = []string{
+	"rancherKubernetesEngineConfig.network.plugin",
+	"rancherKubernetesEngineConfig.services.kubeController.clusterCidr",
+	"rancherKubernetesEngineConfig.services.kubeController.serviceClusterIpRange",
+	"rancherKubernetesEngineConfig.services.kubeApi.serviceClusterIpRange",
+	"rancherKubernetesEngineConfig.services.kubelet.clusterDomain",
+	"eksConfig.region",
+	"eksConfig.subnets",
+	"eksConfig.privateAccess",
+}
+
+// destructiveChanges returns the destructive fields changed from old to cluster, unless the change is allowed
+func destructiveChanges(old, cluster *v1.Cluster) ([]string, error) {
+	if cluster.Annotations[AllowDestructiveUpdateAnnotation] == "true" {
+		return nil, nil
+	}
+
+	oldSpec, err := toMap(old.Spec)
+	if err != nil {
+		return nil, err
+	}
+	newSpec, err := toMap(cluster.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []string
+	for _, field := range destructiveFields {
+		oldValue, ok := lookup(oldSpec, field)
+		if !ok {
+			// setting a field for the first time is not destructive
+			continue
+		}
+		if newValue, _ := lookup(newSpec, field); !reflect.DeepEqual(oldValue, newValue) {
+			changed = append(changed, "spec."+field)
+		}
+	}
+	return changed, nil
+}
+
+func destructiveChangeError(changed []string) string {
+	return fmt.Sprintf("%s can not be changed without the %s: \"true\" annotation",
+		strings.Join(changed, ", "), AllowDestructiveUpdateAnnotation)
+}
+
+func toMap(obj interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	result := map[string]interface{}{}
+	return result, json.Unmarshal(data, &result)
+}
+
+func lookup(data map[string]interface{}, path string) (interface{}, bool) {
+	var value interface{} = data
+	for _, key := range strings.Split(path, ".") {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = m[key]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
@@ -0,0 +1,138 @@
+package webhook
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "github.com/rancher/rancher-operator/pkg/apis/rancher.cattle.io/v1"
+	mgmtcontrollers "github.com/rancher/rancher-operator/pkg/generated/controllers/management.cattle.io/v3"
+	rocontrollers "github.com/rancher/rancher-operator/pkg/generated/controllers/rancher.cattle.io/v1"
+	"github.com/rancher/rancher-operator/pkg/tenancy"
+	admissionv1 "k8s.io/api/admission/v1"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Isolation rejects referenced clusters whose name or selector matches management clusters of other namespaces that grant
+// no access, the controller also never claims them, kubeconfig secret namespaces that grant no access and fleet
+// workspaces that aren't allowed for their namespace. Like the quota it reads from the API.
+type Isolation struct {
+	clusters          mgmtcontrollers.ClusterClient
+	grants            rocontrollers.ClusterReferenceGrantClient
+	workspaces        map[string]string
+	allowedWorkspaces []string
+}
+
+func NewIsolation(clusters mgmtcontrollers.ClusterClient, grants rocontrollers.ClusterReferenceGrantClient, workspaces map[string]string, allowedWorkspaces []string) *Isolation {
+	return &Isolation{
+		clusters:          clusters,
+		grants:            grants,
+		workspaces:        workspaces,
+		allowedWorkspaces: allowedWorkspaces,
+	}
+}
+
+func (i *Isolation) admit(req *admissionv1.AdmissionRequest) ([]patchOperation, error) {
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return nil, nil
+	}
+
+	cluster := &v1.Cluster{}
+	if err := decode(req.Object.Raw, cluster); err != nil {
+		return nil, err
+	}
+	if cluster.DeletionTimestamp != nil {
+		return nil, nil
+	}
+
+	if workspace := cluster.Spec.FleetWorkspaceName; workspace != "" &&
+		!tenancy.CanUseFleetWorkspace(req.Namespace, workspace, i.workspaces, i.allowedWorkspaces) {
+		return nil, fmt.Errorf("spec.fleetWorkspaceName %s is not allowed for clusters of namespace %s", workspace, req.Namespace)
+	}
+	if err := i.admitKubeConfigSecretNamespaces(req.Namespace, cluster); err != nil {
+		return nil, err
+	}
+	return nil, i.admitReferencedConfig(req.Namespace, cluster)
+}
+
+func (i *Isolation) admitKubeConfigSecretNamespaces(namespace string, cluster *v1.Cluster) error {
+	var denied []string
+	for _, target := range cluster.Spec.KubeConfigSecretNamespaces {
+		ok, err := tenancy.CanReplicateKubeConfig(namespace, target, i.listGrants)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			denied = append(denied, target)
+		}
+	}
+	if len(denied) > 0 {
+		return fmt.Errorf("spec.kubeConfigSecretNamespaces %s have no ClusterReferenceGrant allowing kubeConfigSecrets from %s",
+			strings.Join(denied, ", "), namespace)
+	}
+	return nil
+}
+
+func (i *Isolation) admitReferencedConfig(namespace string, cluster *v1.Cluster) error {
+	ref := cluster.Spec.ReferencedConfig
+	if ref == nil || (ref.Name == "" && ref.Selector == nil) {
+		return nil
+	}
+
+	if ref.Name != "" {
+		rCluster, err := i.clusters.Get(ref.Name, metav1.GetOptions{})
+		if apierror.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		ok, err := tenancy.CanReference(namespace, rCluster, i.listGrants)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("spec.referencedConfig.name %s is a management cluster of another namespace that grants no access", ref.Name)
+		}
+		return nil
+	}
+
+	sel, err := metav1.LabelSelectorAsSelector(ref.Selector)
+	if err != nil {
+		return err
+	}
+
+	rClusters, err := i.clusters.List(metav1.ListOptions{LabelSelector: sel.String()})
+	if err != nil {
+		return err
+	}
+
+	var others []string
+	for _, rCluster := range rClusters.Items {
+		ok, err := tenancy.CanReference(namespace, &rCluster, i.listGrants)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			others = append(others, rCluster.Name)
+		}
+	}
+	if len(others) > 0 {
+		sort.Strings(others)
+		return fmt.Errorf("spec.referencedConfig.selector matches management clusters %s of other namespaces that grant no access",
+			strings.Join(others, ", "))
+	}
+	return nil
+}
+
+func (i *Isolation) listGrants(namespace string) ([]*v1.ClusterReferenceGrant, error) {
+	grants, err := i.grants.List(namespace, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var result []*v1.ClusterReferenceGrant
+	for i := range grants.Items {
+		result = append(result, &grants.Items[i])
+	}
+	return result, nil
+}
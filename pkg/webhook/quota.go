@@ -0,0 +1,66 @@
+package webhook
+
+import (
+	"fmt"
+	"strconv"
+
+	rocontrollers "github.com/rancher/rancher-operator/pkg/generated/controllers/rancher.cattle.io/v1"
+	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// QuotaAnnotation on a namespace overrides the operator wide limit of clusters in it, 0 is unlimited
+const QuotaAnnotation = "rancher.cattle.io/cluster-quota"
+
+// Quota limits the number of clusters created per namespace. The webhook runs on every replica, not only the
+// leader whose caches are started, so it reads from the API.
+type Quota struct {
+	defaultLimit int
+	clusters     rocontrollers.ClusterClient
+	namespaces   corecontrollers.NamespaceClient
+}
+
+func NewQuota(defaultLimit int, clusters rocontrollers.ClusterClient, namespaces corecontrollers.NamespaceClient) *Quota {
+	return &Quota{
+		defaultLimit: defaultLimit,
+		clusters:     clusters,
+		namespaces:   namespaces,
+	}
+}
+
+func (q *Quota) admit(req *admissionv1.AdmissionRequest) ([]patchOperation, error) {
+	if req.Operation != admissionv1.Create {
+		return nil, nil
+	}
+
+	limit, err := q.limit(req.Namespace)
+	if err != nil || limit <= 0 {
+		return nil, err
+	}
+
+	clusters, err := q.clusters.List(req.Namespace, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if len(clusters.Items) >= limit {
+		return nil, fmt.Errorf("namespace %s is limited to %d cluster(s) by its %s quota", req.Namespace, limit, QuotaAnnotation)
+	}
+	return nil, nil
+}
+
+func (q *Quota) limit(namespace string) (int, error) {
+	ns, err := q.namespaces.Get(namespace, metav1.GetOptions{})
+	if err != nil {
+		return 0, err
+	}
+	value, ok := ns.Annotations[QuotaAnnotation]
+	if !ok {
+		return q.defaultLimit, nil
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s annotation on namespace %s: %w", QuotaAnnotation, namespace, err)
+	}
+	return limit, nil
+}
@@ -0,0 +1,121 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+
+	"github.com/rancher/rancher-operator/pkg/operatorconfig"
+	"github.com/sirupsen/logrus"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ValidateClusterPath = "/validate-rancher-cattle-io-v1-cluster"
+	MutateClusterPath   = "/mutate-rancher-cattle-io-v1-cluster"
+)
+
+// admitFunc handles a single admission request, a nil error allows it with the patch applied
+type admitFunc func(req *admissionv1.AdmissionRequest) ([]patchOperation, error)
+
+// patchOperation is a JSON patch operation
+type patchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// Serve serves the admission webhooks over TLS on address until ctx is done, certDir holds tls.crt and tls.key
+func Serve(ctx context.Context, address, certDir string, config *operatorconfig.Config, quota *Quota, isolation *Isolation) {
+	mux := http.NewServeMux()
+	mux.Handle(ValidateClusterPath, handler(chain(validateCluster, quota.admit, isolation.admit)))
+	mux.Handle(MutateClusterPath, handler(defaulter(config)))
+	mux.Handle(ConvertClusterPath, convertHandler())
+	server := &http.Server{
+		Addr:    address,
+		Handler: mux,
+	}
+
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+
+	go func() {
+		err := server.ListenAndServeTLS(filepath.Join(certDir, "tls.crt"), filepath.Join(certDir, "tls.key"))
+		if err != nil && err != http.ErrServerClosed {
+			logrus.Errorf("webhook server failed: %v", err)
+		}
+	}()
+}
+
+// chain runs admits in order until one of them fails
+func chain(admits ...admitFunc) admitFunc {
+	return func(req *admissionv1.AdmissionRequest) ([]patchOperation, error) {
+		var patch []patchOperation
+		for _, admit := range admits {
+			next, err := admit(req)
+			if err != nil {
+				return nil, err
+			}
+			patch = append(patch, next...)
+		}
+		return patch, nil
+	}
+}
+
+func handler(admit admitFunc) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		review := &admissionv1.AdmissionReview{}
+		if err := json.NewDecoder(req.Body).Decode(review); err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if review.Request == nil {
+			http.Error(rw, "missing admission request", http.StatusBadRequest)
+			return
+		}
+
+		response := &admissionv1.AdmissionResponse{
+			UID:     review.Request.UID,
+			Allowed: true,
+		}
+		patch, err := admit(review.Request)
+		if err == nil && len(patch) > 0 {
+			response.Patch, err = json.Marshal(patch)
+			patchType := admissionv1.PatchTypeJSONPatch
+			response.PatchType = &patchType
+		}
+		if err != nil {
+			response.Allowed = false
+			response.Patch = nil
+			response.PatchType = nil
+			response.Result = &metav1.Status{
+				Status:  metav1.StatusFailure,
+				Message: err.Error(),
+				Reason:  metav1.StatusReasonInvalid,
+				Code:    http.StatusUnprocessableEntity,
+			}
+		}
+
+		review.Response = response
+		review.Request = nil
+		rw.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(rw).Encode(review); err != nil {
+			logrus.Errorf("failed to write admission response: %v", err)
+		}
+	})
+}
+
+func decode(raw []byte, obj interface{}) error {
+	if len(raw) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(raw, obj); err != nil {
+		return fmt.Errorf("failed to decode object: %w", err)
+	}
+	return nil
+}